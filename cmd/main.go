@@ -13,6 +13,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/midedickson/github-service/config"
 	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/entity"
 	"github.com/midedickson/github-service/interface/controllers"
 	"github.com/midedickson/github-service/interface/database"
 	tasks "github.com/midedickson/github-service/interface/task-manager"
@@ -29,32 +30,125 @@ func main() {
 
 	database.ConnectToDB(dbUrl)
 	database.AutoMigrate()
-	// Use a WaitGroup to manage goroutines
+	// Use a WaitGroup to manage goroutines, and a context to stop them on shutdown
 	var wg sync.WaitGroup
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+
+	// authenticate as a GitHub App when one is configured, with personal tokens for the owners it is not
+	// installed for; otherwise with personal tokens only
+	var credentials requester.CredentialProvider
+	if appID := config.GetGithubAppID(); appID != "" {
+		appCredentials, err := requester.LoadAppCredentialProvider(appID, config.GetGithubAppPrivateKeyPath(), config.GetGithubBaseURL())
+		if err != nil {
+			log.Fatalf("Could not load github app credentials: %v", err)
+		}
+		credentials = appCredentials
+	}
+
+	// every GitHub request acquires budget from this governor
+	rateLimitGovernor := requester.NewGovernor(config.GetInteractiveRateLimitReserve())
+
+	// record every upstream exchange to cassettes, or replay recorded ones to reproduce a sync offline
+	var transport http.RoundTripper
+	if mode := config.GetCassetteMode(); mode != "" {
+		cassettes, err := requester.NewCassetteTransport(mode, config.GetCassetteDir(), nil)
+		if err != nil {
+			log.Fatalf("Could not set up cassettes: %v", err)
+		}
+		transport = cassettes
+		log.Printf("Requester cassettes in %s mode, at %s", mode, config.GetCassetteDir())
+	}
 
-	repoRequester := requester.NewRepositoryRequester()
+	requesterOptions := &requester.Options{
+		BaseURL:        config.GetGithubBaseURL(),
+		UploadURL:      config.GetGithubUploadURL(),
+		GraphQLURL:     config.GetGithubGraphQLURL(),
+		PerPage:        config.GetGithubPerPage(),
+		Tokens:         config.GetGithubTokens(),
+		Credentials:    credentials,
+		Cache:          database.NewSqliteHTTPCacheRepository(database.DB),
+		RequestTimeout: config.GetRequestTimeout(),
+		Governor:       rateLimitGovernor,
+		Retry: requester.RetryPolicy{
+			MaxAttempts: config.GetRetryMaxAttempts(),
+			BaseDelay:   config.GetRetryBaseDelay(),
+			MaxDelay:    config.GetRetryMaxDelay(),
+		},
+		Transport: transport,
+	}
+	// the GraphQL backend batches metadata refreshes and pages through commits with cursors
+	var repoRequester requester.Requester = requester.NewRepositoryRequester(requesterOptions)
+	switch backend := config.GetGithubAPIBackend(); backend {
+	case "", "rest":
+	case "graphql":
+		repoRequester = requester.NewGraphQLRequester(requesterOptions)
+	default:
+		log.Fatalf("Unknown GITHUB_API_BACKEND %q, expected rest or graphql", backend)
+	}
+	// users registered on GitLab are synced through its own requester, sharing the governor and cache
+	gitlabRequester := requester.NewGitLabRequester(&requester.Options{
+		BaseURL:        config.GetGitlabBaseURL(),
+		PerPage:        config.GetGithubPerPage(),
+		Tokens:         config.GetGitlabTokens(),
+		Cache:          requesterOptions.Cache,
+		RequestTimeout: requesterOptions.RequestTimeout,
+		Governor:       rateLimitGovernor,
+		Retry:          requesterOptions.Retry,
+		Transport:      transport,
+	})
+	requesters := map[string]requester.Requester{
+		entity.ProviderGitHub: repoRequester,
+		entity.ProviderGitLab: gitlabRequester,
+	}
+	// there is no public Gitea to fall back to, so users on Gitea are only synced once an instance is configured
+	if giteaURL := config.GetGiteaBaseURL(); giteaURL != "" {
+		requesters[entity.ProviderGitea] = requester.NewGiteaRequester(&requester.Options{
+			BaseURL:        giteaURL,
+			PerPage:        config.GetGithubPerPage(),
+			Tokens:         config.GetGiteaTokens(),
+			Cache:          requesterOptions.Cache,
+			RequestTimeout: requesterOptions.RequestTimeout,
+			Governor:       rateLimitGovernor,
+			Retry:          requesterOptions.Retry,
+			Transport:      transport,
+		})
+	}
+	// clones on disk are read with git itself, without API calls or rate limits
+	if gitRoot := config.GetLocalGitRoot(); gitRoot != "" {
+		requesters[entity.ProviderLocal] = requester.NewLocalGitRequester(gitRoot, config.GetGithubPerPage())
+	}
+	repoRequester = requester.NewMultiRequester(requesters)
 
 	// databasae repositories for each domain/service
 	userRepository := database.NewSqliteUserRepository(database.DB)
 	repoRepository := database.NewSqliteRepoRepository(database.DB)
 	commitRepository := database.NewSqliteCommitRepository(database.DB)
+	branchRepository := database.NewSqliteBranchRepository(database.DB)
+	releaseRepository := database.NewSqliteReleaseRepository(database.DB)
+	pullRequestRepository := database.NewSqlitePullRequestRepository(database.DB)
+	issueRepository := database.NewSqliteIssueRepository(database.DB)
+	contributorRepository := database.NewSqliteContributorRepository(database.DB)
 
 	// commit manager for handling commit discovery and monitoring task execution
-	commitManager := discovery.NewCommitDiscoveryService(repoRepository, repoRequester, commitRepository, config.GetCommitStartDate(), config.GetCommitEndDate())
+	commitManager := discovery.NewCommitDiscoveryService(repoRepository, repoRequester, commitRepository, branchRepository, config.GetCommitStartDate(), config.GetCommitEndDate(), config.GetTrackedBranches())
 
 	// repo discovery for executing tasks relating to finding repositories
-	repoDiscovery := discovery.NewRepositoryDiscoveryService(repoRequester, userRepository, repoRepository, commitRepository, commitManager)
+	repoDiscovery := discovery.NewRepositoryDiscoveryService(repoRequester, userRepository, repoRepository, commitRepository, releaseRepository, pullRequestRepository, issueRepository, contributorRepository, commitManager)
 
 	// task manager for managing the queueing and execution of tasks
-	taskManager := tasks.NewTaskManager(repoDiscovery, commitManager)
+	taskManager := tasks.NewTaskManager(workerCtx, repoDiscovery, commitManager, config.GetJobTimeout(), config.GetUserResyncInterval())
 
 	// Usecase services for each domain/service
 	userUseCase := usecase.NewUserUseCaseService(userRepository, taskManager)
-	repoUseCase := usecase.NewRepoUseCaseService(repoRepository, userUseCase, taskManager)
-	commitUseCase := usecase.NewCommitUseCaseService(commitRepository, repoUseCase, taskManager)
+	repoUseCase := usecase.NewRepoUseCaseService(repoRepository, branchRepository, userUseCase, taskManager)
+	commitUseCase := usecase.NewCommitUseCaseService(commitRepository, repoRepository, userUseCase, repoUseCase, taskManager)
+	releaseUseCase := usecase.NewReleaseUseCaseService(releaseRepository, commitRepository, branchRepository, repoRepository, userUseCase)
+	pullRequestUseCase := usecase.NewPullRequestUseCaseService(pullRequestRepository, repoRepository, userUseCase)
+	issueUseCase := usecase.NewIssueUseCaseService(issueRepository, repoRepository, userUseCase)
+	contributorUseCase := usecase.NewContributorUseCaseService(contributorRepository, commitRepository, repoRepository, userUseCase)
 
 	// creation of application handler
-	controller := controllers.NewController(repoRequester, userUseCase, repoUseCase, commitUseCase)
+	controller := controllers.NewController(repoRequester, rateLimitGovernor, userUseCase, repoUseCase, commitUseCase, releaseUseCase, pullRequestUseCase, issueUseCase, contributorUseCase)
 
 	// Starting goroutines to fetch repositories and check for updates
 	wg.Add(1)
@@ -92,11 +186,8 @@ func main() {
 	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
 	defer cancel()
 
-	// Close channels to signal workers to stop
-	close(taskManager.GetAllRepoForUserQueue)
-	close(taskManager.FetchNewlyRequestedRepoQueue)
-	close(taskManager.CheckForUpdateOnAllRepoQueue)
-	close(taskManager.ResetRepositoryQueue)
+	// Cancel the workers' context to stop them and abort in-flight jobs
+	stopWorkers()
 
 	// Wait for all goroutines to complete
 	wg.Wait()
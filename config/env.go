@@ -3,6 +3,9 @@ package config
 import (
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/joho/godotenv"
 )
@@ -25,3 +28,173 @@ func GetCommitStartDate() string {
 func GetCommitEndDate() string {
 	return os.Getenv("COMMIT_END_DATE")
 }
+
+// GetGithubPerPage returns the page size used for paginated GitHub listings.
+// Zero means the requester should pick its own default.
+func GetGithubPerPage() int {
+	perPage, _ := strconv.Atoi(os.Getenv("GITHUB_PER_PAGE"))
+	return perPage
+}
+
+// GetGithubBaseURL returns the REST API root, empty for the public api.github.com.
+func GetGithubBaseURL() string {
+	return os.Getenv("GITHUB_API_URL")
+}
+
+// GetGithubUploadURL returns the uploads API root, empty for the public uploads.github.com.
+func GetGithubUploadURL() string {
+	return os.Getenv("GITHUB_UPLOAD_URL")
+}
+
+// GetGithubGraphQLURL returns the GraphQL endpoint, empty for the public api.github.com/graphql.
+func GetGithubGraphQLURL() string {
+	return os.Getenv("GITHUB_GRAPHQL_URL")
+}
+
+// GetGithubAPIBackend returns which GitHub API the requester uses, "rest" (the default) or "graphql".
+func GetGithubAPIBackend() string {
+	return os.Getenv("GITHUB_API_BACKEND")
+}
+
+// GetGithubTokens returns the personal access tokens listed in GITHUB_TOKENS
+// (comma separated), falling back to the single GITHUB_TOKEN.
+func GetGithubTokens() []string {
+	tokens := []string{}
+	for _, token := range strings.Split(os.Getenv("GITHUB_TOKENS"), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 && os.Getenv("GITHUB_TOKEN") != "" {
+		tokens = append(tokens, os.Getenv("GITHUB_TOKEN"))
+	}
+	return tokens
+}
+
+// GetGitlabBaseURL returns the GitLab REST API root, e.g. https://gitlab.example.com/api/v4;
+// empty for the public gitlab.com.
+func GetGitlabBaseURL() string {
+	return os.Getenv("GITLAB_API_URL")
+}
+
+// GetGitlabTokens returns the GitLab personal access tokens listed in GITLAB_TOKENS
+// (comma separated), falling back to the single GITLAB_TOKEN.
+func GetGitlabTokens() []string {
+	tokens := []string{}
+	for _, token := range strings.Split(os.Getenv("GITLAB_TOKENS"), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 && os.Getenv("GITLAB_TOKEN") != "" {
+		tokens = append(tokens, os.Getenv("GITLAB_TOKEN"))
+	}
+	return tokens
+}
+
+// GetGiteaBaseURL returns the API root of the Gitea or Forgejo instance, e.g.
+// https://gitea.example.com/api/v1; empty when users on Gitea are not synced.
+func GetGiteaBaseURL() string {
+	return os.Getenv("GITEA_API_URL")
+}
+
+// GetGiteaTokens returns the Gitea access tokens listed in GITEA_TOKENS
+// (comma separated), falling back to the single GITEA_TOKEN.
+func GetGiteaTokens() []string {
+	tokens := []string{}
+	for _, token := range strings.Split(os.Getenv("GITEA_TOKENS"), ",") {
+		if token = strings.TrimSpace(token); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 && os.Getenv("GITEA_TOKEN") != "" {
+		tokens = append(tokens, os.Getenv("GITEA_TOKEN"))
+	}
+	return tokens
+}
+
+// GetLocalGitRoot returns the directory holding git clones as {owner}/{repo}.git, read for
+// users registered with the local provider; empty when there is none.
+func GetLocalGitRoot() string {
+	return os.Getenv("LOCAL_GIT_ROOT")
+}
+
+// GetCassetteMode returns "record" to save every upstream request and response to cassettes,
+// "replay" to serve them back instead of calling upstream, or empty to call upstream as usual.
+func GetCassetteMode() string {
+	return os.Getenv("CASSETTE_MODE")
+}
+
+// GetCassetteDir returns the directory cassettes are recorded to and replayed from.
+func GetCassetteDir() string {
+	if dir := os.Getenv("CASSETTE_DIR"); dir != "" {
+		return dir
+	}
+	return "cassettes"
+}
+
+// GetGithubAppID returns the ID of the GitHub App to authenticate as, empty to use personal tokens.
+func GetGithubAppID() string {
+	return os.Getenv("GITHUB_APP_ID")
+}
+
+// GetGithubAppPrivateKeyPath returns the path of the GitHub App's PEM encoded private key.
+func GetGithubAppPrivateKeyPath() string {
+	return os.Getenv("GITHUB_APP_PRIVATE_KEY_PATH")
+}
+
+// GetRequestTimeout bounds a single GitHub API call, e.g. "30s"; zero uses the requester default.
+func GetRequestTimeout() time.Duration {
+	timeout, _ := time.ParseDuration(os.Getenv("REQUEST_TIMEOUT"))
+	return timeout
+}
+
+// GetJobTimeout bounds a single background job, e.g. "30m"; zero leaves jobs unbounded.
+func GetJobTimeout() time.Duration {
+	timeout, _ := time.ParseDuration(os.Getenv("JOB_TIMEOUT"))
+	return timeout
+}
+
+// GetUserResyncInterval is the least time between two syncs of one owner's repositories, e.g. "10m";
+// zero uses the task manager default.
+func GetUserResyncInterval() time.Duration {
+	interval, _ := time.ParseDuration(os.Getenv("USER_RESYNC_INTERVAL"))
+	return interval
+}
+
+// GetInteractiveRateLimitReserve returns how many requests per credential background
+// syncs leave for interactive work; zero uses the requester default.
+func GetInteractiveRateLimitReserve() int {
+	reserve, _ := strconv.Atoi(os.Getenv("RATE_LIMIT_INTERACTIVE_RESERVE"))
+	return reserve
+}
+
+// GetRetryMaxAttempts caps the attempts made for one GitHub API call; zero uses the requester default.
+func GetRetryMaxAttempts() int {
+	attempts, _ := strconv.Atoi(os.Getenv("RETRY_MAX_ATTEMPTS"))
+	return attempts
+}
+
+// GetRetryBaseDelay is the backoff before the first retry, e.g. "1s"; zero uses the requester default.
+func GetRetryBaseDelay() time.Duration {
+	delay, _ := time.ParseDuration(os.Getenv("RETRY_BASE_DELAY"))
+	return delay
+}
+
+// GetRetryMaxDelay caps the backoff between retries, e.g. "1m"; zero uses the requester default.
+func GetRetryMaxDelay() time.Duration {
+	delay, _ := time.ParseDuration(os.Getenv("RETRY_MAX_DELAY"))
+	return delay
+}
+
+// GetTrackedBranches returns the branches listed in TRACKED_BRANCHES (comma separated) whose
+// commits are synced; empty syncs each repository's default branch, "*" every branch.
+func GetTrackedBranches() []string {
+	branches := []string{}
+	for _, branch := range strings.Split(os.Getenv("TRACKED_BRANCHES"), ",") {
+		if branch = strings.TrimSpace(branch); branch != "" {
+			branches = append(branches, branch)
+		}
+	}
+	return branches
+}
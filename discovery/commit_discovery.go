@@ -1,99 +1,204 @@
 package discovery
 
 import (
+	"context"
+	"errors"
 	"log"
 
 	"github.com/midedickson/github-service/dto"
 	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
 	"github.com/midedickson/github-service/interface/repository"
 	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
 )
 
 type CommitDiscoveryService struct {
 	repoRepository   repository.RepoRepository
 	commitRepository repository.CommitRepository
+	branchRepository repository.BranchRepository
 	requester        requester.Requester
 	startDateLimit   string
 	endDateLimit     string
+	// trackedBranches lists the branches whose commits are synced; empty tracks the default branch only, "*" every branch
+	trackedBranches []string
 }
 
 func NewCommitDiscoveryService(repoRepository repository.RepoRepository,
 	requester requester.Requester,
-	commitRepository repository.CommitRepository, startDateLimit, endDateLimit string) *CommitDiscoveryService {
+	commitRepository repository.CommitRepository,
+	branchRepository repository.BranchRepository,
+	startDateLimit, endDateLimit string,
+	trackedBranches []string) *CommitDiscoveryService {
 	return &CommitDiscoveryService{
 		repoRepository:   repoRepository,
 		commitRepository: commitRepository,
+		branchRepository: branchRepository,
 		requester:        requester,
 		startDateLimit:   startDateLimit,
 		endDateLimit:     endDateLimit,
+		trackedBranches:  trackedBranches,
 	}
 }
 
-func (cd *CommitDiscoveryService) GetLatestCommitSHAInRepository(repoName string) (string, error) {
-	mostRecentCommit, err := cd.commitRepository.GetMostRecentCommitInRepository(repoName)
-	if err != nil {
-		return "", err
-	}
-	if mostRecentCommit != nil {
-		return mostRecentCommit.SHA, nil
-	}
-	return "", nil
-}
+// errBranchCaughtUp stops paging through a branch's commits once the listing reaches commits synced before.
+var errBranchCaughtUp = errors.New("branch caught up with last sync")
 
-func (cd *CommitDiscoveryService) CheckForNewCommits(repo *entity.Repository) error {
+func (cd *CommitDiscoveryService) CheckForNewCommits(ctx context.Context, repo *entity.Repository) error {
 	log.Printf("fetching new repository commits for repo: %s...", repo.Name)
-	mostRecentSHA, err := cd.GetLatestCommitSHAInRepository(repo.Name)
-	if err != nil {
-		log.Printf("Error in fetching most recent commit SHA: %v", err)
-		return err
-	}
-	newRemoteCommits, err := cd.requester.GetRepositoryCommits(repo.Owner.Username, repo.Name, &dto.CommitQueryParams{SHA: mostRecentSHA, Since: cd.startDateLimit, Until: cd.endDateLimit})
+	return cd.syncRepository(withProvider(ctx, repo.Owner), repo)
+}
+
+func (cd *CommitDiscoveryService) GetCommitsForNewRepo(ctx context.Context, repo *entity.Repository) error {
+	log.Printf("fetching repository commits for repo: %s...", repo.Name)
+	return cd.syncRepository(withProvider(ctx, repo.Owner), repo)
+}
+
+// syncRepository stores the repository's branches and tags, syncs the commits of
+// every tracked branch that moved since it was last synced, then fills in the stats
+// of the commits that were new.
+func (cd *CommitDiscoveryService) syncRepository(ctx context.Context, repo *entity.Repository) error {
+	err := cd.requester.GetRepositoryBranches(ctx, repo.Owner.Username, repo.Name, func(remoteBranches *[]dto.BranchResponseDTO) error {
+		for _, remoteBranch := range *remoteBranches {
+			branch, err := cd.branchRepository.StoreBranch(repo.ID, &remoteBranch)
+			if err != nil {
+				return err
+			}
+			if !cd.isTracked(repo, branch.Name) {
+				continue
+			}
+			if branch.SyncedSHA == branch.SHA {
+				log.Printf("Branch %s of repo %s has no new commits", branch.Name, repo.Name)
+				continue
+			}
+			if err := cd.syncBranchCommits(ctx, repo, branch); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 	if err != nil {
-		log.Printf("Error in fetching new commits: %v", err)
+		log.Printf("Error in syncing branches: %v", err)
 		return err
 	}
-	err = cd.commitRepository.StoreRepositoryCommits(newRemoteCommits, repo.Name, repo.Owner)
+	err = cd.requester.GetRepositoryTags(ctx, repo.Owner.Username, repo.Name, func(remoteTags *[]dto.TagResponseDTO) error {
+		return cd.branchRepository.StoreTags(repo.ID, remoteTags)
+	})
 	if err != nil {
-		log.Printf("Error in saving new commits: %v", err)
+		log.Printf("Error in syncing tags: %v", err)
 		return err
 	}
-	return nil
+	return cd.FetchMissingCommitStats(ctx, repo)
 }
 
-func (cd *CommitDiscoveryService) GetCommitsForNewRepo(repo *entity.Repository) error {
-	log.Printf("fetching repository commits for repo: %s...", repo.Name)
-	remoteCommits, err := cd.requester.GetRepositoryCommits(repo.Owner.Username, repo.Name, &dto.CommitQueryParams{Since: cd.startDateLimit, Until: cd.endDateLimit})
-	if err != nil {
-		log.Printf("Error in fetching commits: %v", err)
-		return err
+func (cd *CommitDiscoveryService) isTracked(repo *entity.Repository, branchName string) bool {
+	if len(cd.trackedBranches) == 0 {
+		// repositories stored before default branches were recorded track every branch until their next update
+		return repo.DefaultBranch == "" || branchName == repo.DefaultBranch
 	}
-	cd.UpdateAuthorCountInNewCommits(*remoteCommits)
-	err = cd.commitRepository.StoreRepositoryCommits(remoteCommits, repo.Name, repo.Owner)
-	if err != nil {
-		log.Printf("Error in saving commits: %v", err)
+	for _, tracked := range cd.trackedBranches {
+		if tracked == "*" || tracked == branchName {
+			return true
+		}
+	}
+	return false
+}
+
+// syncBranchCommits pages through the commits on branch from its current head,
+// storing those we do not have yet and recording that each is on the branch. Paging
+// stops at the head of the last sync, as everything older was recorded then.
+func (cd *CommitDiscoveryService) syncBranchCommits(ctx context.Context, repo *entity.Repository, branch *database.Branch) error {
+	log.Printf("syncing commits on branch %s of repo %s...", branch.Name, repo.Name)
+	queryParams := &dto.CommitQueryParams{SHA: branch.SHA, Since: cd.startDateLimit, Until: cd.endDateLimit}
+	err := cd.requester.GetRepositoryCommits(ctx, repo.Owner.Username, repo.Name, queryParams, func(remoteCommits *[]dto.CommitResponseDTO) error {
+		caughtUp := false
+		shas := make([]string, 0, len(*remoteCommits))
+		for i, commit := range *remoteCommits {
+			if commit.SHA == branch.SyncedSHA {
+				*remoteCommits = (*remoteCommits)[:i]
+				caughtUp = true
+				break
+			}
+			shas = append(shas, commit.SHA)
+		}
+		newCommits, err := cd.commitRepository.StoreRepositoryCommits(remoteCommits, repo.Name, repo.Owner)
+		if err != nil {
+			log.Printf("Error in saving commits: %v", err)
+			return err
+		}
+		// a commit already stored from another branch is only counted once
+		cd.UpdateAuthorCountInNewCommits(newCommits)
+		if err := cd.branchRepository.AddCommitsToBranch(repo.ID, branch.Name, shas); err != nil {
+			return err
+		}
+		if caughtUp {
+			return errBranchCaughtUp
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errBranchCaughtUp) {
+		log.Printf("Error in fetching commits on branch %s: %v", branch.Name, err)
 		return err
 	}
-	return nil
+	return cd.branchRepository.MarkBranchSynced(branch, branch.SHA)
 }
 
-func (cd *CommitDiscoveryService) UpdateAuthorCountInNewCommits(remoteCommits []dto.CommitResponseDTO) {
+func (cd *CommitDiscoveryService) UpdateAuthorCountInNewCommits(newCommits []*database.Commit) {
+	// commits are counted by author identity; the most recent commit of an author names them
 	authorCommitCounts := make(map[string]int)
-	for _, c := range remoteCommits {
-		_, ok := authorCommitCounts[c.Author]
+	authors := make(map[string]entity.AuthorIdentity)
+	for _, c := range newCommits {
+		author := c.AuthorIdentity()
+		_, ok := authorCommitCounts[author.Key]
 		if !ok {
-			authorCommitCounts[c.Author] = 1
+			authorCommitCounts[author.Key] = 1
+			authors[author.Key] = author
 		} else {
-			authorCommitCounts[c.Author]++
+			authorCommitCounts[author.Key]++
 		}
 	}
-	for author := range authorCommitCounts {
-		cd.commitRepository.AddAuthorCommitCount(author, authorCommitCounts[author])
+	for key, author := range authors {
+		cd.commitRepository.AddAuthorCommitCount(author, authorCommitCounts[key])
 	}
 }
 
-func (cd *CommitDiscoveryService) ResetCommitToSHA(repoName, resetSha string) error {
+// FetchMissingCommitStats fetches the line stats and changed files of every stored
+// commit in repo that does not have them yet. The commit listing leaves them out,
+// so each commit costs a request of its own.
+func (cd *CommitDiscoveryService) FetchMissingCommitStats(ctx context.Context, repo *entity.Repository) error {
+	commits, err := cd.commitRepository.GetCommitsWithoutStats(repo.ID)
+	if err != nil {
+		log.Printf("Error in fetching commits without stats: %v", err)
+		return err
+	}
+	log.Printf("fetching stats for %d commits in repo: %s...", len(commits), repo.Name)
+	ctx = withProvider(ctx, repo.Owner)
+	for _, commit := range commits {
+		commitDetail, err := cd.requester.GetCommit(ctx, repo.Owner.Username, repo.Name, commit.SHA)
+		if errors.Is(err, utils.ErrRepoNotFound) {
+			// the commit is gone from GitHub, e.g. after a force push
+			log.Printf("Commit %s no longer exists in repo %s; skipping", commit.SHA, repo.Name)
+			continue
+		}
+		if err != nil {
+			log.Printf("Error in fetching stats of commit %s: %v", commit.SHA, err)
+			return err
+		}
+		if err := cd.commitRepository.StoreCommitStats(repo.ID, commitDetail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (cd *CommitDiscoveryService) ResetCommitToSHA(ctx context.Context, repoResetRequest *dto.RepoResetRequest) error {
+	repoName, resetSha := repoResetRequest.RepoName, repoResetRequest.ResetSHA
 	log.Printf("resetting commits for repo: %s to SHA: %s...", repoName, resetSha)
-	err := cd.commitRepository.DeleteUntilSHA(repoName, resetSha)
+	if err := ctx.Err(); err != nil {
+		log.Printf("Skipping reset of repo %s: %v", repoName, err)
+		return err
+	}
+	err := cd.commitRepository.DeleteUntilSHA(repoResetRequest.RepoID, resetSha)
 	if err != nil {
 		log.Printf("Error in resetting commits: %v", err)
 		return err
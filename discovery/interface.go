@@ -1,6 +1,7 @@
 package discovery
 
 import (
+	"context"
 	"sync"
 
 	"github.com/midedickson/github-service/dto"
@@ -8,13 +9,13 @@ import (
 )
 
 type RepositoryDiscovery interface {
-	GetAllUserRepositories(user *entity.User)
-	FetchNewlyRequestedRepo(repoRequest *dto.RepoRequest, wg *sync.WaitGroup)
-	CheckForUpdateOnAllRepo() error
+	GetAllUserRepositories(ctx context.Context, user *entity.User)
+	FetchNewlyRequestedRepo(ctx context.Context, repoRequest *dto.RepoRequest, wg *sync.WaitGroup) error
+	CheckForUpdateOnAllRepo(ctx context.Context) error
 }
 
 type CommitDiscovery interface {
-	CheckForNewCommits(repo *entity.Repository) error
-	GetCommitsForNewRepo(repo *entity.Repository) error
-	ResetCommitToSHA(repoName, resetSha string) error
+	CheckForNewCommits(ctx context.Context, repo *entity.Repository) error
+	GetCommitsForNewRepo(ctx context.Context, repo *entity.Repository) error
+	ResetCommitToSHA(ctx context.Context, repoResetRequest *dto.RepoResetRequest) error
 }
@@ -1,84 +1,165 @@
 package discovery
 
 import (
+	"context"
+	"errors"
 	"log"
 	"sync"
 	"time"
 
 	"github.com/midedickson/github-service/dto"
 	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
 	"github.com/midedickson/github-service/interface/repository"
 	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
 )
 
 type RepositoryDiscoveryService struct {
-	requester        requester.Requester
-	userRepository   repository.UserRepository
-	repoRepository   repository.RepoRepository
-	commitRepository repository.CommitRepository
-	commitManager    CommitDiscovery
+	requester             requester.Requester
+	userRepository        repository.UserRepository
+	repoRepository        repository.RepoRepository
+	commitRepository      repository.CommitRepository
+	releaseRepository     repository.ReleaseRepository
+	pullRequestRepository repository.PullRequestRepository
+	issueRepository       repository.IssueRepository
+	contributorRepository repository.ContributorRepository
+	commitManager         CommitDiscovery
 }
 
 func NewRepositoryDiscoveryService(requester requester.Requester,
 	userRepository repository.UserRepository,
 	repoRepository repository.RepoRepository,
 	commitRepository repository.CommitRepository,
+	releaseRepository repository.ReleaseRepository,
+	pullRequestRepository repository.PullRequestRepository,
+	issueRepository repository.IssueRepository,
+	contributorRepository repository.ContributorRepository,
 	commitManager CommitDiscovery,
 ) *RepositoryDiscoveryService {
 	return &RepositoryDiscoveryService{
-		requester:        requester,
-		userRepository:   userRepository,
-		repoRepository:   repoRepository,
-		commitRepository: commitRepository,
-		commitManager:    commitManager,
+		requester:             requester,
+		userRepository:        userRepository,
+		repoRepository:        repoRepository,
+		commitRepository:      commitRepository,
+		releaseRepository:     releaseRepository,
+		pullRequestRepository: pullRequestRepository,
+		issueRepository:       issueRepository,
+		contributorRepository: contributorRepository,
+		commitManager:         commitManager,
 	}
 }
 
-func (rd *RepositoryDiscoveryService) GetAllUserRepositories(user *entity.User) {
+func (rd *RepositoryDiscoveryService) GetAllUserRepositories(ctx context.Context, user *entity.User) {
 	//  logic to fetch all repositories for the given user
 	// re-comfirm that this user is still in our database
-	dbUser, _ := rd.userRepository.GetUser(user.Username)
+	dbUser, _ := rd.userRepository.GetUser(user.Handle())
 	if dbUser == nil {
-		log.Printf("User %v not found in database", user.Username)
+		log.Printf("User %v not found in database", user.Handle())
 		return
 	}
-	// Fetch all repositories for the user
-	userRepositories, err := rd.requester.GetAllUserRepositories(user.Username)
-	if err != nil {
-		log.Printf("Error in fetching repositories for user %v: %v", user.Username, err)
-		return
+	ctx = withProvider(ctx, user)
+	listRepositories := rd.requester.GetAllUserRepositories
+	if rd.ownerType(ctx, dbUser) == entity.OwnerTypeOrganization {
+		listRepositories = rd.requester.GetAllOrganizationRepositories
 	}
-	// using a go routine to optimize the saving of repositories and fetching the repo  commits
+	// Fetch all repositories for the user, handling each page as soon as it arrives
 	// this will help the worker process tasks from the channel faster for users at scale
-	for _, newRepoInfo := range *userRepositories {
-		repo, err := rd.repoRepository.StoreRepositoryInfo(&newRepoInfo, user)
-		if err != nil {
-			log.Printf("Error in storing repository: %v", err)
-			continue
+	err := listRepositories(ctx, user.Username, func(userRepositories *[]dto.RepositoryInfoResponseDTO) error {
+		for _, newRepoInfo := range *userRepositories {
+			repo, err := rd.repoRepository.StoreRepositoryInfo(&newRepoInfo, user)
+			if err != nil {
+				log.Printf("Error in storing repository: %v", err)
+				continue
+			}
+			rd.commitManager.CheckForNewCommits(ctx, repo.ToEntity())
+			rd.SyncLanguages(ctx, repo.ToEntity(), newRepoInfo.UpdatedAt)
+			rd.SyncContributors(ctx, repo.ToEntity(), newRepoInfo.UpdatedAt)
+			if !isRateLimited(user) {
+				continue
+			}
+			// sleep to imitate more processing for each added repository, this also helps testing without trigerring the rate limiter
+			if err := utils.SleepWithContext(ctx, 3*time.Minute); err != nil {
+				return err
+			}
 		}
-		rd.commitManager.CheckForNewCommits(repo.ToEntity())
-		// time.sleep to imitate more processing for each added repository, this also helps testing without trigerring the rate limiter
-		time.Sleep(3 * time.Minute)
+		return nil
+	})
+	if err != nil {
+		log.Printf("Error in fetching repositories for user %v: %v", user.Username, err)
+		return
 	}
 	log.Printf("Gotten repositories for user %v", user)
 }
 
-func (rd *RepositoryDiscoveryService) FetchNewlyRequestedRepo(repoRequest *dto.RepoRequest, wg *sync.WaitGroup) {
+// ownerType returns whether owner is a user or an organization, asking their forge the first time
+// and remembering the answer. Owners on forges that cannot tell are treated as users.
+func (rd *RepositoryDiscoveryService) ownerType(ctx context.Context, owner *database.User) string {
+	if owner.OwnerType != "" {
+		return owner.OwnerType
+	}
+	ownerType := entity.OwnerTypeUser
+	account, err := rd.requester.GetOwner(ctx, owner.Username)
+	switch {
+	case errors.Is(err, utils.ErrNotSupported):
+	case err != nil:
+		// ask again on the next sync rather than remember a guess
+		log.Printf("Error looking up owner %v: %v", owner.Username, err)
+		return ownerType
+	case account.Type == dto.OwnerTypeOrganization:
+		ownerType = entity.OwnerTypeOrganization
+	}
+	if err := rd.userRepository.SetOwnerType(owner.ID, ownerType); err != nil {
+		log.Printf("Error in storing owner type of %v: %v", owner.Username, err)
+	}
+	owner.OwnerType = ownerType
+	return ownerType
+}
+
+func (rd *RepositoryDiscoveryService) FetchNewlyRequestedRepo(ctx context.Context, repoRequest *dto.RepoRequest, wg *sync.WaitGroup) error {
 	//  logic to fetch a newly requested repo and commits for the given repository
 	defer wg.Done()
 	log.Println("waiting for newly requested repos...")
 
-	remoteRepoInfo, err := rd.requester.GetRepositoryInfo(repoRequest.Username, repoRequest.RepoName)
+	// the request names its owner by handle, which says which forge to ask
+	username, provider := entity.ParseOwner(repoRequest.Username)
+	ctx = requester.WithProvider(ctx, provider)
+	remoteRepoInfo, err := rd.requester.GetRepositoryInfo(ctx, username, repoRequest.RepoName)
 	if err != nil {
 		log.Printf("Error getting repository info: %v", err)
-		return
+		return err
+	}
+	user, err := rd.userRepository.GetUser(repoRequest.Username)
+	if err != nil {
+		return err
 	}
-	user, _ := rd.userRepository.GetUser(repoRequest.Username)
-	repo, _ := rd.repoRepository.StoreRepositoryInfo(remoteRepoInfo, user.ToEntity())
-	rd.commitManager.GetCommitsForNewRepo(repo.ToEntity())
+	if user == nil {
+		return utils.ErrUserNotFound
+	}
+	repo, err := rd.repoRepository.StoreRepositoryInfo(remoteRepoInfo, user.ToEntity())
+	if err != nil {
+		log.Printf("Error in storing repository: %v", err)
+		return err
+	}
+	if err := rd.commitManager.GetCommitsForNewRepo(ctx, repo.ToEntity()); err != nil {
+		return err
+	}
+	if err := rd.SyncReleases(ctx, repo.ToEntity()); err != nil {
+		return err
+	}
+	if err := rd.SyncPullRequests(ctx, repo.ToEntity()); err != nil {
+		return err
+	}
+	if err := rd.SyncIssues(ctx, repo.ToEntity()); err != nil {
+		return err
+	}
+	if err := rd.SyncLanguages(ctx, repo.ToEntity(), remoteRepoInfo.UpdatedAt); err != nil {
+		return err
+	}
+	return rd.SyncContributors(ctx, repo.ToEntity(), remoteRepoInfo.UpdatedAt)
 }
 
-func (rd *RepositoryDiscoveryService) CheckForUpdateOnAllRepo() error {
+func (rd *RepositoryDiscoveryService) CheckForUpdateOnAllRepo(ctx context.Context) error {
 	//  logic to check for updates on all repositories in the database
 
 	allRepos, err := rd.repoRepository.GetAllRepositories()
@@ -87,22 +168,253 @@ func (rd *RepositoryDiscoveryService) CheckForUpdateOnAllRepo() error {
 		return err
 	}
 
+	prefetched := rd.prefetchRepositoryInfo(ctx, allRepos)
+
 	for _, repo := range allRepos {
 
 		log.Printf("Checking for updates on repo: %s...", repo.Name)
-		remoteRepoInfo, err := rd.requester.GetRepositoryInfo(repo.Owner.Username, repo.Name)
-		if err != nil {
-			log.Printf("Error in fetching repository info: %v", err)
-			continue
+		// publishing a release, merging a pull request or closing an issue does not always change
+		// the repository itself, so all three are synced on every check
+		if err := rd.SyncReleases(ctx, repo.ToEntity()); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := rd.SyncPullRequests(ctx, repo.ToEntity()); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err := rd.SyncIssues(ctx, repo.ToEntity()); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		remoteRepoInfo, batched := prefetched[repo.ID]
+		if !batched {
+			remoteRepoInfo, err = rd.requester.GetRepositoryInfo(withProvider(ctx, repo.Owner.ToEntity()), repo.Owner.Username, repo.Name)
+			if err != nil {
+				log.Printf("Error in fetching repository info: %v", err)
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				continue
+			}
+		}
+		// an unchanged repository still has its languages and contributors fetched once, if they never were
+		if err := rd.SyncLanguages(ctx, repo.ToEntity(), remoteRepoInfo.UpdatedAt); err != nil && ctx.Err() != nil {
+			return ctx.Err()
 		}
-		if repo.RemoteUpdatedAt != remoteRepoInfo.UpdatedAt {
+		if err := rd.SyncContributors(ctx, repo.ToEntity(), remoteRepoInfo.UpdatedAt); err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// a 304 only says GitHub's copy is the one we were last sent, not that we stored it, so the
+		// record is compared with it instead; that also fills in fields added since the record was stored
+		if !repo.Matches(remoteRepoInfo) {
 			_, err = rd.repoRepository.StoreRepositoryInfo(remoteRepoInfo, repo.Owner.ToEntity())
 			if err != nil {
 				log.Printf("Error in updating repository: %v", err)
 			}
 		}
+		if remoteRepoInfo.NotModified {
+			// unchanged since the last check; the conditional request cost no rate limit, so move straight on
+			log.Printf("Repo %s not modified since last check", repo.Name)
+			continue
+		}
+		if batched || !isRateLimited(repo.Owner.ToEntity()) {
+			// the metadata came in a batch or from disk, so there are no per-repository requests to spread out
+			continue
+		}
 		// simulate more processing to reduce wasting ratelimit requests during tests
-		time.Sleep(90 * time.Second)
+		if err := utils.SleepWithContext(ctx, 90*time.Second); err != nil {
+			return err
+		}
 	}
 	return nil
 }
+
+// prefetchRepositoryInfo fetches the metadata of every repository owner by owner when the
+// requester can batch it, keyed by repository ID. It leaves out the owners whose forge cannot
+// batch and those whose batch failed, so those repositories are fetched one at a time.
+func (rd *RepositoryDiscoveryService) prefetchRepositoryInfo(ctx context.Context, repos []*database.Repository) map[uint]*dto.RepositoryInfoResponseDTO {
+	prefetched := make(map[uint]*dto.RepositoryInfoResponseDTO)
+	batchRequester, ok := rd.requester.(requester.BatchRequester)
+	if !ok {
+		return prefetched
+	}
+	// the same username on two forges is two owners, so repositories are grouped by handle
+	byOwner := make(map[string][]*database.Repository)
+	for _, repo := range repos {
+		handle := repo.Owner.ToEntity().Handle()
+		byOwner[handle] = append(byOwner[handle], repo)
+	}
+	for handle, ownerRepos := range byOwner {
+		owner := ownerRepos[0].Owner.ToEntity()
+		names := make([]string, len(ownerRepos))
+		for i, repo := range ownerRepos {
+			names[i] = repo.Name
+		}
+		infos, err := batchRequester.GetRepositoriesInfo(withProvider(ctx, owner), owner.Username, names)
+		if errors.Is(err, utils.ErrNotSupported) {
+			continue
+		}
+		if err != nil {
+			log.Printf("Error in fetching repository info for %s in a batch: %v", handle, err)
+			continue
+		}
+		for _, repo := range ownerRepos {
+			if info, ok := infos[repo.Name]; ok {
+				prefetched[repo.ID] = info
+			}
+		}
+	}
+	return prefetched
+}
+
+// SyncReleases stores every release of repo, updating those already stored. Like the other
+// syncs below, it does nothing for repositories on forges whose releases are not fetched.
+func (rd *RepositoryDiscoveryService) SyncReleases(ctx context.Context, repo *entity.Repository) error {
+	log.Printf("syncing releases for repo: %s...", repo.Name)
+	err := rd.requester.GetRepositoryReleases(withProvider(ctx, repo.Owner), repo.Owner.Username, repo.Name, func(remoteReleases *[]dto.ReleaseResponseDTO) error {
+		return rd.releaseRepository.StoreReleases(remoteReleases, repo)
+	})
+	if errors.Is(err, utils.ErrNotSupported) {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error in syncing releases for repo %s: %v", repo.Name, err)
+	}
+	return err
+}
+
+// errPullRequestsCaughtUp stops paging through pull requests once the listing reaches those unchanged since the last sync.
+var errPullRequestsCaughtUp = errors.New("pull requests caught up with last sync")
+
+// SyncPullRequests stores the pull requests of repo that were opened or changed since the last
+// complete sync. The listing is newest-updated first, so the sync only moves past a pull request
+// once every page down to it has been stored; a sync that fails partway starts over next time.
+func (rd *RepositoryDiscoveryService) SyncPullRequests(ctx context.Context, repo *entity.Repository) error {
+	log.Printf("syncing pull requests for repo: %s...", repo.Name)
+	lastSync := repo.PullRequestsSyncedAt
+	newestUpdate := lastSync
+	err := rd.requester.GetRepositoryPullRequests(withProvider(ctx, repo.Owner), repo.Owner.Username, repo.Name, func(remotePullRequests *[]dto.PullRequestResponseDTO) error {
+		caughtUp := false
+		for i, pullRequest := range *remotePullRequests {
+			// the listing is sorted by last update, so everything from here on is already stored
+			if pullRequest.UpdatedAt < lastSync {
+				*remotePullRequests = (*remotePullRequests)[:i]
+				caughtUp = true
+				break
+			}
+			if pullRequest.UpdatedAt > newestUpdate {
+				newestUpdate = pullRequest.UpdatedAt
+			}
+		}
+		if err := rd.pullRequestRepository.StorePullRequests(remotePullRequests, repo); err != nil {
+			return err
+		}
+		if caughtUp {
+			return errPullRequestsCaughtUp
+		}
+		return nil
+	})
+	if errors.Is(err, utils.ErrNotSupported) {
+		return nil
+	}
+	if err != nil && !errors.Is(err, errPullRequestsCaughtUp) {
+		log.Printf("Error in syncing pull requests for repo %s: %v", repo.Name, err)
+		return err
+	}
+	if newestUpdate == lastSync {
+		return nil
+	}
+	return rd.repoRepository.MarkPullRequestsSynced(repo, newestUpdate)
+}
+
+// SyncIssues stores the issues of repo that were opened or changed since the last complete
+// sync. The listing is not sorted by last update, so the sync only moves on once every page
+// has been stored; a sync that fails partway asks for the same issues again next time.
+func (rd *RepositoryDiscoveryService) SyncIssues(ctx context.Context, repo *entity.Repository) error {
+	log.Printf("syncing issues for repo: %s...", repo.Name)
+	lastSync := repo.IssuesSyncedAt
+	newestUpdate := lastSync
+	// GitHub filters the listing by last update itself, so there is no need to stop paging early
+	err := rd.requester.GetRepositoryIssues(withProvider(ctx, repo.Owner), repo.Owner.Username, repo.Name, lastSync, func(remoteIssues *[]dto.IssueResponseDTO) error {
+		for _, issue := range *remoteIssues {
+			if issue.UpdatedAt > newestUpdate {
+				newestUpdate = issue.UpdatedAt
+			}
+		}
+		return rd.issueRepository.StoreIssues(remoteIssues, repo)
+	})
+	if errors.Is(err, utils.ErrNotSupported) {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error in syncing issues for repo %s: %v", repo.Name, err)
+		return err
+	}
+	if newestUpdate == lastSync {
+		return nil
+	}
+	return rd.repoRepository.MarkIssuesSynced(repo, newestUpdate)
+}
+
+// SyncContributors stores GitHub's commit totals for every contributor of repo. The totals
+// only move when the repository does, so nothing is fetched while updatedAt, the repository's
+// last update, is the one they were fetched at. While GitHub is still computing the line totals
+// the sync is not marked done, so the next one picks them up.
+func (rd *RepositoryDiscoveryService) SyncContributors(ctx context.Context, repo *entity.Repository, updatedAt string) error {
+	if updatedAt != "" && repo.ContributorsUpdatedAt == updatedAt {
+		return nil
+	}
+	log.Printf("syncing contributors for repo: %s...", repo.Name)
+	ctx = withProvider(ctx, repo.Owner)
+	err := rd.requester.GetRepositoryContributors(ctx, repo.Owner.Username, repo.Name, func(remoteContributors *[]dto.ContributorResponseDTO) error {
+		return rd.contributorRepository.StoreContributors(remoteContributors, repo)
+	})
+	if errors.Is(err, utils.ErrNotSupported) {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error in syncing contributors for repo %s: %v", repo.Name, err)
+		return err
+	}
+	stats, err := rd.requester.GetContributorStats(ctx, repo.Owner.Username, repo.Name)
+	if err != nil {
+		if errors.Is(err, utils.ErrStatsPending) {
+			log.Printf("Contributor stats for repo %s are not ready yet", repo.Name)
+			return nil
+		}
+		log.Printf("Error in syncing contributor stats for repo %s: %v", repo.Name, err)
+		return err
+	}
+	if err := rd.contributorRepository.StoreContributorStats(&stats, repo); err != nil {
+		return err
+	}
+	return rd.repoRepository.MarkContributorsSynced(repo, updatedAt)
+}
+
+// SyncLanguages replaces the bytes of code stored for each language of repo. GitHub only
+// recounts them when the repository changes, so nothing is fetched while updatedAt, the
+// repository's last update, is the one the stored languages were fetched at.
+func (rd *RepositoryDiscoveryService) SyncLanguages(ctx context.Context, repo *entity.Repository, updatedAt string) error {
+	if updatedAt != "" && repo.LanguagesUpdatedAt == updatedAt {
+		return nil
+	}
+	log.Printf("syncing languages for repo: %s...", repo.Name)
+	languages, err := rd.requester.GetRepositoryLanguages(withProvider(ctx, repo.Owner), repo.Owner.Username, repo.Name)
+	if errors.Is(err, utils.ErrNotSupported) {
+		return nil
+	}
+	if err != nil {
+		log.Printf("Error in syncing languages for repo %s: %v", repo.Name, err)
+		return err
+	}
+	return rd.repoRepository.StoreRepositoryLanguages(languages, repo, updatedAt)
+}
+
+// withProvider marks ctx for the forge owner is on, so the requester asks the right one.
+func withProvider(ctx context.Context, owner *entity.User) context.Context {
+	return requester.WithProvider(ctx, owner.Provider)
+}
+
+// isRateLimited reports whether syncing owner's repositories costs API requests, which the
+// syncs spread out over time; clones on disk are read without any.
+func isRateLimited(owner *entity.User) bool {
+	return owner.Provider != entity.ProviderLocal
+}
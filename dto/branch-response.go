@@ -0,0 +1,45 @@
+package dto
+
+import "encoding/json"
+
+type BranchResponseDTO struct {
+	Name      string
+	SHA       string
+	Protected bool
+}
+
+type TagResponseDTO struct {
+	Name string
+	SHA  string
+}
+
+type refCommit struct {
+	SHA string `json:"sha"`
+}
+
+type tempRefResponseDTO struct {
+	Name      string    `json:"name"`
+	Commit    refCommit `json:"commit"`
+	Protected bool      `json:"protected"`
+}
+
+func (b *BranchResponseDTO) UnmarshalJSON(data []byte) error {
+	var temp tempRefResponseDTO
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	b.Name = temp.Name
+	b.SHA = temp.Commit.SHA
+	b.Protected = temp.Protected
+	return nil
+}
+
+func (t *TagResponseDTO) UnmarshalJSON(data []byte) error {
+	var temp tempRefResponseDTO
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	t.Name = temp.Name
+	t.SHA = temp.Commit.SHA
+	return nil
+}
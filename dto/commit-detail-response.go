@@ -0,0 +1,42 @@
+package dto
+
+import "encoding/json"
+
+type CommitFileDTO struct {
+	Filename  string `json:"filename"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+	Changes   int    `json:"changes"`
+}
+
+// CommitDetailResponseDTO is a single commit as returned by GET /repos/{owner}/{repo}/commits/{sha},
+// which, unlike the commit listing, carries line stats and the files the commit touched.
+type CommitDetailResponseDTO struct {
+	CommitResponseDTO
+	Additions int
+	Deletions int
+	Files     []CommitFileDTO
+}
+
+type tempCommitDetailResponseDTO struct {
+	Stats struct {
+		Additions int `json:"additions"`
+		Deletions int `json:"deletions"`
+	} `json:"stats"`
+	Files []CommitFileDTO `json:"files"`
+}
+
+func (c *CommitDetailResponseDTO) UnmarshalJSON(data []byte) error {
+	if err := c.CommitResponseDTO.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	var temp tempCommitDetailResponseDTO
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	c.Additions = temp.Stats.Additions
+	c.Deletions = temp.Stats.Deletions
+	c.Files = temp.Files
+	return nil
+}
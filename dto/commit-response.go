@@ -3,25 +3,54 @@ package dto
 import "encoding/json"
 
 type CommitResponseDTO struct {
-	SHA     string `json:"sha"`
-	Message string
-	Author  string
-	Date    string
+	SHA         string `json:"sha"`
+	Message     string
+	Author      string
+	AuthorEmail string
+	// AuthorLogin and AuthorID are the GitHub account the author email is linked to, empty when there is none
+	AuthorLogin string
+	AuthorID    int
+	// Date is when the commit was authored
+	Date           string
+	Committer      string
+	CommitterEmail string
+	CommitterLogin string
+	CommitterID    int
+	// CommitterDate is when the commit was last applied, which differs from Date once it is rebased or cherry-picked
+	CommitterDate string
+	// Parents are the SHAs of the commits this one was made on top of, more than one for a merge
+	Parents []string
 	URL     string `json:"html_url"`
 }
 
+type commitSignature struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date"`
+}
+
+type commitAccount struct {
+	Login string `json:"login"`
+	ID    int    `json:"id"`
+}
+
 type nestedCommit struct {
-	Message string `json:"message"`
-	Author  struct {
-		Name string `json:"name"`
-		Date string `json:"date"`
-	} `json:"author"`
+	Message   string          `json:"message"`
+	Author    commitSignature `json:"author"`
+	Committer commitSignature `json:"committer"`
+}
+
+type commitParent struct {
+	SHA string `json:"sha"`
 }
 
 type tempCommitResponseDTO struct {
-	SHA    string       `json:"sha"`
-	Commit nestedCommit `json:"commit"`
-	URL    string       `json:"html_url"`
+	SHA       string         `json:"sha"`
+	Commit    nestedCommit   `json:"commit"`
+	Author    commitAccount  `json:"author"`
+	Committer commitAccount  `json:"committer"`
+	Parents   []commitParent `json:"parents"`
+	URL       string         `json:"html_url"`
 }
 
 func (c *CommitResponseDTO) UnmarshalJSON(data []byte) error {
@@ -32,7 +61,18 @@ func (c *CommitResponseDTO) UnmarshalJSON(data []byte) error {
 	c.SHA = temp.SHA
 	c.Message = temp.Commit.Message
 	c.Author = temp.Commit.Author.Name
+	c.AuthorEmail = temp.Commit.Author.Email
+	c.AuthorLogin = temp.Author.Login
+	c.AuthorID = temp.Author.ID
 	c.Date = temp.Commit.Author.Date
+	c.Committer = temp.Commit.Committer.Name
+	c.CommitterEmail = temp.Commit.Committer.Email
+	c.CommitterLogin = temp.Committer.Login
+	c.CommitterID = temp.Committer.ID
+	c.CommitterDate = temp.Commit.Committer.Date
+	for _, parent := range temp.Parents {
+		c.Parents = append(c.Parents, parent.SHA)
+	}
 	c.URL = temp.URL
 	return nil
 }
@@ -1,9 +1,12 @@
 package dto
 
+import "strconv"
+
 type CommitQueryParams struct {
-	SHA   string
-	Since string
-	Until string
+	SHA     string
+	Since   string
+	Until   string
+	PerPage int
 }
 
 func (p CommitQueryParams) String() string {
@@ -23,6 +26,12 @@ func (p CommitQueryParams) String() string {
 		}
 		queryString += "until=" + p.Until
 	}
+	if p.PerPage > 0 {
+		if queryString != "" {
+			queryString += "&"
+		}
+		queryString += "per_page=" + strconv.Itoa(p.PerPage)
+	}
 	if queryString != "" {
 		return "?" + queryString
 	}
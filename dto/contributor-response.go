@@ -0,0 +1,47 @@
+package dto
+
+import "encoding/json"
+
+// ContributorResponseDTO is an entry of /repos/{owner}/{repo}/contributors.
+type ContributorResponseDTO struct {
+	ID            int    `json:"id"`
+	Login         string `json:"login"`
+	Contributions int    `json:"contributions"`
+}
+
+// ContributorStatsResponseDTO is an entry of /repos/{owner}/{repo}/stats/contributors,
+// with the weekly additions and deletions summed up.
+type ContributorStatsResponseDTO struct {
+	ID        int
+	Login     string
+	Total     int
+	Additions int
+	Deletions int
+}
+
+type tempContributorStatsResponseDTO struct {
+	Total  int `json:"total"`
+	Author struct {
+		ID    int    `json:"id"`
+		Login string `json:"login"`
+	} `json:"author"`
+	Weeks []struct {
+		Additions int `json:"a"`
+		Deletions int `json:"d"`
+	} `json:"weeks"`
+}
+
+func (c *ContributorStatsResponseDTO) UnmarshalJSON(data []byte) error {
+	var temp tempContributorStatsResponseDTO
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	c.ID = temp.Author.ID
+	c.Login = temp.Author.Login
+	c.Total = temp.Total
+	for _, week := range temp.Weeks {
+		c.Additions += week.Additions
+		c.Deletions += week.Deletions
+	}
+	return nil
+}
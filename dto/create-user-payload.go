@@ -3,4 +3,6 @@ package dto
 type CreateUserPayloadDTO struct {
 	Username string `json:"username"`
 	FullName string `json:"fullName"`
+	// Provider is the forge the user is on, GitHub when empty
+	Provider string `json:"provider"`
 }
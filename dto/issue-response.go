@@ -0,0 +1,68 @@
+package dto
+
+import (
+	"encoding/json"
+)
+
+type IssueResponseDTO struct {
+	ID        int
+	Number    int
+	Title     string
+	State     string
+	Labels    []string
+	Author    string
+	Assignees []string
+	CreatedAt string
+	UpdatedAt string
+	ClosedAt  string
+	URL       string
+	// IsPullRequest is set for the pull requests GitHub lists among a repository's issues
+	IsPullRequest bool
+}
+
+type issueUser struct {
+	Login string `json:"login"`
+}
+
+type tempIssueResponseDTO struct {
+	ID     int    `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	User        issueUser       `json:"user"`
+	Assignees   []issueUser     `json:"assignees"`
+	CreatedAt   string          `json:"created_at"`
+	UpdatedAt   string          `json:"updated_at"`
+	ClosedAt    string          `json:"closed_at"`
+	URL         string          `json:"html_url"`
+	PullRequest json.RawMessage `json:"pull_request"`
+}
+
+func (i *IssueResponseDTO) UnmarshalJSON(data []byte) error {
+	var temp tempIssueResponseDTO
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	i.ID = temp.ID
+	i.Number = temp.Number
+	i.Title = temp.Title
+	i.State = temp.State
+	i.Labels = make([]string, len(temp.Labels))
+	for j, label := range temp.Labels {
+		i.Labels[j] = label.Name
+	}
+	i.Author = temp.User.Login
+	i.Assignees = make([]string, len(temp.Assignees))
+	for j, assignee := range temp.Assignees {
+		i.Assignees[j] = assignee.Login
+	}
+	i.CreatedAt = temp.CreatedAt
+	i.UpdatedAt = temp.UpdatedAt
+	i.ClosedAt = temp.ClosedAt
+	i.URL = temp.URL
+	i.IsPullRequest = len(temp.PullRequest) > 0 && string(temp.PullRequest) != "null"
+	return nil
+}
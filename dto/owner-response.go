@@ -0,0 +1,14 @@
+package dto
+
+// GitHub's values for OwnerResponseDTO.Type; the other forges' requesters map onto them
+const (
+	OwnerTypeUser         = "User"
+	OwnerTypeOrganization = "Organization"
+)
+
+// OwnerResponseDTO is the account behind a name, as returned by /users/{owner}.
+type OwnerResponseDTO struct {
+	ID    int    `json:"id"`
+	Login string `json:"login"`
+	Type  string `json:"type"`
+}
@@ -0,0 +1,65 @@
+package dto
+
+import "encoding/json"
+
+type PullRequestResponseDTO struct {
+	ID             int
+	Number         int
+	Title          string
+	State          string
+	Draft          bool
+	Author         string
+	CreatedAt      string
+	UpdatedAt      string
+	MergedAt       string
+	ClosedAt       string
+	BaseRef        string
+	HeadRef        string
+	MergeCommitSHA string
+	URL            string
+}
+
+type pullRequestRef struct {
+	Ref string `json:"ref"`
+}
+
+type tempPullRequestResponseDTO struct {
+	ID     int    `json:"id"`
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Draft  bool   `json:"draft"`
+	User   struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	CreatedAt      string         `json:"created_at"`
+	UpdatedAt      string         `json:"updated_at"`
+	MergedAt       string         `json:"merged_at"`
+	ClosedAt       string         `json:"closed_at"`
+	Base           pullRequestRef `json:"base"`
+	Head           pullRequestRef `json:"head"`
+	MergeCommitSHA string         `json:"merge_commit_sha"`
+	URL            string         `json:"html_url"`
+}
+
+func (p *PullRequestResponseDTO) UnmarshalJSON(data []byte) error {
+	var temp tempPullRequestResponseDTO
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	p.ID = temp.ID
+	p.Number = temp.Number
+	p.Title = temp.Title
+	p.State = temp.State
+	p.Draft = temp.Draft
+	p.Author = temp.User.Login
+	p.CreatedAt = temp.CreatedAt
+	p.UpdatedAt = temp.UpdatedAt
+	p.MergedAt = temp.MergedAt
+	p.ClosedAt = temp.ClosedAt
+	p.BaseRef = temp.Base.Ref
+	p.HeadRef = temp.Head.Ref
+	p.MergeCommitSHA = temp.MergeCommitSHA
+	p.URL = temp.URL
+	return nil
+}
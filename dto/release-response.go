@@ -0,0 +1,49 @@
+package dto
+
+import "encoding/json"
+
+type ReleaseResponseDTO struct {
+	ID          int
+	TagName     string
+	Name        string
+	Body        string
+	Draft       bool
+	Prerelease  bool
+	Author      string
+	CreatedAt   string
+	PublishedAt string
+	URL         string
+}
+
+type tempReleaseResponseDTO struct {
+	ID         int    `json:"id"`
+	TagName    string `json:"tag_name"`
+	Name       string `json:"name"`
+	Body       string `json:"body"`
+	Draft      bool   `json:"draft"`
+	Prerelease bool   `json:"prerelease"`
+	Author     struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	CreatedAt   string `json:"created_at"`
+	PublishedAt string `json:"published_at"`
+	URL         string `json:"html_url"`
+}
+
+func (r *ReleaseResponseDTO) UnmarshalJSON(data []byte) error {
+	var temp tempReleaseResponseDTO
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+	r.ID = temp.ID
+	r.TagName = temp.TagName
+	r.Name = temp.Name
+	r.Body = temp.Body
+	r.Draft = temp.Draft
+	r.Prerelease = temp.Prerelease
+	r.Author = temp.Author.Login
+	r.CreatedAt = temp.CreatedAt
+	r.PublishedAt = temp.PublishedAt
+	r.URL = temp.URL
+	return nil
+}
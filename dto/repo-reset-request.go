@@ -1,6 +1,8 @@
 package dto
 
 type RepoResetRequest struct {
+	// RepoID is the repository to reset; RepoName is only for logs, as several owners can have a repository of that name
+	RepoID   uint
 	RepoName string
 	ResetSHA string
 }
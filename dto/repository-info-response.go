@@ -1,18 +1,60 @@
 package dto
 
 type RepositoryInfoResponseDTO struct {
-	ID          int    `json:"id"`
-	Name        string `json:"name"`
-	FullName    string `json:"full_name"`
-	HtmlUrl     string `json:"html_url"`
-	Description string `json:"description"`
-	URL         string `json:"url"`
-	Fork        bool   `json:"fork"`
-	Language    string `json:"language"`
-	ForksCount  int    `json:"forks_count"`
-	StarsCount  int    `json:"stargazers_count"`
-	OpenIssues  int    `json:"open_issues_count"`
-	Watchers    int    `json:"watchers_count"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	FullName    string   `json:"full_name"`
+	HtmlUrl     string   `json:"html_url"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Fork        bool     `json:"fork"`
+	Archived    bool     `json:"archived"`
+	Private     bool     `json:"private"`
+	Visibility  string   `json:"visibility"`
+	Topics      []string `json:"topics"`
+	License     *License `json:"license"`
+	Language    string   `json:"language"`
+	ForksCount  int      `json:"forks_count"`
+	StarsCount  int      `json:"stargazers_count"`
+	OpenIssues  int      `json:"open_issues_count"`
+	Watchers    int      `json:"watchers_count"`
+	// Size is the size of the repository in kilobytes
+	Size          int    `json:"size"`
+	DefaultBranch string `json:"default_branch"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+	// PushedAt is when a commit was last pushed to any branch; UpdatedAt also moves when the
+	// repository's settings or metadata change
+	PushedAt string `json:"pushed_at"`
+	// NotModified is set when GitHub reported the repository unchanged since it was last fetched;
+	// it does not mean what was fetched then has been stored.
+	NotModified bool `json:"-"`
+}
+
+// License is the license GitHub detected in a repository. SPDXID is NOASSERTION for a license
+// file it could not identify.
+type License struct {
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	SPDXID string `json:"spdx_id"`
+}
+
+// RepositoryVisibility is the visibility of the repository, worked out from whether it is
+// private when the forge does not report it.
+func (r *RepositoryInfoResponseDTO) RepositoryVisibility() string {
+	if r.Visibility != "" {
+		return r.Visibility
+	}
+	if r.Private {
+		return "private"
+	}
+	return "public"
+}
+
+// LicenseID is the SPDX identifier of the repository's license, empty when it has none.
+func (r *RepositoryInfoResponseDTO) LicenseID() string {
+	if r.License == nil {
+		return ""
+	}
+	return r.License.SPDXID
 }
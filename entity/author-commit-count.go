@@ -1,6 +1,8 @@
 package entity
 
 type AuthorCommitCount struct {
-	Author      string `json:"author"`
+	Author string `json:"author"`
+	// AuthorLogin is the author's account on the forge, empty when their commits are not linked to one
+	AuthorLogin string `json:"authorLogin,omitempty"`
 	CommitCount int    `json:"commitCount"`
 }
@@ -0,0 +1,16 @@
+package entity
+
+type Branch struct {
+	ID         uint
+	Repository *Repository
+	Name       string
+	SHA        string
+	Protected  bool
+}
+
+type Tag struct {
+	ID         uint
+	Repository *Repository
+	Name       string
+	SHA        string
+}
@@ -0,0 +1,27 @@
+package entity
+
+// AuthorChurn sums the lines an author added and removed across their commits.
+type AuthorChurn struct {
+	Author      string `json:"author"`
+	AuthorLogin string `json:"authorLogin,omitempty"`
+	Commits     int    `json:"commits"`
+	Additions   int    `json:"additions"`
+	Deletions   int    `json:"deletions"`
+}
+
+// FileChurn counts how often a file changed and by how many lines.
+type FileChurn struct {
+	Filename  string `json:"filename"`
+	Changes   int    `json:"changes"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// RepositoryChurn sums the lines added and removed in a repository, broken down by author.
+type RepositoryChurn struct {
+	Repository string         `json:"repository"`
+	Commits    int            `json:"commits"`
+	Additions  int            `json:"additions"`
+	Deletions  int            `json:"deletions"`
+	Authors    []*AuthorChurn `json:"authors"`
+}
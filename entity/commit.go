@@ -1,11 +1,58 @@
 package entity
 
+import (
+	"strconv"
+	"strings"
+)
+
 type Commit struct {
-	ID         uint
-	Repository *Repository
-	Message    string
-	Author     string
-	Date       string
-	URL        string
-	SHA        string
+	ID          uint
+	Repository  *Repository
+	Message     string
+	Author      string
+	AuthorEmail string
+	AuthorLogin string
+	AuthorID    int
+	// Date is when the commit was authored
+	Date           string
+	Committer      string
+	CommitterEmail string
+	CommitterLogin string
+	CommitterID    int
+	// CommitterDate is when the commit was last applied, e.g. by a rebase or a cherry-pick
+	CommitterDate string
+	URL           string
+	SHA           string
+
+	Additions    int
+	Deletions    int
+	ChangedFiles int
+}
+
+// AuthorIdentity is who a commit is credited to on the leaderboards: Key tells authors apart,
+// while Name and Login are only for display.
+type AuthorIdentity struct {
+	Key   string
+	Name  string
+	Login string
+}
+
+// AuthorKey identifies a commit author by the most stable identity available: the ID of their
+// account on the forge, which survives renames, then their login, then their email address, and
+// only then the name they committed under, which people spell in several ways and share with others.
+// Accounts are only unique within a forge, so their keys carry the provider.
+func AuthorKey(provider string, id int, login, email, name string) string {
+	if provider == "" {
+		provider = ProviderGitHub
+	}
+	switch {
+	case id != 0:
+		return provider + ":id:" + strconv.Itoa(id)
+	case login != "":
+		return provider + ":login:" + login
+	case email != "":
+		return "email:" + strings.ToLower(email)
+	default:
+		return "name:" + name
+	}
 }
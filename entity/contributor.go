@@ -0,0 +1,27 @@
+package entity
+
+type Contributor struct {
+	Login          string `json:"login"`
+	Contributions  int    `json:"contributions"`
+	StatsCommits   int    `json:"statsCommits"`
+	StatsAdditions int    `json:"statsAdditions"`
+	StatsDeletions int    `json:"statsDeletions"`
+}
+
+// ContributorReconciliationEntry compares GitHub's commit total for a contributor with the commits we stored.
+type ContributorReconciliationEntry struct {
+	Login string `json:"login"`
+	// Author is the commit author name our leaderboard counts the contributor under,
+	// empty when none of their commits are stored
+	Author     string `json:"author"`
+	Upstream   int    `json:"upstream"`
+	Stored     int    `json:"stored"`
+	Adjustment int    `json:"adjustment"`
+	Difference int    `json:"difference"`
+}
+
+type ContributorReconciliation struct {
+	Repository   string                            `json:"repository"`
+	Corrected    bool                              `json:"corrected"`
+	Contributors []*ContributorReconciliationEntry `json:"contributors"`
+}
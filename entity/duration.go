@@ -0,0 +1,18 @@
+package entity
+
+// DurationBucket counts the durations below an upper bound and at or above the previous bucket's.
+type DurationBucket struct {
+	Label string `json:"label"`
+	Count int    `json:"count"`
+}
+
+// DurationDistribution summarises a set of durations, such as how long pull requests took to merge, in hours.
+type DurationDistribution struct {
+	Count   int              `json:"count"`
+	Mean    float64          `json:"meanHours"`
+	Median  float64          `json:"medianHours"`
+	P75     float64          `json:"p75Hours"`
+	P90     float64          `json:"p90Hours"`
+	Max     float64          `json:"maxHours"`
+	Buckets []DurationBucket `json:"buckets"`
+}
@@ -0,0 +1,25 @@
+package entity
+
+type Issue struct {
+	ID         uint
+	Repository *Repository
+	Number     int
+	Title      string
+	State      string
+	Labels     []string
+	Author     string
+	Assignees  []string
+	CreatedAt  string
+	UpdatedAt  string
+	ClosedAt   string
+	URL        string
+}
+
+// IssueStatistics describes how quickly the issues of a repository get closed and how old the open ones are.
+type IssueStatistics struct {
+	Repository   string               `json:"repository"`
+	Open         int                  `json:"open"`
+	Closed       int                  `json:"closed"`
+	TimeToClose  DurationDistribution `json:"timeToClose"`
+	OpenIssueAge DurationDistribution `json:"openIssueAge"`
+}
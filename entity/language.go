@@ -0,0 +1,20 @@
+package entity
+
+// LanguageShare is how much of the code in a breakdown is written in one language.
+type LanguageShare struct {
+	Language string `json:"language"`
+	Bytes    int    `json:"bytes"`
+	// Percentage is the share of the breakdown's bytes, rounded to two decimals
+	Percentage float64 `json:"percentage"`
+	// Repositories is how many repositories of an owner use the language
+	Repositories int `json:"repositories,omitempty"`
+}
+
+// LanguageBreakdown is the distribution of code across languages in a repository, or across
+// every repository of an owner, largest first.
+type LanguageBreakdown struct {
+	Owner      string           `json:"owner"`
+	Repository string           `json:"repository,omitempty"`
+	Bytes      int              `json:"bytes"`
+	Languages  []*LanguageShare `json:"languages"`
+}
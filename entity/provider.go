@@ -0,0 +1,44 @@
+package entity
+
+import "strings"
+
+// forges repositories can be synced from
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+	// ProviderGitea covers Forgejo as well, which serves the same API
+	ProviderGitea = "gitea"
+	// ProviderLocal is for repositories read from git clones on disk rather than from a forge
+	ProviderLocal = "local"
+)
+
+// Providers lists every forge a user can be registered on.
+var Providers = []string{ProviderGitHub, ProviderGitLab, ProviderGitea, ProviderLocal}
+
+// IsProvider reports whether provider is one of Providers.
+func IsProvider(provider string) bool {
+	for _, known := range Providers {
+		if provider == known {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseOwner splits an owner as given in our routes into the username and the forge it is on.
+// Owners on GitHub are given by username alone; owners on any other forge are written
+// username@provider, so the same username on two forges does not collide.
+func ParseOwner(owner string) (username, provider string) {
+	if i := strings.LastIndex(owner, "@"); i >= 0 {
+		return owner[:i], owner[i+1:]
+	}
+	return owner, ProviderGitHub
+}
+
+// OwnerHandle is the inverse of ParseOwner.
+func OwnerHandle(username, provider string) string {
+	if provider == "" || provider == ProviderGitHub {
+		return username
+	}
+	return username + "@" + provider
+}
@@ -0,0 +1,37 @@
+package entity
+
+type PullRequest struct {
+	ID             uint
+	Repository     *Repository
+	Number         int
+	Title          string
+	State          string
+	Draft          bool
+	Author         string
+	CreatedAt      string
+	MergedAt       string
+	ClosedAt       string
+	BaseRef        string
+	HeadRef        string
+	MergeCommitSHA string
+	URL            string
+}
+
+// PullRequestMetrics describes the pull requests of a repository, or of one author in it.
+type PullRequestMetrics struct {
+	Author string `json:"author,omitempty"`
+	Total  int    `json:"total"`
+	Open   int    `json:"open"`
+	Merged int    `json:"merged"`
+	// ClosedUnmerged counts pull requests closed without being merged
+	ClosedUnmerged int `json:"closedUnmerged"`
+	// MergeRate is the share of closed pull requests that were merged
+	MergeRate   float64              `json:"mergeRate"`
+	TimeToMerge DurationDistribution `json:"timeToMerge"`
+}
+
+type RepositoryPullRequestMetrics struct {
+	Repository string `json:"repository"`
+	PullRequestMetrics
+	Authors []*PullRequestMetrics `json:"authors"`
+}
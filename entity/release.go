@@ -0,0 +1,31 @@
+package entity
+
+type Release struct {
+	ID          uint
+	RemoteID    int
+	Repository  *Repository
+	TagName     string
+	Name        string
+	Body        string
+	Draft       bool
+	Prerelease  bool
+	Author      string
+	PublishedAt string
+	URL         string
+}
+
+// how the commits of a release timeline entry were picked
+const (
+	// CommitRangeTags walks the history from the release's tag back to the tag of the release before it
+	CommitRangeTags = "tags"
+	// CommitRangeDates approximates that with the commits dated between the two releases' publish dates,
+	// for releases whose tags or history were not synced; backdated or cherry-picked commits may land in the wrong release
+	CommitRangeDates = "dates"
+)
+
+// ReleaseTimelineEntry is a published release with the commits made since the release before it.
+type ReleaseTimelineEntry struct {
+	Release     *Release
+	Commits     []*Commit
+	CommitRange string
+}
@@ -1,17 +1,55 @@
 package entity
 
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+	// VisibilityInternal repositories are visible to every member of an enterprise or instance
+	VisibilityInternal = "internal"
+)
+
+var Visibilities = []string{VisibilityPublic, VisibilityPrivate, VisibilityInternal}
+
+// IsVisibility reports whether visibility is one of Visibilities.
+func IsVisibility(visibility string) bool {
+	for _, known := range Visibilities {
+		if visibility == known {
+			return true
+		}
+	}
+	return false
+}
+
 type Repository struct {
-	ID              uint
-	RemoteID        int
-	Owner           *User
-	Name            string
-	Description     string
-	URL             string
-	Language        string
-	ForksCount      int
-	StarsCount      int
-	OpenIssues      int
-	Watchers        int
+	ID          uint
+	RemoteID    int
+	Owner       *User
+	Name        string
+	Description string
+	URL         string
+	Language    string
+	Fork        bool
+	Archived    bool
+	Visibility  string
+	Topics      []string
+	// License is the SPDX identifier of the repository's license, empty when it has none
+	License    string
+	ForksCount int
+	StarsCount int
+	OpenIssues int
+	Watchers   int
+	// Size is the size of the repository in kilobytes
+	Size            int
 	RemoteCreatedAt string
 	RemoteUpdatedAt string
+	RemotePushedAt  string
+	// LanguagesUpdatedAt is the RemoteUpdatedAt the languages were last fetched at
+	LanguagesUpdatedAt string
+	// ContributorsUpdatedAt is the RemoteUpdatedAt the contributor totals were last fetched at
+	ContributorsUpdatedAt string
+	// PullRequestsSyncedAt is the newest pull request update stored by the last complete sync
+	PullRequestsSyncedAt string
+	// IssuesSyncedAt is the newest issue update stored by the last complete sync
+	IssuesSyncedAt string
+	DefaultBranch  string
+	Provider       string
 }
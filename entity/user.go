@@ -1,7 +1,40 @@
 package entity
 
+// kinds of account repositories can be owned by
+const (
+	OwnerTypeUser         = "user"
+	OwnerTypeOrganization = "organization"
+)
+
+// OwnerTypes lists every kind of owner, in the order they are offered to API clients.
+var OwnerTypes = []string{OwnerTypeUser, OwnerTypeOrganization}
+
+// IsOwnerType reports whether ownerType is one of OwnerTypes.
+func IsOwnerType(ownerType string) bool {
+	for _, known := range OwnerTypes {
+		if ownerType == known {
+			return true
+		}
+	}
+	return false
+}
+
 type User struct {
 	ID       uint
 	FullName string
 	Username string
+	Provider string
+	// OwnerType is whether the account is a person or an organization; it is empty
+	// until the first sync of the account's repositories has asked its forge
+	OwnerType string
+}
+
+// Handle is how the user is referred to as an owner in our routes.
+func (u *User) Handle() string {
+	return OwnerHandle(u.Username, u.Provider)
+}
+
+// IsOrganization reports whether the account's repositories are listed as an organization's.
+func (u *User) IsOrganization() bool {
+	return u.OwnerType == OwnerTypeOrganization
 }
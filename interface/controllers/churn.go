@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/midedickson/github-service/utils"
+)
+
+func (c *Controller) GetTopNAuthorsByChurn(w http.ResponseWriter, r *http.Request) {
+	topNString, err := utils.GetPathParam(r, "top_n")
+	if err != nil {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	topN, err := strconv.Atoi(topNString)
+	if err != nil {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	if topN <= 0 {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	authors, err := c.commitUsecase.GetTopNAuthorsByChurn(topN)
+	if err != nil {
+		log.Printf("Error in getting top n authors by churn: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Top Authors by Churn Fetched Successfully", authors)
+}
+
+func (c *Controller) GetRepositoryChurn(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	if repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	churn, err := c.commitUsecase.GetRepositoryChurn(owner, repoName)
+	if err != nil {
+		log.Printf("Error in getting repository churn: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Repository Churn Fetched Successfully", churn)
+}
+
+func (c *Controller) GetMostChangedFiles(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	if repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	topNString, err := utils.GetPathParam(r, "top_n")
+	if err != nil {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	topN, err := strconv.Atoi(topNString)
+	if err != nil {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	if topN <= 0 {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	files, err := c.commitUsecase.GetMostChangedFiles(owner, repoName, topN)
+	if err != nil {
+		log.Printf("Error in getting most changed files: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Most Changed Files Fetched Successfully", files)
+}
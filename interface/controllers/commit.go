@@ -5,10 +5,16 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/midedickson/github-service/entity"
 	"github.com/midedickson/github-service/utils"
 )
 
 func (c *Controller) GetRepositoryCommits(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
 	repoName, err := utils.GetPathParam(r, "repo")
 	if err != nil {
 		utils.Dispatch400Error(w, "Invalid Payload", err)
@@ -18,10 +24,15 @@ func (c *Controller) GetRepositoryCommits(w http.ResponseWriter, r *http.Request
 		utils.Dispatch400Error(w, "Invalid Payload", err)
 		return
 	}
-	commits, err := c.commitUsecase.GetRepositoryCommits(repoName)
+	var commits []*entity.Commit
+	if branch := r.URL.Query().Get("branch"); branch != "" {
+		commits, err = c.commitUsecase.GetBranchCommits(owner, repoName, branch)
+	} else {
+		commits, err = c.commitUsecase.GetRepositoryCommits(owner, repoName)
+	}
 	if err != nil {
 		log.Printf("%v", err)
-		utils.Dispatch500Error(w, err)
+		utils.DispatchError(w, err)
 		return
 	}
 	utils.Dispatch200(w, "Repository Commits Fetched Successfully", commits)
@@ -54,7 +65,7 @@ func (c *Controller) RequestRepositoryReset(w http.ResponseWriter, r *http.Reque
 	err = c.commitUsecase.MakeRepoResetRequest(owner, repoName, resetSHA)
 	if err != nil {
 		log.Printf("Error occured while trying to make a reset repo request: %v", err)
-		utils.Dispatch500Error(w, err)
+		utils.DispatchError(w, err)
 		return
 	}
 	utils.Dispatch200(w, "Reset Request sent successfully", nil)
@@ -78,7 +89,7 @@ func (c *Controller) GetTopNAuthorsByCommits(w http.ResponseWriter, r *http.Requ
 	authors, err := c.commitUsecase.GetTopNAuthorsByCommits(topN)
 	if err != nil {
 		log.Printf("Error in getting top n authors: %v", err)
-		utils.Dispatch500Error(w, err)
+		utils.DispatchError(w, err)
 		return
 	}
 	utils.Dispatch200(w, "Top Authors by Commits Fetched Successfully", authors)
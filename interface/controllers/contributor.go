@@ -0,0 +1,60 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/midedickson/github-service/utils"
+)
+
+func (c *Controller) GetContributors(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	contributors, err := c.contributorUsecase.GetContributors(owner, repoName)
+	if err != nil {
+		log.Printf("Error in getting contributors: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Contributors Fetched Successfully", contributors)
+}
+
+func (c *Controller) GetContributorReconciliation(w http.ResponseWriter, r *http.Request) {
+	c.reconcileAuthorCommitCounts(w, r, false)
+}
+
+func (c *Controller) CorrectAuthorCommitCounts(w http.ResponseWriter, r *http.Request) {
+	c.reconcileAuthorCommitCounts(w, r, true)
+}
+
+func (c *Controller) reconcileAuthorCommitCounts(w http.ResponseWriter, r *http.Request, correct bool) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	reconciliation, err := c.contributorUsecase.ReconcileAuthorCommitCounts(owner, repoName, correct)
+	if err != nil {
+		log.Printf("Error in reconciling author commit counts: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	message := "Contributor Reconciliation Fetched Successfully"
+	if correct {
+		message = "Author Commit Counts Corrected Successfully"
+	}
+	utils.Dispatch200(w, message, reconciliation)
+}
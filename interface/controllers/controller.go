@@ -6,22 +6,37 @@ import (
 )
 
 type Controller struct {
-	requester     requester.Requester
-	userUseCase   usecase.UserUseCase
-	repoUsecase   usecase.RepoUseCase
-	commitUsecase usecase.CommitUseCase
+	requester          requester.Requester
+	governor           *requester.Governor
+	userUseCase        usecase.UserUseCase
+	repoUsecase        usecase.RepoUseCase
+	commitUsecase      usecase.CommitUseCase
+	releaseUsecase     usecase.ReleaseUseCase
+	pullRequestUsecase usecase.PullRequestUseCase
+	issueUsecase       usecase.IssueUseCase
+	contributorUsecase usecase.ContributorUseCase
 }
 
 func NewController(
 	requester requester.Requester,
+	governor *requester.Governor,
 	userUseCase usecase.UserUseCase,
 	repoUsecase usecase.RepoUseCase,
 	commitUsecase usecase.CommitUseCase,
+	releaseUsecase usecase.ReleaseUseCase,
+	pullRequestUsecase usecase.PullRequestUseCase,
+	issueUsecase usecase.IssueUseCase,
+	contributorUsecase usecase.ContributorUseCase,
 ) *Controller {
 	return &Controller{
-		requester:     requester,
-		userUseCase:   userUseCase,
-		repoUsecase:   repoUsecase,
-		commitUsecase: commitUsecase,
+		requester:          requester,
+		governor:           governor,
+		userUseCase:        userUseCase,
+		repoUsecase:        repoUsecase,
+		commitUsecase:      commitUsecase,
+		releaseUsecase:     releaseUsecase,
+		pullRequestUsecase: pullRequestUsecase,
+		issueUsecase:       issueUsecase,
+		contributorUsecase: contributorUsecase,
 	}
 }
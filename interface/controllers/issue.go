@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/midedickson/github-service/utils"
+)
+
+func (c *Controller) GetIssues(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	issueSearchParams := &utils.IssueSearchParams{}
+	utils.ParseIssueSearchQueryParams(r, issueSearchParams)
+	// open issues unless another state is asked for; "all" returns every state
+	switch issueSearchParams.State {
+	case "":
+		issueSearchParams.State = "open"
+	case "open", "closed":
+	case "all":
+		issueSearchParams.State = ""
+	default:
+		utils.Dispatch400Error(w, "Invalid Payload", errors.New("state must be one of open, closed or all"))
+		return
+	}
+	issues, err := c.issueUsecase.GetIssues(owner, repoName, issueSearchParams)
+	if err != nil {
+		log.Printf("Error in getting issues: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Issues Fetched Successfully", issues)
+}
+
+func (c *Controller) GetIssueStatistics(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	statistics, err := c.issueUsecase.GetIssueStatistics(owner, repoName)
+	if err != nil {
+		log.Printf("Error in getting issue statistics: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Issue Statistics Fetched Successfully", statistics)
+}
@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/midedickson/github-service/utils"
+)
+
+func (c *Controller) GetPullRequests(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	// open pull requests unless another state is asked for; "all" returns every state
+	state := r.URL.Query().Get("state")
+	switch state {
+	case "":
+		state = "open"
+	case "open", "closed":
+	case "all":
+		state = ""
+	default:
+		utils.Dispatch400Error(w, "Invalid Payload", errors.New("state must be one of open, closed or all"))
+		return
+	}
+	pullRequests, err := c.pullRequestUsecase.GetPullRequests(owner, repoName, state)
+	if err != nil {
+		log.Printf("Error in getting pull requests: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Pull Requests Fetched Successfully", pullRequests)
+}
+
+func (c *Controller) GetPullRequestMetrics(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	metrics, err := c.pullRequestUsecase.GetPullRequestMetrics(owner, repoName)
+	if err != nil {
+		log.Printf("Error in getting pull request metrics: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Pull Request Metrics Fetched Successfully", metrics)
+}
@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+)
+
+func (c *Controller) GetRateLimitStatus(w http.ResponseWriter, r *http.Request) {
+	statuses := []requester.RateLimitStatus{}
+	if c.governor != nil {
+		statuses = c.governor.Snapshot()
+	}
+	utils.Dispatch200(w, "Rate Limit Status Fetched Successfully", statuses)
+}
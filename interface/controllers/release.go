@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"log"
+	"net/http"
+
+	"github.com/midedickson/github-service/utils"
+)
+
+func (c *Controller) GetRepositoryReleases(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	releases, err := c.releaseUsecase.GetRepositoryReleases(owner, repoName)
+	if err != nil {
+		log.Printf("Error in getting repository releases: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Repository Releases Fetched Successfully", releases)
+}
+
+func (c *Controller) GetReleaseTimeline(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	timeline, err := c.releaseUsecase.GetReleaseTimeline(owner, repoName)
+	if err != nil {
+		log.Printf("Error in getting release timeline: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Release Timeline Fetched Successfully", timeline)
+}
@@ -34,7 +34,7 @@ func (c *Controller) GetRepositoryInfo(w http.ResponseWriter, r *http.Request) {
 	repo, err := c.repoUsecase.GetRepositoryInfo(owner, repoName)
 	if err != nil {
 		log.Printf("Error in use case: %v", err)
-		utils.Dispatch500Error(w, err)
+		utils.DispatchError(w, err)
 		return
 	}
 	if repo == nil {
@@ -51,11 +51,87 @@ func (c *Controller) GetRepositories(w http.ResponseWriter, r *http.Request) {
 		utils.Dispatch400Error(w, "Invalid Payload", err)
 		return
 	}
-	utils.ParseRepoSearchQueryParams(r, repoSearchParams)
+	if err := utils.ParseRepoSearchQueryParams(r, repoSearchParams); err != nil {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
 	repositories, err := c.repoUsecase.GetUserRepositories(owner, repoSearchParams)
 	if err != nil {
-		utils.Dispatch500Error(w, err)
+		utils.DispatchError(w, err)
 		return
 	}
 	utils.Dispatch200(w, "Repositories Fetched Successfully", repositories)
 }
+
+func (c *Controller) GetRepositoryBranches(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	branches, err := c.repoUsecase.GetRepositoryBranches(owner, repoName)
+	if err != nil {
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Repository Branches Fetched Successfully", branches)
+}
+
+func (c *Controller) GetRepositoryTags(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	tags, err := c.repoUsecase.GetRepositoryTags(owner, repoName)
+	if err != nil {
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Repository Tags Fetched Successfully", tags)
+}
+
+func (c *Controller) GetRepositoryLanguages(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	repoName, err := utils.GetPathParam(r, "repo")
+	if err != nil || repoName == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	languages, err := c.repoUsecase.GetRepositoryLanguages(owner, repoName)
+	if err != nil {
+		log.Printf("Error in getting repository languages: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Repository Languages Fetched Successfully", languages)
+}
+
+func (c *Controller) GetOwnerLanguages(w http.ResponseWriter, r *http.Request) {
+	owner, err := utils.GetPathParam(r, "owner")
+	if err != nil || owner == "" {
+		utils.Dispatch400Error(w, "Invalid Payload", err)
+		return
+	}
+	languages, err := c.repoUsecase.GetOwnerLanguages(owner)
+	if err != nil {
+		log.Printf("Error in getting owner languages: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Languages Fetched Successfully", languages)
+}
@@ -2,10 +2,13 @@ package controllers
 
 import (
 	"encoding/json"
+	"errors"
 	"log"
 	"net/http"
+	"strings"
 
 	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
 	"github.com/midedickson/github-service/utils"
 )
 
@@ -18,17 +21,38 @@ func (c *Controller) CreateUser(w http.ResponseWriter, r *http.Request) {
 		utils.Dispatch400Error(w, "Invalid Payload", err)
 		return
 	}
-	if createUserPayload.Username == "" || createUserPayload.FullName == "" {
+	// "@" separates a username from its provider in owner handles, and no forge allows it in usernames
+	if createUserPayload.Username == "" || createUserPayload.FullName == "" || strings.Contains(createUserPayload.Username, "@") {
 		utils.Dispatch400Error(w, "Invalid Payload", nil)
 		return
 	}
+	if createUserPayload.Provider != "" && !entity.IsProvider(createUserPayload.Provider) {
+		utils.Dispatch400Error(w, "Unknown provider, expected one of "+strings.Join(entity.Providers, ", "), nil)
+		return
+	}
 
 	user, err := c.userUseCase.CreateUser(&createUserPayload)
 	if err != nil {
 		log.Printf("Error occured while running %v", err)
-		utils.Dispatch500Error(w, err)
+		utils.DispatchError(w, err)
 		return
 	}
 
 	utils.Dispatch200(w, "user created successfully", user)
 }
+
+func (c *Controller) GetUsers(w http.ResponseWriter, r *http.Request) {
+	// every registered user, or only the people or only the organizations when a type is asked for
+	ownerType := r.URL.Query().Get("type")
+	if ownerType != "" && !entity.IsOwnerType(ownerType) {
+		utils.Dispatch400Error(w, "Invalid Payload", errors.New("type must be one of "+strings.Join(entity.OwnerTypes, ", ")))
+		return
+	}
+	users, err := c.userUseCase.GetUsers(ownerType)
+	if err != nil {
+		log.Printf("Error in getting users: %v", err)
+		utils.DispatchError(w, err)
+		return
+	}
+	utils.Dispatch200(w, "Users Fetched Successfully", users)
+}
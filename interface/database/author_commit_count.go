@@ -7,13 +7,17 @@ import (
 
 type AuthorCommitCount struct {
 	gorm.Model
+	// AuthorKey tells authors apart; Author and AuthorLogin are the name and login they were last seen under
+	AuthorKey   string `gorm:"index"`
 	Author      string `gorm:"author"`
+	AuthorLogin string `gorm:"author_login"`
 	CommitCount int    `gorm:"commit_count"`
 }
 
 func (model *AuthorCommitCount) ToEntity() *entity.AuthorCommitCount {
 	return &entity.AuthorCommitCount{
 		Author:      model.Author,
+		AuthorLogin: model.AuthorLogin,
 		CommitCount: model.CommitCount,
 	}
 }
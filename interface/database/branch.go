@@ -0,0 +1,49 @@
+package database
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+type Branch struct {
+	gorm.Model
+	RepositoryID uint   `gorm:"index"`
+	Name         string `gorm:"name"`
+	SHA          string `gorm:"sha"`
+	Protected    bool   `gorm:"protected"`
+	// SyncedSHA is the head of the branch when its commits were last synced
+	SyncedSHA string `gorm:"synced_sha"`
+}
+
+func (model *Branch) ToEntity() *entity.Branch {
+	return &entity.Branch{
+		ID:        model.ID,
+		Name:      model.Name,
+		SHA:       model.SHA,
+		Protected: model.Protected,
+	}
+}
+
+type Tag struct {
+	gorm.Model
+	RepositoryID uint   `gorm:"index"`
+	Name         string `gorm:"name"`
+	SHA          string `gorm:"sha"`
+}
+
+func (model *Tag) ToEntity() *entity.Tag {
+	return &entity.Tag{
+		ID:   model.ID,
+		Name: model.Name,
+		SHA:  model.SHA,
+	}
+}
+
+// CommitBranch records that a commit is reachable from a branch; a commit is
+// stored once however many branches it is on.
+type CommitBranch struct {
+	ID           uint   `gorm:"primarykey"`
+	RepositoryID uint   `gorm:"uniqueIndex:idx_commit_branch_repository"`
+	BranchName   string `gorm:"uniqueIndex:idx_commit_branch_repository"`
+	CommitSHA    string `gorm:"uniqueIndex:idx_commit_branch_repository"`
+}
@@ -0,0 +1,72 @@
+package database
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+// AuthorChurn is pre-aggregated like AuthorCommitCount: one row per author and
+// repository, updated as commit stats are pulled in, so churn leaderboards never
+// have to scan the commit table.
+type AuthorChurn struct {
+	gorm.Model
+	RepositoryID uint   `gorm:"index"`
+	AuthorKey    string `gorm:"index"`
+	Author       string `gorm:"author"`
+	AuthorLogin  string `gorm:"author_login"`
+	Commits      int    `gorm:"commits"`
+	Additions    int    `gorm:"additions"`
+	Deletions    int    `gorm:"deletions"`
+}
+
+func (model *AuthorChurn) ToEntity() *entity.AuthorChurn {
+	return &entity.AuthorChurn{
+		Author:      model.Author,
+		AuthorLogin: model.AuthorLogin,
+		Commits:     model.Commits,
+		Additions:   model.Additions,
+		Deletions:   model.Deletions,
+	}
+}
+
+// FileChurn is the pre-aggregated count of commits that changed a file in a repository.
+type FileChurn struct {
+	gorm.Model
+	RepositoryID uint   `gorm:"index"`
+	Filename     string `gorm:"filename"`
+	Changes      int    `gorm:"changes"`
+	Additions    int    `gorm:"additions"`
+	Deletions    int    `gorm:"deletions"`
+}
+
+func (model *FileChurn) ToEntity() *entity.FileChurn {
+	return &entity.FileChurn{
+		Filename:  model.Filename,
+		Changes:   model.Changes,
+		Additions: model.Additions,
+		Deletions: model.Deletions,
+	}
+}
+
+// RepositoryChurn totals the AuthorChurn rows of a repository.
+type RepositoryChurn struct {
+	RepositoryName string
+	Commits        int
+	Additions      int
+	Deletions      int
+	Authors        []*AuthorChurn `gorm:"-"`
+}
+
+func (model *RepositoryChurn) ToEntity() *entity.RepositoryChurn {
+	authors := make([]*entity.AuthorChurn, len(model.Authors))
+	for i, author := range model.Authors {
+		authors[i] = author.ToEntity()
+	}
+	return &entity.RepositoryChurn{
+		Repository: model.RepositoryName,
+		Commits:    model.Commits,
+		Additions:  model.Additions,
+		Deletions:  model.Deletions,
+		Authors:    authors,
+	}
+}
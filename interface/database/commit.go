@@ -9,20 +9,70 @@ type Commit struct {
 	gorm.Model
 	RepositoryName string      `gorm:"repository_name"`
 	Repository     *Repository `gorm:"foreignKey:RepositoryName"`
-	Message        string      `gorm:"message" json:"message"`
-	Author         string      `gorm:"author" json:"author"`
-	Date           string      `gorm:"string" json:"date"`
-	URL            string      `gorm:"html_url" json:"html_url"`
-	SHA            string      `gorm:"sha" json:"sha"`
+	// RepositoryID tells apart repositories of the same name under different owners; a commit is
+	// stored once per repository it was fetched for, so a fork keeps the history it shares with its parent
+	RepositoryID uint   `gorm:"uniqueIndex:idx_commit_repository_sha" json:"-"`
+	Message      string `gorm:"message" json:"message"`
+	Author       string `gorm:"author" json:"author"`
+	AuthorEmail  string `gorm:"author_email" json:"author_email"`
+	AuthorLogin  string `gorm:"author_login" json:"author_login"`
+	AuthorID     int    `gorm:"author_id" json:"author_id"`
+	// AuthorKey is what author aggregates are keyed on; see entity.AuthorKey
+	AuthorKey      string `gorm:"index" json:"-"`
+	Date           string `gorm:"string" json:"date"`
+	Committer      string `gorm:"committer" json:"committer"`
+	CommitterEmail string `gorm:"committer_email" json:"committer_email"`
+	CommitterLogin string `gorm:"committer_login" json:"committer_login"`
+	CommitterID    int    `gorm:"committer_id" json:"committer_id"`
+	CommitterDate  string `gorm:"committer_date" json:"committer_date"`
+	// Parents are stored comma separated like issue labels; nil for commits stored before parents were recorded
+	Parents      *string `gorm:"parents" json:"-"`
+	URL          string  `gorm:"html_url" json:"html_url"`
+	SHA          string  `gorm:"uniqueIndex:idx_commit_repository_sha" json:"sha"`
+	Additions    int     `gorm:"additions" json:"additions"`
+	Deletions    int     `gorm:"deletions" json:"deletions"`
+	ChangedFiles int     `gorm:"changed_files" json:"changed_files"`
+	// StatsFetched is set once the commit's stats and files have been fetched from its detail endpoint
+	StatsFetched bool `gorm:"stats_fetched" json:"-"`
+}
+
+// AuthorIdentity is who the commit is credited to. Commits stored before authors were keyed
+// on their identity only have a name to go by.
+func (model *Commit) AuthorIdentity() entity.AuthorIdentity {
+	key := model.AuthorKey
+	if key == "" {
+		key = entity.AuthorKey("", 0, "", "", model.Author)
+	}
+	return entity.AuthorIdentity{Key: key, Name: model.Author, Login: model.AuthorLogin}
+}
+
+// ParentSHAs lists the commits this one was made on top of, and whether they were recorded at all.
+func (model *Commit) ParentSHAs() ([]string, bool) {
+	if model.Parents == nil {
+		return nil, false
+	}
+	return splitNames(*model.Parents), true
 }
 
 func (model *Commit) ToEntity() *entity.Commit {
 	return &entity.Commit{
-		ID:      model.ID,
-		SHA:     model.SHA,
-		Message: model.Message,
-		Author:  model.Author,
-		Date:    model.Date,
-		URL:     model.URL,
+		ID:             model.ID,
+		SHA:            model.SHA,
+		Message:        model.Message,
+		Author:         model.Author,
+		AuthorEmail:    model.AuthorEmail,
+		AuthorLogin:    model.AuthorLogin,
+		AuthorID:       model.AuthorID,
+		Date:           model.Date,
+		Committer:      model.Committer,
+		CommitterEmail: model.CommitterEmail,
+		CommitterLogin: model.CommitterLogin,
+		CommitterID:    model.CommitterID,
+		CommitterDate:  model.CommitterDate,
+		URL:            model.URL,
+
+		Additions:    model.Additions,
+		Deletions:    model.Deletions,
+		ChangedFiles: model.ChangedFiles,
 	}
 }
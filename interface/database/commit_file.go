@@ -0,0 +1,16 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+// CommitFile is a file touched by a commit, with the lines the commit added and removed in it.
+type CommitFile struct {
+	gorm.Model
+	RepositoryID uint   `gorm:"index"`
+	CommitSHA    string `gorm:"index"`
+	Filename     string `gorm:"filename"`
+	Status       string `gorm:"status"`
+	Additions    int    `gorm:"additions"`
+	Deletions    int    `gorm:"deletions"`
+}
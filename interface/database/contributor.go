@@ -0,0 +1,43 @@
+package database
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+// Contributor holds GitHub's own totals for one contributor of a repository, as
+// reported by the contributors and contributor statistics endpoints.
+type Contributor struct {
+	gorm.Model
+	RepositoryID  uint        `gorm:"uniqueIndex:idx_repository_contributor"`
+	Repository    *Repository `gorm:"foreignKey:RepositoryID"`
+	Login         string      `gorm:"uniqueIndex:idx_repository_contributor"`
+	RemoteID      int         `gorm:"remote_id"`
+	Contributions int         `gorm:"contributions"`
+	// StatsCommits, StatsAdditions and StatsDeletions come from /stats/contributors,
+	// which GitHub computes in the background and may not have ready yet
+	StatsCommits   int `gorm:"stats_commits"`
+	StatsAdditions int `gorm:"stats_additions"`
+	StatsDeletions int `gorm:"stats_deletions"`
+	// Adjustment is the correction already applied to the author's commit count
+	// to bring it in line with GitHub, so correcting twice changes nothing
+	Adjustment int `gorm:"adjustment"`
+}
+
+// AuthorLoginCommitCount is the number of stored commits of a repository by one author name and login.
+type AuthorLoginCommitCount struct {
+	AuthorLogin string
+	AuthorKey   string
+	Author      string
+	Commits     int
+}
+
+func (model *Contributor) ToEntity() *entity.Contributor {
+	return &entity.Contributor{
+		Login:          model.Login,
+		Contributions:  model.Contributions,
+		StatsCommits:   model.StatsCommits,
+		StatsAdditions: model.StatsAdditions,
+		StatsDeletions: model.StatsDeletions,
+	}
+}
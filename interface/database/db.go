@@ -26,9 +26,54 @@ func ConnectToDB(url string) {
 
 func AutoMigrate() {
 	log.Println("Auto Migrating Models...")
-	err := DB.AutoMigrate(&Repository{}, &Commit{}, &User{}, &AuthorCommitCount{})
+	purgeDeletedCommits(DB)
+	err := DB.AutoMigrate(&Repository{}, &Commit{}, &User{}, &AuthorCommitCount{}, &HTTPCacheEntry{}, &CommitFile{}, &AuthorChurn{}, &FileChurn{}, &Branch{}, &Tag{}, &CommitBranch{}, &Release{}, &PullRequest{}, &Issue{}, &Contributor{}, &RepositoryLanguage{})
 	if err != nil {
 		panic(err)
 	}
+	backfillAuthorKeys(DB)
+	backfillRepositoryIDs(DB)
 	log.Println("Migrated DB Successfully")
 }
+
+// purgeDeletedCommits drops the commits resets used to only mark as deleted. A commit fetched again
+// after a reset was stored next to its deleted row, which would break the unique index on the
+// repository and sha of a commit.
+func purgeDeletedCommits(db *gorm.DB) {
+	if !db.Migrator().HasTable(&Commit{}) {
+		return
+	}
+	err := db.Unscoped().Where("deleted_at IS NOT NULL").Delete(&Commit{}).Error
+	if err != nil {
+		panic(err)
+	}
+}
+
+// backfillAuthorKeys keys the commits and author aggregates stored before authors were told apart
+// by identity on the only thing they were told apart by then, their name, so the aggregates keep
+// matching the commits they were counted from.
+func backfillAuthorKeys(db *gorm.DB) {
+	for _, model := range []interface{}{&Commit{}, &AuthorCommitCount{}, &AuthorChurn{}} {
+		err := db.Model(model).Where("author_key = '' OR author_key IS NULL").
+			Update("author_key", gorm.Expr("'name:' || author")).Error
+		if err != nil {
+			panic(err)
+		}
+	}
+}
+
+// backfillRepositoryIDs points the rows stored while these tables were keyed by repository name
+// at a repository. A name shared by several owners' repositories cannot be told apart any more,
+// so such rows go to the repository of that name stored first.
+func backfillRepositoryIDs(db *gorm.DB) {
+	for _, table := range []string{"commits", "commit_files", "author_churns", "file_churns", "branches", "tags", "commit_branches"} {
+		if !db.Migrator().HasColumn(table, "repository_name") {
+			continue
+		}
+		err := db.Table(table).Where("repository_id = 0 OR repository_id IS NULL").
+			Update("repository_id", gorm.Expr("(SELECT MIN(repositories.id) FROM repositories WHERE repositories.name = "+table+".repository_name)")).Error
+		if err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+package database
+
+import "gorm.io/gorm"
+
+// HTTPCacheEntry holds the validators GitHub returned for a URL, along with the
+// body they describe so a 304 Not Modified can still be answered in full.
+type HTTPCacheEntry struct {
+	gorm.Model
+	URL          string `gorm:"uniqueIndex"`
+	ETag         string `gorm:"etag"`
+	LastModified string `gorm:"last_modified"`
+	Body         string `gorm:"body"`
+}
@@ -0,0 +1,58 @@
+package database
+
+import (
+	"strings"
+
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+type Issue struct {
+	gorm.Model
+	RemoteID     int         `gorm:"uniqueIndex"`
+	RepositoryID uint        `gorm:"index"`
+	Repository   *Repository `gorm:"foreignKey:RepositoryID"`
+	Number       int         `gorm:"number"`
+	Title        string      `gorm:"title"`
+	State        string      `gorm:"state"`
+	// Labels and Assignees are stored comma separated, wrapped in commas so a single
+	// name can be matched with LIKE '%,name,%'
+	Labels          string `gorm:"labels"`
+	Author          string `gorm:"author"`
+	Assignees       string `gorm:"assignees"`
+	RemoteCreatedAt string `gorm:"remote_created_at"`
+	RemoteUpdatedAt string `gorm:"remote_updated_at"`
+	ClosedAt        string `gorm:"closed_at"`
+	URL             string `gorm:"html_url"`
+}
+
+func joinNames(names []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	return "," + strings.Join(names, ",") + ","
+}
+
+func splitNames(joined string) []string {
+	joined = strings.Trim(joined, ",")
+	if joined == "" {
+		return []string{}
+	}
+	return strings.Split(joined, ",")
+}
+
+func (model *Issue) ToEntity() *entity.Issue {
+	return &entity.Issue{
+		ID:        model.ID,
+		Number:    model.Number,
+		Title:     model.Title,
+		State:     model.State,
+		Labels:    splitNames(model.Labels),
+		Author:    model.Author,
+		Assignees: splitNames(model.Assignees),
+		CreatedAt: model.RemoteCreatedAt,
+		UpdatedAt: model.RemoteUpdatedAt,
+		ClosedAt:  model.ClosedAt,
+		URL:       model.URL,
+	}
+}
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+// RepositoryLanguage is the bytes of code in one language of a repository, as reported by
+// the languages endpoint. A repository's languages are replaced as a whole when it changes.
+type RepositoryLanguage struct {
+	gorm.Model
+	RepositoryID uint        `gorm:"uniqueIndex:idx_repository_language"`
+	Repository   *Repository `gorm:"foreignKey:RepositoryID"`
+	Language     string      `gorm:"uniqueIndex:idx_repository_language"`
+	Bytes        int         `gorm:"bytes"`
+	// Repositories is only set when languages are summed across repositories
+	Repositories int `gorm:"->;-:migration"`
+}
+
+func (model *RepositoryLanguage) ToEntity() *entity.LanguageShare {
+	return &entity.LanguageShare{
+		Language:     model.Language,
+		Bytes:        model.Bytes,
+		Repositories: model.Repositories,
+	}
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+type PullRequest struct {
+	gorm.Model
+	RemoteID        int         `gorm:"uniqueIndex"`
+	RepositoryID    uint        `gorm:"index"`
+	Repository      *Repository `gorm:"foreignKey:RepositoryID"`
+	Number          int         `gorm:"number"`
+	Title           string      `gorm:"title"`
+	State           string      `gorm:"state"`
+	Draft           bool        `gorm:"draft"`
+	Author          string      `gorm:"author"`
+	RemoteCreatedAt string      `gorm:"remote_created_at"`
+	RemoteUpdatedAt string      `gorm:"remote_updated_at"`
+	MergedAt        string      `gorm:"merged_at"`
+	ClosedAt        string      `gorm:"closed_at"`
+	BaseRef         string      `gorm:"base_ref"`
+	HeadRef         string      `gorm:"head_ref"`
+	MergeCommitSHA  string      `gorm:"merge_commit_sha"`
+	URL             string      `gorm:"html_url"`
+}
+
+func (model *PullRequest) ToEntity() *entity.PullRequest {
+	return &entity.PullRequest{
+		ID:             model.ID,
+		Number:         model.Number,
+		Title:          model.Title,
+		State:          model.State,
+		Draft:          model.Draft,
+		Author:         model.Author,
+		CreatedAt:      model.RemoteCreatedAt,
+		MergedAt:       model.MergedAt,
+		ClosedAt:       model.ClosedAt,
+		BaseRef:        model.BaseRef,
+		HeadRef:        model.HeadRef,
+		MergeCommitSHA: model.MergeCommitSHA,
+		URL:            model.URL,
+	}
+}
@@ -0,0 +1,36 @@
+package database
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+type Release struct {
+	gorm.Model
+	RemoteID     int         `gorm:"uniqueIndex"`
+	RepositoryID uint        `gorm:"index"`
+	Repository   *Repository `gorm:"foreignKey:RepositoryID"`
+	TagName      string      `gorm:"tag_name"`
+	Name         string      `gorm:"name"`
+	Body         string      `gorm:"body"`
+	Draft        bool        `gorm:"draft"`
+	Prerelease   bool        `gorm:"prerelease"`
+	Author       string      `gorm:"author"`
+	PublishedAt  string      `gorm:"published_at"`
+	URL          string      `gorm:"html_url"`
+}
+
+func (model *Release) ToEntity() *entity.Release {
+	return &entity.Release{
+		ID:          model.ID,
+		RemoteID:    model.RemoteID,
+		TagName:     model.TagName,
+		Name:        model.Name,
+		Body:        model.Body,
+		Draft:       model.Draft,
+		Prerelease:  model.Prerelease,
+		Author:      model.Author,
+		PublishedAt: model.PublishedAt,
+		URL:         model.URL,
+	}
+}
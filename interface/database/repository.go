@@ -1,41 +1,95 @@
 package database
 
 import (
+	"github.com/midedickson/github-service/dto"
 	"github.com/midedickson/github-service/entity"
 	"gorm.io/gorm"
 )
 
 type Repository struct {
 	gorm.Model
-	RemoteID        int    `gorm:"remote_id"`
-	OwnerID         uint   `gorm:"owner_id"`
-	Owner           *User  `gorm:"foreignKey:OwnerID"`
-	Name            string `gorm:"name"`
-	Description     string `gorm:"description"`
-	URL             string `gorm:"html_url"`
-	Language        string `gorm:"language"`
+	RemoteID    int    `gorm:"remote_id"`
+	OwnerID     uint   `gorm:"owner_id"`
+	Owner       *User  `gorm:"foreignKey:OwnerID"`
+	Name        string `gorm:"name"`
+	Description string `gorm:"description"`
+	URL         string `gorm:"html_url"`
+	Language    string `gorm:"language"`
+	Fork        bool   `gorm:"fork"`
+	Archived    bool   `gorm:"archived"`
+	Visibility  string `gorm:"visibility"`
+	// Topics are stored comma separated like issue labels, so a single topic can be matched with LIKE '%,topic,%'
+	Topics          string `gorm:"topics"`
+	License         string `gorm:"license"`
 	ForksCount      int    `gorm:"forks_count"`
 	StarsCount      int    `gorm:"stargazers_count"`
 	OpenIssues      int    `gorm:"open_issues_count"`
 	Watchers        int    `gorm:"watchers_count"`
+	Size            int    `gorm:"size"`
 	RemoteCreatedAt string `gorm:"remote_created_at"`
 	RemoteUpdatedAt string `gorm:"remote_updated_at"`
+	RemotePushedAt  string `gorm:"remote_pushed_at"`
+	// LanguagesUpdatedAt is the RemoteUpdatedAt the languages were last fetched at
+	LanguagesUpdatedAt string `gorm:"languages_updated_at"`
+	// ContributorsUpdatedAt is the RemoteUpdatedAt the contributor totals were last fetched at
+	ContributorsUpdatedAt string `gorm:"contributors_updated_at"`
+	// PullRequestsSyncedAt is the newest pull request update stored by the last sync that fetched every page
+	PullRequestsSyncedAt string `gorm:"pull_requests_synced_at"`
+	// IssuesSyncedAt is the newest issue update stored by the last sync that fetched every page
+	IssuesSyncedAt string `gorm:"issues_synced_at"`
+	DefaultBranch  string `gorm:"default_branch"`
+	Provider       string `gorm:"provider;default:github"`
 }
 
 func (model *Repository) ToEntity() *entity.Repository {
 	return &entity.Repository{
-		ID:              model.ID,
-		RemoteID:        model.RemoteID,
-		Owner:           model.Owner.ToEntity(),
-		Name:            model.Name,
-		Description:     model.Description,
-		URL:             model.URL,
-		Language:        model.Language,
-		ForksCount:      model.ForksCount,
-		StarsCount:      model.StarsCount,
-		OpenIssues:      model.OpenIssues,
-		Watchers:        model.Watchers,
-		RemoteCreatedAt: model.RemoteCreatedAt,
-		RemoteUpdatedAt: model.RemoteUpdatedAt,
+		ID:                    model.ID,
+		RemoteID:              model.RemoteID,
+		Owner:                 model.Owner.ToEntity(),
+		Name:                  model.Name,
+		Description:           model.Description,
+		URL:                   model.URL,
+		Language:              model.Language,
+		Fork:                  model.Fork,
+		Archived:              model.Archived,
+		Visibility:            model.Visibility,
+		Topics:                splitNames(model.Topics),
+		License:               model.License,
+		ForksCount:            model.ForksCount,
+		StarsCount:            model.StarsCount,
+		OpenIssues:            model.OpenIssues,
+		Watchers:              model.Watchers,
+		Size:                  model.Size,
+		RemoteCreatedAt:       model.RemoteCreatedAt,
+		RemoteUpdatedAt:       model.RemoteUpdatedAt,
+		RemotePushedAt:        model.RemotePushedAt,
+		LanguagesUpdatedAt:    model.LanguagesUpdatedAt,
+		ContributorsUpdatedAt: model.ContributorsUpdatedAt,
+		PullRequestsSyncedAt:  model.PullRequestsSyncedAt,
+		IssuesSyncedAt:        model.IssuesSyncedAt,
+		DefaultBranch:         model.DefaultBranch,
+		Provider:              model.Provider,
 	}
 }
+
+// Matches reports whether the record already holds everything remoteRepoInfo says about the
+// repository, so storing it would change nothing.
+func (model *Repository) Matches(remoteRepoInfo *dto.RepositoryInfoResponseDTO) bool {
+	return model.Name == remoteRepoInfo.Name &&
+		model.Description == remoteRepoInfo.Description &&
+		model.URL == remoteRepoInfo.HtmlUrl &&
+		model.Language == remoteRepoInfo.Language &&
+		model.Fork == remoteRepoInfo.Fork &&
+		model.Archived == remoteRepoInfo.Archived &&
+		model.Visibility == remoteRepoInfo.RepositoryVisibility() &&
+		model.Topics == joinNames(remoteRepoInfo.Topics) &&
+		model.License == remoteRepoInfo.LicenseID() &&
+		model.ForksCount == remoteRepoInfo.ForksCount &&
+		model.StarsCount == remoteRepoInfo.StarsCount &&
+		model.OpenIssues == remoteRepoInfo.OpenIssues &&
+		model.Watchers == remoteRepoInfo.Watchers &&
+		model.Size == remoteRepoInfo.Size &&
+		model.RemoteUpdatedAt == remoteRepoInfo.UpdatedAt &&
+		model.RemotePushedAt == remoteRepoInfo.PushedAt &&
+		model.DefaultBranch == remoteRepoInfo.DefaultBranch
+}
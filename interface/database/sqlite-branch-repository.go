@@ -0,0 +1,95 @@
+package database
+
+import (
+	"log"
+
+	"github.com/midedickson/github-service/dto"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type SqliteBranchRepository struct {
+	DB *gorm.DB
+}
+
+func NewSqliteBranchRepository(db *gorm.DB) *SqliteBranchRepository {
+	return &SqliteBranchRepository{DB: db}
+}
+
+func (s *SqliteBranchRepository) StoreBranch(repoID uint, remoteBranch *dto.BranchResponseDTO) (*Branch, error) {
+	//  logic to store a branch, or move its head if we already know it
+	branch := &Branch{}
+	err := s.DB.Where("repository_id =?", repoID).Where("name =?", remoteBranch.Name).FirstOrInit(branch, Branch{RepositoryID: repoID, Name: remoteBranch.Name}).Error
+	if err != nil {
+		return nil, err
+	}
+	branch.SHA = remoteBranch.SHA
+	branch.Protected = remoteBranch.Protected
+	err = s.DB.Save(branch).Error
+	if err != nil {
+		log.Printf("Error in saving branch %s of repo %d: %v", remoteBranch.Name, repoID, err)
+		return nil, err
+	}
+	return branch, nil
+}
+
+func (s *SqliteBranchRepository) MarkBranchSynced(branch *Branch, sha string) error {
+	branch.SyncedSHA = sha
+	return s.DB.Model(branch).Update("synced_sha", sha).Error
+}
+
+func (s *SqliteBranchRepository) GetRepositoryBranches(repoID uint) ([]*Branch, error) {
+	branches := &[]*Branch{}
+	err := s.DB.Where("repository_id =?", repoID).Order("name").Find(branches).Error
+	if err != nil {
+		log.Printf("Error fetching branches of repo %d: %v", repoID, err)
+		return nil, err
+	}
+	return *branches, nil
+}
+
+func (s *SqliteBranchRepository) StoreTags(repoID uint, remoteTags *[]dto.TagResponseDTO) error {
+	//  logic to store tags, moving any that now point at another commit
+	for _, remoteTag := range *remoteTags {
+		tag := &Tag{}
+		err := s.DB.Where("repository_id =?", repoID).Where("name =?", remoteTag.Name).FirstOrInit(tag, Tag{RepositoryID: repoID, Name: remoteTag.Name}).Error
+		if err != nil {
+			return err
+		}
+		if tag.ID != 0 && tag.SHA == remoteTag.SHA {
+			continue
+		}
+		tag.SHA = remoteTag.SHA
+		err = s.DB.Save(tag).Error
+		if err != nil {
+			log.Printf("Error in saving tag %s of repo %d: %v", remoteTag.Name, repoID, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SqliteBranchRepository) GetRepositoryTags(repoID uint) ([]*Tag, error) {
+	tags := &[]*Tag{}
+	err := s.DB.Where("repository_id =?", repoID).Order("name").Find(tags).Error
+	if err != nil {
+		log.Printf("Error fetching tags of repo %d: %v", repoID, err)
+		return nil, err
+	}
+	return *tags, nil
+}
+
+func (s *SqliteBranchRepository) AddCommitsToBranch(repoID uint, branchName string, shas []string) error {
+	if len(shas) == 0 {
+		return nil
+	}
+	memberships := make([]*CommitBranch, len(shas))
+	for i, sha := range shas {
+		memberships[i] = &CommitBranch{RepositoryID: repoID, BranchName: branchName, CommitSHA: sha}
+	}
+	err := s.DB.Clauses(clause.OnConflict{DoNothing: true}).Create(&memberships).Error
+	if err != nil {
+		log.Printf("Error in adding commits to branch %s of repo %d: %v", branchName, repoID, err)
+	}
+	return err
+}
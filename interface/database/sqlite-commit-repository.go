@@ -17,21 +17,22 @@ func NewSqliteCommitRepository(db *gorm.DB) *SqliteCommitRepository {
 	return &SqliteCommitRepository{DB: db}
 }
 
-func (s *SqliteCommitRepository) StoreRepositoryCommits(commitRepoInfos *[]dto.CommitResponseDTO, repoName string, owner *entity.User) error {
-	//  logic to store commit info in the database
+func (s *SqliteCommitRepository) StoreRepositoryCommits(commitRepoInfos *[]dto.CommitResponseDTO, repoName string, owner *entity.User) ([]*Commit, error) {
+	//  logic to store commit info in the database, returning the commits we did not have yet
 	repo := &Repository{}
 	err := s.DB.Where("owner_id =?", owner.ID).Where("name =?", repoName).First(repo).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return fmt.Errorf("repository not found for owner %v and repo %v", owner.Username, repoName)
+			return nil, fmt.Errorf("repository not found for owner %v and repo %v", owner.Username, repoName)
 
 		}
-		return err
+		return nil, err
 	}
+	newCommits := []*Commit{}
 
 	for _, commit := range *commitRepoInfos {
-		// check if this commit already exists in our database
-		existingCommit, err := s.GetCommitBySHA(commit.SHA)
+		// check if this commit already exists for this repository
+		existingCommit, err := s.GetCommitBySHA(repo.ID, commit.SHA)
 		if err != nil {
 			log.Println("Error in checking existing commits by sha")
 			continue
@@ -41,26 +42,39 @@ func (s *SqliteCommitRepository) StoreRepositoryCommits(commitRepoInfos *[]dto.C
 			log.Printf("Commit with SHA: %s already exists; skipping", existingCommit.SHA)
 			continue
 		}
+		parents := joinNames(commit.Parents)
 		newCommit := &Commit{
 			RepositoryName: repoName,
+			RepositoryID:   repo.ID,
 			SHA:            commit.SHA,
 			Message:        commit.Message,
 			Author:         commit.Author,
+			AuthorEmail:    commit.AuthorEmail,
+			AuthorLogin:    commit.AuthorLogin,
+			AuthorID:       commit.AuthorID,
+			AuthorKey:      entity.AuthorKey(owner.Provider, commit.AuthorID, commit.AuthorLogin, commit.AuthorEmail, commit.Author),
 			Date:           commit.Date,
+			Committer:      commit.Committer,
+			CommitterEmail: commit.CommitterEmail,
+			CommitterLogin: commit.CommitterLogin,
+			CommitterID:    commit.CommitterID,
+			CommitterDate:  commit.CommitterDate,
+			Parents:        &parents,
 		}
 		log.Printf("New commit to be created: %v", newCommit)
 		err = s.DB.Create(newCommit).Error
 		if err != nil {
 			log.Printf("Error in saving commits with SHA: %s", newCommit.SHA)
-			return err
+			return nil, err
 		}
+		newCommits = append(newCommits, newCommit)
 	}
-	return nil
+	return newCommits, nil
 }
 
-func (s *SqliteCommitRepository) GetCommitBySHA(sha string) (*Commit, error) {
+func (s *SqliteCommitRepository) GetCommitBySHA(repoID uint, sha string) (*Commit, error) {
 	commit := &Commit{}
-	err := s.DB.Where("sha =?", sha).First(commit).Error
+	err := s.DB.Where("repository_id =?", repoID).Where("sha =?", sha).First(commit).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -70,10 +84,10 @@ func (s *SqliteCommitRepository) GetCommitBySHA(sha string) (*Commit, error) {
 	return commit, nil
 }
 
-func (s *SqliteCommitRepository) GetRepositoryCommits(repoName string) ([]*Commit, error) {
-	//  logic to retrieve commit info from the database by repository name
+func (s *SqliteCommitRepository) GetRepositoryCommits(repoID uint) ([]*Commit, error) {
+	//  logic to retrieve commit info from the database by repository
 	commits := &[]*Commit{}
-	err := s.DB.Where("repository_name =?", repoName).Find(commits).Error
+	err := s.DB.Where("repository_id =?", repoID).Find(commits).Error
 	if err != nil {
 		log.Printf("%v", err)
 		return nil, err
@@ -81,62 +95,108 @@ func (s *SqliteCommitRepository) GetRepositoryCommits(repoName string) ([]*Commi
 	return *commits, nil
 }
 
-func (s *SqliteCommitRepository) GetMostRecentCommitInRepository(repoName string) (*Commit, error) {
-	commit := &Commit{}
-	err := s.DB.Where("repository_name =?", repoName).Order("created_at DESC").First(commit).Error
+func (s *SqliteCommitRepository) GetBranchCommits(repoID uint, branchName string) ([]*Commit, error) {
+	//  logic to retrieve the commits of a repository that are on a branch
+	commits := &[]*Commit{}
+	err := s.DB.Joins("JOIN commit_branches ON commit_branches.commit_sha = commits.sha AND commit_branches.repository_id = commits.repository_id").
+		Where("commit_branches.repository_id =?", repoID).Where("commit_branches.branch_name =?", branchName).
+		Find(commits).Error
 	if err != nil {
-		if err == gorm.ErrRecordNotFound {
-			return nil, nil
-		}
+		log.Printf("Error fetching commits on branch %s of repo %d: %v", branchName, repoID, err)
 		return nil, err
 	}
-	return commit, nil
+	return *commits, nil
 }
 
-func (s *SqliteCommitRepository) DeleteUntilSHA(repoName, sha string) error {
-	allCommits := &[]*Commit{}
-
-	// get all the commits in descending order of when they were created
-	err := s.DB.Where("repository_name =?", repoName).Order("created_at DESC").Find(allCommits).Error
+func (s *SqliteCommitRepository) GetRepositoryCommitsBetween(repoID uint, after, until string) ([]*Commit, error) {
+	//  logic to retrieve the commits of a repository dated after one time and up to another, newest first;
+	// dates are stored as RFC 3339 strings in UTC, so they compare in time order
+	commits := &[]*Commit{}
+	dbQueryBuilder := s.DB.Where("repository_id =?", repoID).Where("date <=?", until)
+	if after != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("date >?", after)
+	}
+	err := dbQueryBuilder.Order("date DESC").Find(commits).Error
 	if err != nil {
-		log.Printf("Error fetching all commits in created at order: %v", err)
-		return err
+		log.Printf("Error fetching commits of repo %d between %s and %s: %v", repoID, after, until, err)
+		return nil, err
 	}
-	// we remove all the items from the most recent commits to the preferred sha we want to resr into
-	for _, commit := range *allCommits {
-		if commit.SHA == sha {
-			break
-		}
-		err = s.DB.Delete(commit).Error
+	return *commits, nil
+}
+
+func (s *SqliteCommitRepository) DeleteUntilSHA(repoID uint, sha string) error {
+	// the commits and the sync marks of the branches they were on are reset together, so the next
+	// sync of those branches fetches the deleted commits again instead of taking them as caught up
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		allCommits := &[]*Commit{}
+		// get all the commits in descending order of when they were created
+		err := tx.Where("repository_id =?", repoID).Order("created_at DESC").Find(allCommits).Error
 		if err != nil {
-			log.Printf("Error deleting commits after sha %s: %v", sha, err)
+			log.Printf("Error fetching all commits in created at order: %v", err)
 			return err
 		}
-	}
-
-	return nil
+		// we remove all the items from the most recent commits to the preferred sha we want to resr into
+		rewoundBranches := []string{}
+		for _, commit := range *allCommits {
+			if commit.SHA == sha {
+				break
+			}
+			if err := removeCommitStats(tx, commit); err != nil {
+				log.Printf("Error deleting commits after sha %s: %v", sha, err)
+				return err
+			}
+			branchNames := []string{}
+			err = tx.Model(&CommitBranch{}).Where("repository_id =?", repoID).Where("commit_sha =?", commit.SHA).Pluck("branch_name", &branchNames).Error
+			if err != nil {
+				return err
+			}
+			rewoundBranches = append(rewoundBranches, branchNames...)
+			err = tx.Where("repository_id =?", repoID).Where("commit_sha =?", commit.SHA).Delete(&CommitBranch{}).Error
+			if err != nil {
+				return err
+			}
+			// deleted for good, so the commit can be stored again when its branch is synced
+			if err := tx.Unscoped().Delete(commit).Error; err != nil {
+				log.Printf("Error deleting commits after sha %s: %v", sha, err)
+				return err
+			}
+		}
+		if len(rewoundBranches) == 0 {
+			return nil
+		}
+		// everything up to sha is still stored, so paging can stop there again
+		return tx.Model(&Branch{}).Where("repository_id =?", repoID).Where("name IN ?", rewoundBranches).Update("synced_sha", sha).Error
+	})
 }
 
-func (s *SqliteCommitRepository) AddAuthorCommitCount(author string, count int) error {
+func (s *SqliteCommitRepository) AddAuthorCommitCount(author entity.AuthorIdentity, count int) error {
 	authorCommitCount := &AuthorCommitCount{}
-	err := s.DB.Where("author =?", author).First(authorCommitCount).Error
+	err := s.DB.Where("author_key =?", author.Key).First(authorCommitCount).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			newAuthorCommitCount := &AuthorCommitCount{
-				Author:      author,
+				AuthorKey:   author.Key,
+				Author:      author.Name,
+				AuthorLogin: author.Login,
 				CommitCount: count,
 			}
 			err = s.DB.Create(newAuthorCommitCount).Error
 			if err != nil {
-				log.Printf("Error creating author commit count for author %s: %v", author, err)
+				log.Printf("Error creating author commit count for author %s: %v", author.Key, err)
 				return err
 			}
+			return nil
 		} else {
-			log.Printf("Error fetching author commit count for author %s: %v", author, err)
+			log.Printf("Error fetching author commit count for author %s: %v", author.Key, err)
 			return err
 		}
 	}
 	authorCommitCount.CommitCount += count
+	// the author is shown under the name and login they last committed with
+	authorCommitCount.Author = author.Name
+	if author.Login != "" {
+		authorCommitCount.AuthorLogin = author.Login
+	}
 	return s.DB.Save(authorCommitCount).Error
 }
 
@@ -149,3 +209,180 @@ func (s *SqliteCommitRepository) FindTopNAuthorsByCommitCounts(topN int) ([]*Aut
 	}
 	return *authorCounts, nil
 }
+
+func (s *SqliteCommitRepository) CountRepositoryCommitsByLogin(repoID uint, branchName string) ([]*AuthorLoginCommitCount, error) {
+	//  logic to count the stored commits on a branch of a repository per GitHub login, author key and author name;
+	// an empty branch counts every commit stored for the repository. Commits whose author email is not linked
+	// to an account have no login and are left out
+	counts := &[]*AuthorLoginCommitCount{}
+	dbQueryBuilder := s.DB.Model(&Commit{}).Select("author_login, author_key, author, COUNT(*) AS commits")
+	if branchName != "" {
+		dbQueryBuilder = dbQueryBuilder.Joins("JOIN commit_branches ON commit_branches.commit_sha = commits.sha AND commit_branches.repository_id = commits.repository_id").
+			Where("commit_branches.repository_id =?", repoID).Where("commit_branches.branch_name =?", branchName)
+	} else {
+		dbQueryBuilder = dbQueryBuilder.Where("repository_id =?", repoID)
+	}
+	err := dbQueryBuilder.Where("author_login <> ''").Group("author_login, author_key, author").Find(counts).Error
+	if err != nil {
+		log.Printf("Error counting commits by login in repo %d: %v", repoID, err)
+		return nil, err
+	}
+	return *counts, nil
+}
+
+func (s *SqliteCommitRepository) GetCommitsWithoutStats(repoID uint) ([]*Commit, error) {
+	commits := &[]*Commit{}
+	err := s.DB.Where("repository_id =?", repoID).Where("stats_fetched =?", false).Find(commits).Error
+	if err != nil {
+		log.Printf("Error fetching commits without stats in repo %d: %v", repoID, err)
+		return nil, err
+	}
+	return *commits, nil
+}
+
+func (s *SqliteCommitRepository) StoreCommitStats(repoID uint, commitDetail *dto.CommitDetailResponseDTO) error {
+	//  logic to store a commit's line stats and the files it touched, adding them to the churn aggregates
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		commit := &Commit{}
+		err := tx.Where("repository_id =?", repoID).Where("sha =?", commitDetail.SHA).First(commit).Error
+		if err != nil {
+			if err == gorm.ErrRecordNotFound {
+				return fmt.Errorf("commit %v not found in repo %v", commitDetail.SHA, repoID)
+			}
+			return err
+		}
+		if commit.StatsFetched {
+			// already counted
+			return nil
+		}
+		commit.Additions = commitDetail.Additions
+		commit.Deletions = commitDetail.Deletions
+		commit.ChangedFiles = len(commitDetail.Files)
+		commit.StatsFetched = true
+		err = tx.Save(commit).Error
+		if err != nil {
+			log.Printf("Error saving stats of commit %s: %v", commit.SHA, err)
+			return err
+		}
+		err = addAuthorChurn(tx, repoID, commit.AuthorIdentity(), 1, commit.Additions, commit.Deletions)
+		if err != nil {
+			return err
+		}
+		for _, file := range commitDetail.Files {
+			commitFile := &CommitFile{
+				RepositoryID: repoID,
+				CommitSHA:    commit.SHA,
+				Filename:     file.Filename,
+				Status:       file.Status,
+				Additions:    file.Additions,
+				Deletions:    file.Deletions,
+			}
+			err = tx.Create(commitFile).Error
+			if err != nil {
+				log.Printf("Error saving files of commit %s: %v", commit.SHA, err)
+				return err
+			}
+			err = addFileChurn(tx, repoID, file.Filename, 1, file.Additions, file.Deletions)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// removeCommitStats takes a deleted commit's stats back out of the churn aggregates.
+func removeCommitStats(tx *gorm.DB, commit *Commit) error {
+	if !commit.StatsFetched {
+		return nil
+	}
+	err := addAuthorChurn(tx, commit.RepositoryID, commit.AuthorIdentity(), -1, -commit.Additions, -commit.Deletions)
+	if err != nil {
+		return err
+	}
+	files := &[]*CommitFile{}
+	err = tx.Where("repository_id =?", commit.RepositoryID).Where("commit_sha =?", commit.SHA).Find(files).Error
+	if err != nil {
+		return err
+	}
+	for _, file := range *files {
+		err = addFileChurn(tx, commit.RepositoryID, file.Filename, -1, -file.Additions, -file.Deletions)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Where("repository_id =?", commit.RepositoryID).Where("commit_sha =?", commit.SHA).Delete(&CommitFile{}).Error
+}
+
+func addAuthorChurn(tx *gorm.DB, repoID uint, author entity.AuthorIdentity, commits, additions, deletions int) error {
+	authorChurn := &AuthorChurn{}
+	err := tx.Where("repository_id =?", repoID).Where("author_key =?", author.Key).
+		FirstOrInit(authorChurn, AuthorChurn{RepositoryID: repoID, AuthorKey: author.Key}).Error
+	if err != nil {
+		log.Printf("Error fetching churn for author %s: %v", author.Key, err)
+		return err
+	}
+	if commits > 0 {
+		authorChurn.Author = author.Name
+		if author.Login != "" {
+			authorChurn.AuthorLogin = author.Login
+		}
+	}
+	authorChurn.Commits += commits
+	authorChurn.Additions += additions
+	authorChurn.Deletions += deletions
+	return tx.Save(authorChurn).Error
+}
+
+func addFileChurn(tx *gorm.DB, repoID uint, filename string, changes, additions, deletions int) error {
+	fileChurn := &FileChurn{}
+	err := tx.Where("repository_id =?", repoID).Where("filename =?", filename).FirstOrInit(fileChurn, FileChurn{RepositoryID: repoID, Filename: filename}).Error
+	if err != nil {
+		log.Printf("Error fetching churn for file %s: %v", filename, err)
+		return err
+	}
+	fileChurn.Changes += changes
+	fileChurn.Additions += additions
+	fileChurn.Deletions += deletions
+	return tx.Save(fileChurn).Error
+}
+
+func (s *SqliteCommitRepository) FindTopNAuthorsByChurn(topN int) ([]*AuthorChurn, error) {
+	// the same author may have a row per repository, possibly under a different name in each
+	authorChurn := &[]*AuthorChurn{}
+	err := s.DB.Model(&AuthorChurn{}).
+		Select("author_key, max(author) AS author, max(author_login) AS author_login, sum(commits) AS commits, sum(additions) AS additions, sum(deletions) AS deletions").
+		Group("author_key").Order("sum(additions) + sum(deletions) DESC").Limit(topN).
+		Scan(authorChurn).Error
+	if err != nil {
+		log.Printf("Error fetching top %d authors by churn: %v", topN, err)
+		return nil, err
+	}
+	return *authorChurn, nil
+}
+
+func (s *SqliteCommitRepository) GetRepositoryChurn(repoID uint) (*RepositoryChurn, error) {
+	authors := &[]*AuthorChurn{}
+	err := s.DB.Where("repository_id =?", repoID).Order("additions + deletions DESC").Find(authors).Error
+	if err != nil {
+		log.Printf("Error fetching churn by author in repo %d: %v", repoID, err)
+		return nil, err
+	}
+	repoChurn := &RepositoryChurn{Authors: *authors}
+	for _, author := range *authors {
+		repoChurn.Commits += author.Commits
+		repoChurn.Additions += author.Additions
+		repoChurn.Deletions += author.Deletions
+	}
+	return repoChurn, nil
+}
+
+func (s *SqliteCommitRepository) FindMostChangedFiles(repoID uint, topN int) ([]*FileChurn, error) {
+	fileChurn := &[]*FileChurn{}
+	err := s.DB.Where("repository_id =?", repoID).Where("changes > 0").Order("changes DESC").Order("additions + deletions DESC").Limit(topN).Find(fileChurn).Error
+	if err != nil {
+		log.Printf("Error fetching top %d files by changes in repo %d: %v", topN, repoID, err)
+		return nil, err
+	}
+	return *fileChurn, nil
+}
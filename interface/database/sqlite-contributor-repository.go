@@ -0,0 +1,76 @@
+package database
+
+import (
+	"log"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+type SqliteContributorRepository struct {
+	DB *gorm.DB
+}
+
+func NewSqliteContributorRepository(db *gorm.DB) *SqliteContributorRepository {
+	return &SqliteContributorRepository{DB: db}
+}
+
+func (s *SqliteContributorRepository) findOrInitContributor(repo *entity.Repository, login string) (*Contributor, error) {
+	contributor := &Contributor{}
+	err := s.DB.Where("repository_id =?", repo.ID).Where("login =?", login).FirstOrInit(contributor, Contributor{RepositoryID: repo.ID, Login: login}).Error
+	return contributor, err
+}
+
+func (s *SqliteContributorRepository) StoreContributors(remoteContributors *[]dto.ContributorResponseDTO, repo *entity.Repository) error {
+	//  logic to store the contribution counts of a repository's contributors, updating those we already have
+	for _, remoteContributor := range *remoteContributors {
+		contributor, err := s.findOrInitContributor(repo, remoteContributor.Login)
+		if err != nil {
+			return err
+		}
+		contributor.RemoteID = remoteContributor.ID
+		contributor.Contributions = remoteContributor.Contributions
+		err = s.DB.Save(contributor).Error
+		if err != nil {
+			log.Printf("Error in saving contributor %s of repo %s: %v", remoteContributor.Login, repo.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SqliteContributorRepository) StoreContributorStats(remoteStats *[]dto.ContributorStatsResponseDTO, repo *entity.Repository) error {
+	//  logic to store the commit and line totals of a repository's contributors, updating those we already have
+	for _, remoteStat := range *remoteStats {
+		contributor, err := s.findOrInitContributor(repo, remoteStat.Login)
+		if err != nil {
+			return err
+		}
+		contributor.RemoteID = remoteStat.ID
+		contributor.StatsCommits = remoteStat.Total
+		contributor.StatsAdditions = remoteStat.Additions
+		contributor.StatsDeletions = remoteStat.Deletions
+		err = s.DB.Save(contributor).Error
+		if err != nil {
+			log.Printf("Error in saving contributor stats for %s of repo %s: %v", remoteStat.Login, repo.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SqliteContributorRepository) GetRepositoryContributors(repoID uint) ([]*Contributor, error) {
+	contributors := &[]*Contributor{}
+	err := s.DB.Where("repository_id =?", repoID).Order("contributions DESC").Order("login").Find(contributors).Error
+	if err != nil {
+		log.Printf("Error fetching contributors of repository %d: %v", repoID, err)
+		return nil, err
+	}
+	return *contributors, nil
+}
+
+func (s *SqliteContributorRepository) AddContributorAdjustment(contributor *Contributor, adjustment int) error {
+	contributor.Adjustment += adjustment
+	return s.DB.Model(contributor).Update("adjustment", contributor.Adjustment).Error
+}
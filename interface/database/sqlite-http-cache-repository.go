@@ -0,0 +1,44 @@
+package database
+
+import (
+	"gorm.io/gorm"
+)
+
+type SqliteHTTPCacheRepository struct {
+	DB *gorm.DB
+}
+
+func NewSqliteHTTPCacheRepository(db *gorm.DB) *SqliteHTTPCacheRepository {
+	return &SqliteHTTPCacheRepository{DB: db}
+}
+
+func (s *SqliteHTTPCacheRepository) GetHTTPCacheEntry(url string) (*HTTPCacheEntry, error) {
+	entry := &HTTPCacheEntry{}
+	err := s.DB.Where("url =?", url).First(entry).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return entry, nil
+}
+
+func (s *SqliteHTTPCacheRepository) StoreHTTPCacheEntry(url, etag, lastModified, body string) error {
+	existingEntry, err := s.GetHTTPCacheEntry(url)
+	if err != nil {
+		return err
+	}
+	if existingEntry != nil {
+		existingEntry.ETag = etag
+		existingEntry.LastModified = lastModified
+		existingEntry.Body = body
+		return s.DB.Save(existingEntry).Error
+	}
+	return s.DB.Create(&HTTPCacheEntry{
+		URL:          url,
+		ETag:         etag,
+		LastModified: lastModified,
+		Body:         body,
+	}).Error
+}
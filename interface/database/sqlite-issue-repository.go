@@ -0,0 +1,73 @@
+package database
+
+import (
+	"log"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/utils"
+	"gorm.io/gorm"
+)
+
+type SqliteIssueRepository struct {
+	DB *gorm.DB
+}
+
+func NewSqliteIssueRepository(db *gorm.DB) *SqliteIssueRepository {
+	return &SqliteIssueRepository{DB: db}
+}
+
+func (s *SqliteIssueRepository) StoreIssues(remoteIssues *[]dto.IssueResponseDTO, repo *entity.Repository) error {
+	//  logic to store issues, updating those we already have; pull requests listed among them are skipped
+	for _, remoteIssue := range *remoteIssues {
+		if remoteIssue.IsPullRequest {
+			continue
+		}
+		issue := &Issue{}
+		err := s.DB.Where("remote_id =?", remoteIssue.ID).FirstOrInit(issue, Issue{RemoteID: remoteIssue.ID}).Error
+		if err != nil {
+			return err
+		}
+		issue.RepositoryID = repo.ID
+		issue.Number = remoteIssue.Number
+		issue.Title = remoteIssue.Title
+		issue.State = remoteIssue.State
+		issue.Labels = joinNames(remoteIssue.Labels)
+		issue.Author = remoteIssue.Author
+		issue.Assignees = joinNames(remoteIssue.Assignees)
+		issue.RemoteCreatedAt = remoteIssue.CreatedAt
+		issue.RemoteUpdatedAt = remoteIssue.UpdatedAt
+		issue.ClosedAt = remoteIssue.ClosedAt
+		issue.URL = remoteIssue.URL
+		err = s.DB.Save(issue).Error
+		if err != nil {
+			log.Printf("Error in saving issue #%d of repo %s: %v", remoteIssue.Number, repo.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SqliteIssueRepository) SearchIssues(repoID uint, issueSearchParams *utils.IssueSearchParams) ([]*Issue, error) {
+	//  logic to retrieve the issues of a repository matching every given filter, newest first
+	issues := &[]*Issue{}
+	dbQueryBuilder := s.DB.Where("repository_id =?", repoID)
+	if issueSearchParams.State != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("state =?", issueSearchParams.State)
+	}
+	if issueSearchParams.Label != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("labels LIKE?", "%,"+issueSearchParams.Label+",%")
+	}
+	if issueSearchParams.Author != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("author =?", issueSearchParams.Author)
+	}
+	if issueSearchParams.Assignee != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("assignees LIKE?", "%,"+issueSearchParams.Assignee+",%")
+	}
+	err := dbQueryBuilder.Order("number DESC").Find(issues).Error
+	if err != nil {
+		log.Printf("Error fetching issues of repository %d: %v", repoID, err)
+		return nil, err
+	}
+	return *issues, nil
+}
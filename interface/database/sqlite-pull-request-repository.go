@@ -0,0 +1,63 @@
+package database
+
+import (
+	"log"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+type SqlitePullRequestRepository struct {
+	DB *gorm.DB
+}
+
+func NewSqlitePullRequestRepository(db *gorm.DB) *SqlitePullRequestRepository {
+	return &SqlitePullRequestRepository{DB: db}
+}
+
+func (s *SqlitePullRequestRepository) StorePullRequests(remotePullRequests *[]dto.PullRequestResponseDTO, repo *entity.Repository) error {
+	//  logic to store pull requests, updating those we already have as they get merged or closed
+	for _, remotePullRequest := range *remotePullRequests {
+		pullRequest := &PullRequest{}
+		err := s.DB.Where("remote_id =?", remotePullRequest.ID).FirstOrInit(pullRequest, PullRequest{RemoteID: remotePullRequest.ID}).Error
+		if err != nil {
+			return err
+		}
+		pullRequest.RepositoryID = repo.ID
+		pullRequest.Number = remotePullRequest.Number
+		pullRequest.Title = remotePullRequest.Title
+		pullRequest.State = remotePullRequest.State
+		pullRequest.Draft = remotePullRequest.Draft
+		pullRequest.Author = remotePullRequest.Author
+		pullRequest.RemoteCreatedAt = remotePullRequest.CreatedAt
+		pullRequest.RemoteUpdatedAt = remotePullRequest.UpdatedAt
+		pullRequest.MergedAt = remotePullRequest.MergedAt
+		pullRequest.ClosedAt = remotePullRequest.ClosedAt
+		pullRequest.BaseRef = remotePullRequest.BaseRef
+		pullRequest.HeadRef = remotePullRequest.HeadRef
+		pullRequest.MergeCommitSHA = remotePullRequest.MergeCommitSHA
+		pullRequest.URL = remotePullRequest.URL
+		err = s.DB.Save(pullRequest).Error
+		if err != nil {
+			log.Printf("Error in saving pull request #%d of repo %s: %v", remotePullRequest.Number, repo.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SqlitePullRequestRepository) GetRepositoryPullRequests(repoID uint, state string) ([]*PullRequest, error) {
+	//  logic to retrieve the pull requests of a repository, newest first; an empty state returns them all
+	pullRequests := &[]*PullRequest{}
+	dbQueryBuilder := s.DB.Where("repository_id =?", repoID)
+	if state != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("state =?", state)
+	}
+	err := dbQueryBuilder.Order("number DESC").Find(pullRequests).Error
+	if err != nil {
+		log.Printf("Error fetching pull requests of repository %d: %v", repoID, err)
+		return nil, err
+	}
+	return *pullRequests, nil
+}
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"log"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"gorm.io/gorm"
+)
+
+type SqliteReleaseRepository struct {
+	DB *gorm.DB
+}
+
+func NewSqliteReleaseRepository(db *gorm.DB) *SqliteReleaseRepository {
+	return &SqliteReleaseRepository{DB: db}
+}
+
+func (s *SqliteReleaseRepository) StoreReleases(remoteReleases *[]dto.ReleaseResponseDTO, repo *entity.Repository) error {
+	//  logic to store releases, updating those we already have since drafts get published and notes get edited
+	for _, remoteRelease := range *remoteReleases {
+		release := &Release{}
+		err := s.DB.Where("remote_id =?", remoteRelease.ID).FirstOrInit(release, Release{RemoteID: remoteRelease.ID}).Error
+		if err != nil {
+			return err
+		}
+		release.RepositoryID = repo.ID
+		release.TagName = remoteRelease.TagName
+		release.Name = remoteRelease.Name
+		release.Body = remoteRelease.Body
+		release.Draft = remoteRelease.Draft
+		release.Prerelease = remoteRelease.Prerelease
+		release.Author = remoteRelease.Author
+		release.PublishedAt = remoteRelease.PublishedAt
+		release.URL = remoteRelease.URL
+		err = s.DB.Save(release).Error
+		if err != nil {
+			log.Printf("Error in saving release %s of repo %s: %v", remoteRelease.TagName, repo.Name, err)
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *SqliteReleaseRepository) GetRepositoryReleases(repoID uint) ([]*Release, error) {
+	//  logic to retrieve the releases of a repository, newest first; drafts have no publish date and come last
+	releases := &[]*Release{}
+	err := s.DB.Where("repository_id =?", repoID).Order("published_at DESC").Find(releases).Error
+	if err != nil {
+		log.Printf("Error fetching releases of repository %d: %v", repoID, err)
+		return nil, err
+	}
+	return *releases, nil
+}
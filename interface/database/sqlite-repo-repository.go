@@ -1,6 +1,8 @@
 package database
 
 import (
+	"log"
+
 	"github.com/midedickson/github-service/dto"
 	"github.com/midedickson/github-service/entity"
 	"github.com/midedickson/github-service/utils"
@@ -18,25 +20,38 @@ func NewSqliteRepoRepository(db *gorm.DB) *SqliteRepoRepository {
 func (s *SqliteRepoRepository) StoreRepositoryInfo(remoteRepoInfo *dto.RepositoryInfoResponseDTO, owner *entity.User) (*Repository, error) {
 	//  logic to store repository info in the database
 
-	// check if this remote repository already exists in our database
-	existingRepo, err := s.GetRepositoryInfoByRemoteId(remoteRepoInfo.ID)
+	// check if this remote repository already exists in our database; remote IDs are only unique on one forge
+	provider := owner.Provider
+	if provider == "" {
+		provider = entity.ProviderGitHub
+	}
+	existingRepo, err := s.GetRepositoryInfoByRemoteId(provider, remoteRepoInfo.ID)
 	if err != nil {
 		return nil, err
 	}
 	if existingRepo != nil {
 		// repository already exists, update existing record;
-		if existingRepo.RemoteUpdatedAt != remoteRepoInfo.UpdatedAt {
+		if existingRepo.Matches(remoteRepoInfo) {
 			// but if only there has been an update
 			return existingRepo, nil
 		}
 		existingRepo.Name = remoteRepoInfo.Name
 		existingRepo.Description = remoteRepoInfo.Description
-		existingRepo.URL = remoteRepoInfo.URL
+		existingRepo.URL = remoteRepoInfo.HtmlUrl
 		existingRepo.Language = remoteRepoInfo.Language
+		existingRepo.Fork = remoteRepoInfo.Fork
+		existingRepo.Archived = remoteRepoInfo.Archived
+		existingRepo.Visibility = remoteRepoInfo.RepositoryVisibility()
+		existingRepo.Topics = joinNames(remoteRepoInfo.Topics)
+		existingRepo.License = remoteRepoInfo.LicenseID()
 		existingRepo.ForksCount = remoteRepoInfo.ForksCount
 		existingRepo.StarsCount = remoteRepoInfo.StarsCount
 		existingRepo.OpenIssues = remoteRepoInfo.OpenIssues
 		existingRepo.Watchers = remoteRepoInfo.Watchers
+		existingRepo.Size = remoteRepoInfo.Size
+		existingRepo.RemoteUpdatedAt = remoteRepoInfo.UpdatedAt
+		existingRepo.RemotePushedAt = remoteRepoInfo.PushedAt
+		existingRepo.DefaultBranch = remoteRepoInfo.DefaultBranch
 		return existingRepo, s.DB.Save(existingRepo).Error
 	}
 	newRepo := &Repository{
@@ -46,12 +61,21 @@ func (s *SqliteRepoRepository) StoreRepositoryInfo(remoteRepoInfo *dto.Repositor
 		Description:     remoteRepoInfo.Description,
 		URL:             remoteRepoInfo.HtmlUrl,
 		Language:        remoteRepoInfo.Language,
+		Fork:            remoteRepoInfo.Fork,
+		Archived:        remoteRepoInfo.Archived,
+		Visibility:      remoteRepoInfo.RepositoryVisibility(),
+		Topics:          joinNames(remoteRepoInfo.Topics),
+		License:         remoteRepoInfo.LicenseID(),
 		ForksCount:      remoteRepoInfo.ForksCount,
 		StarsCount:      remoteRepoInfo.StarsCount,
 		OpenIssues:      remoteRepoInfo.OpenIssues,
 		Watchers:        remoteRepoInfo.Watchers,
+		Size:            remoteRepoInfo.Size,
 		RemoteCreatedAt: remoteRepoInfo.CreatedAt,
 		RemoteUpdatedAt: remoteRepoInfo.UpdatedAt,
+		RemotePushedAt:  remoteRepoInfo.PushedAt,
+		DefaultBranch:   remoteRepoInfo.DefaultBranch,
+		Provider:        provider,
 	}
 	err = s.DB.Create(newRepo).Error
 	if err != nil {
@@ -62,10 +86,10 @@ func (s *SqliteRepoRepository) StoreRepositoryInfo(remoteRepoInfo *dto.Repositor
 	return &repository, nil
 }
 
-func (s *SqliteRepoRepository) GetRepositoryInfoByRemoteId(remoteID int) (*Repository, error) {
+func (s *SqliteRepoRepository) GetRepositoryInfoByRemoteId(provider string, remoteID int) (*Repository, error) {
 	//  logic to retrieve repository info from the database by remote ID
 	repo := &Repository{}
-	err := s.DB.Where("remote_id =?", remoteID).Preload("Owner").First(repo).Error
+	err := s.DB.Where("provider =?", provider).Where("remote_id =?", remoteID).Preload("Owner").First(repo).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, nil
@@ -101,6 +125,29 @@ func (s *SqliteRepoRepository) SearchRepository(ownerID uint, repoSearchParams *
 	if repoSearchParams.Language != "" {
 		dbQueryBuilder = dbQueryBuilder.Where("language =?", repoSearchParams.Language)
 	}
+	if repoSearchParams.Topic != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("topics LIKE?", "%,"+repoSearchParams.Topic+",%")
+	}
+	if repoSearchParams.Fork != nil {
+		dbQueryBuilder = dbQueryBuilder.Where("fork =?", *repoSearchParams.Fork)
+	}
+	if repoSearchParams.Archived != nil {
+		dbQueryBuilder = dbQueryBuilder.Where("archived =?", *repoSearchParams.Archived)
+	}
+	if repoSearchParams.Visibility != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("visibility =?", repoSearchParams.Visibility)
+	}
+	if repoSearchParams.License != "" {
+		// SPDX identifiers are matched case-insensitively, so mit finds MIT
+		dbQueryBuilder = dbQueryBuilder.Where("license =? COLLATE NOCASE", repoSearchParams.License)
+	}
+	if repoSearchParams.DefaultBranch != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("default_branch =?", repoSearchParams.DefaultBranch)
+	}
+	if repoSearchParams.PushedAfter != "" {
+		// push times are stored as RFC 3339 strings in UTC, so they compare in time order
+		dbQueryBuilder = dbQueryBuilder.Where("remote_pushed_at >=?", repoSearchParams.PushedAfter)
+	}
 
 	err := dbQueryBuilder.Preload("Owner").Find(&repos).Error
 	if err != nil {
@@ -118,3 +165,81 @@ func (s *SqliteRepoRepository) GetAllRepositories() ([]*Repository, error) {
 	}
 	return *repos, nil
 }
+
+func (s *SqliteRepoRepository) StoreRepositoryLanguages(languages map[string]int, repo *entity.Repository, updatedAt string) error {
+	//  logic to replace the languages of a repository, remembering which update of it they were fetched at
+	return s.DB.Transaction(func(tx *gorm.DB) error {
+		err := tx.Unscoped().Where("repository_id =?", repo.ID).Delete(&RepositoryLanguage{}).Error
+		if err != nil {
+			log.Printf("Error in clearing languages of repo %s: %v", repo.Name, err)
+			return err
+		}
+		for language, bytes := range languages {
+			err = tx.Create(&RepositoryLanguage{RepositoryID: repo.ID, Language: language, Bytes: bytes}).Error
+			if err != nil {
+				log.Printf("Error in saving language %s of repo %s: %v", language, repo.Name, err)
+				return err
+			}
+		}
+		return tx.Model(&Repository{}).Where("id =?", repo.ID).Update("languages_updated_at", updatedAt).Error
+	})
+}
+
+func (s *SqliteRepoRepository) GetRepositoryLanguages(repoID uint) ([]*RepositoryLanguage, error) {
+	languages := &[]*RepositoryLanguage{}
+	err := s.DB.Where("repository_id =?", repoID).Order("bytes DESC").Order("language").Find(languages).Error
+	if err != nil {
+		log.Printf("Error fetching languages of repo %d: %v", repoID, err)
+		return nil, err
+	}
+	return *languages, nil
+}
+
+func (s *SqliteRepoRepository) GetOwnerLanguages(ownerID uint) ([]*RepositoryLanguage, error) {
+	//  logic to sum the languages of every repository of an owner
+	languages := &[]*RepositoryLanguage{}
+	err := s.DB.Model(&RepositoryLanguage{}).
+		Select("repository_languages.language, sum(repository_languages.bytes) AS bytes, count(*) AS repositories").
+		Joins("JOIN repositories ON repositories.id = repository_languages.repository_id AND repositories.deleted_at IS NULL").
+		Where("repositories.owner_id =?", ownerID).
+		Group("repository_languages.language").Order("bytes DESC").Order("repository_languages.language").
+		Scan(languages).Error
+	if err != nil {
+		log.Printf("Error fetching languages of owner %d: %v", ownerID, err)
+		return nil, err
+	}
+	return *languages, nil
+}
+
+func (s *SqliteRepoRepository) MarkContributorsSynced(repo *entity.Repository, updatedAt string) error {
+	//  logic to remember which update of a repository its contributor totals were fetched at
+	err := s.DB.Model(&Repository{}).Where("id =?", repo.ID).Update("contributors_updated_at", updatedAt).Error
+	if err != nil {
+		log.Printf("Error in marking contributors of repo %s as synced: %v", repo.Name, err)
+		return err
+	}
+	repo.ContributorsUpdatedAt = updatedAt
+	return nil
+}
+
+func (s *SqliteRepoRepository) MarkPullRequestsSynced(repo *entity.Repository, updatedAt string) error {
+	//  logic to remember the newest pull request update a complete sync has stored
+	err := s.DB.Model(&Repository{}).Where("id =?", repo.ID).Update("pull_requests_synced_at", updatedAt).Error
+	if err != nil {
+		log.Printf("Error in marking pull requests of repo %s as synced: %v", repo.Name, err)
+		return err
+	}
+	repo.PullRequestsSyncedAt = updatedAt
+	return nil
+}
+
+func (s *SqliteRepoRepository) MarkIssuesSynced(repo *entity.Repository, updatedAt string) error {
+	//  logic to remember the newest issue update a complete sync has stored
+	err := s.DB.Model(&Repository{}).Where("id =?", repo.ID).Update("issues_synced_at", updatedAt).Error
+	if err != nil {
+		log.Printf("Error in marking issues of repo %s as synced: %v", repo.Name, err)
+		return err
+	}
+	repo.IssuesSyncedAt = updatedAt
+	return nil
+}
@@ -2,6 +2,7 @@ package database
 
 import (
 	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
 	"gorm.io/gorm"
 )
 
@@ -15,7 +16,11 @@ func NewSqliteUserRepository(db *gorm.DB) *SqliteUserRepository {
 
 func (s *SqliteUserRepository) CreateUser(createUserPaylod *dto.CreateUserPayloadDTO) (*User, error) {
 	// Create a user from payload
-	existingUser, err := s.GetUser(createUserPaylod.Username)
+	provider := createUserPaylod.Provider
+	if provider == "" {
+		provider = entity.ProviderGitHub
+	}
+	existingUser, err := s.GetUser(entity.OwnerHandle(createUserPaylod.Username, provider))
 	if err != nil {
 		return nil, err
 	}
@@ -27,17 +32,37 @@ func (s *SqliteUserRepository) CreateUser(createUserPaylod *dto.CreateUserPayloa
 	newUser := &User{
 		Username: createUserPaylod.Username,
 		FullName: createUserPaylod.FullName,
+		Provider: provider,
 	}
 	// add users into the pool to get more
 	return newUser, s.DB.Create(newUser).Error
 }
 
-func (s *SqliteUserRepository) GetUser(username string) (*User, error) {
-	// Get user by username
+func (s *SqliteUserRepository) GetUser(owner string) (*User, error) {
+	// Get user by username, qualified with the provider unless they are on GitHub
+	username, provider := entity.ParseOwner(owner)
 	var user User
-	err := s.DB.Where("username =?", username).First(&user).Error
+	err := s.DB.Where("username =?", username).Where("provider =?", provider).First(&user).Error
 	if err == gorm.ErrRecordNotFound {
 		return nil, nil
 	}
 	return &user, nil
 }
+
+func (s *SqliteUserRepository) GetUsers(ownerType string) ([]*User, error) {
+	// Get every registered user, only those of ownerType when it is given
+	users := []*User{}
+	dbQueryBuilder := s.DB.Order("username ASC")
+	if ownerType != "" {
+		dbQueryBuilder = dbQueryBuilder.Where("owner_type =?", ownerType)
+	}
+	if err := dbQueryBuilder.Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (s *SqliteUserRepository) SetOwnerType(userID uint, ownerType string) error {
+	// Record whether the user is a person or an organization, once their forge has said which
+	return s.DB.Model(&User{}).Where("id =?", userID).Update("owner_type", ownerType).Error
+}
@@ -9,12 +9,17 @@ type User struct {
 	gorm.Model
 	FullName string `gorm:"full_name"`
 	Username string `gorm:"username"`
+	Provider string `gorm:"provider;default:github"`
+	// OwnerType is empty until the account has been looked up on its forge
+	OwnerType string `gorm:"owner_type"`
 }
 
 func (model *User) ToEntity() *entity.User {
 	return &entity.User{
-		ID:       model.ID,
-		FullName: model.FullName,
-		Username: model.Username,
+		ID:        model.ID,
+		FullName:  model.FullName,
+		Username:  model.Username,
+		Provider:  model.Provider,
+		OwnerType: model.OwnerType,
 	}
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/interface/database"
+)
+
+type BranchRepository interface {
+	StoreBranch(repoID uint, remoteBranch *dto.BranchResponseDTO) (*database.Branch, error)
+	MarkBranchSynced(branch *database.Branch, sha string) error
+	GetRepositoryBranches(repoID uint) ([]*database.Branch, error)
+	StoreTags(repoID uint, remoteTags *[]dto.TagResponseDTO) error
+	GetRepositoryTags(repoID uint) ([]*database.Tag, error)
+	AddCommitsToBranch(repoID uint, branchName string, shas []string) error
+}
@@ -7,10 +7,17 @@ import (
 )
 
 type CommitRepository interface {
-	StoreRepositoryCommits(commitRepoInfos *[]dto.CommitResponseDTO, repoName string, owner *entity.User) error
-	GetRepositoryCommits(repoName string) ([]*database.Commit, error)
-	GetMostRecentCommitInRepository(repoName string) (*database.Commit, error)
-	DeleteUntilSHA(repoName, sha string) error
+	StoreRepositoryCommits(commitRepoInfos *[]dto.CommitResponseDTO, repoName string, owner *entity.User) ([]*database.Commit, error)
+	GetRepositoryCommits(repoID uint) ([]*database.Commit, error)
+	GetBranchCommits(repoID uint, branchName string) ([]*database.Commit, error)
+	GetRepositoryCommitsBetween(repoID uint, after, until string) ([]*database.Commit, error)
+	DeleteUntilSHA(repoID uint, sha string) error
 	FindTopNAuthorsByCommitCounts(topN int) ([]*database.AuthorCommitCount, error)
-	AddAuthorCommitCount(author string, count int) error
+	AddAuthorCommitCount(author entity.AuthorIdentity, count int) error
+	CountRepositoryCommitsByLogin(repoID uint, branchName string) ([]*database.AuthorLoginCommitCount, error)
+	GetCommitsWithoutStats(repoID uint) ([]*database.Commit, error)
+	StoreCommitStats(repoID uint, commitDetail *dto.CommitDetailResponseDTO) error
+	FindTopNAuthorsByChurn(topN int) ([]*database.AuthorChurn, error)
+	GetRepositoryChurn(repoID uint) (*database.RepositoryChurn, error)
+	FindMostChangedFiles(repoID uint, topN int) ([]*database.FileChurn, error)
 }
@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+)
+
+type ContributorRepository interface {
+	StoreContributors(remoteContributors *[]dto.ContributorResponseDTO, repo *entity.Repository) error
+	StoreContributorStats(remoteStats *[]dto.ContributorStatsResponseDTO, repo *entity.Repository) error
+	GetRepositoryContributors(repoID uint) ([]*database.Contributor, error)
+	AddContributorAdjustment(contributor *database.Contributor, adjustment int) error
+}
@@ -0,0 +1,10 @@
+package repository
+
+import (
+	"github.com/midedickson/github-service/interface/database"
+)
+
+type HTTPCacheRepository interface {
+	GetHTTPCacheEntry(url string) (*database.HTTPCacheEntry, error)
+	StoreHTTPCacheEntry(url, etag, lastModified, body string) error
+}
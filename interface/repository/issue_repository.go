@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/utils"
+)
+
+type IssueRepository interface {
+	StoreIssues(remoteIssues *[]dto.IssueResponseDTO, repo *entity.Repository) error
+	SearchIssues(repoID uint, issueSearchParams *utils.IssueSearchParams) ([]*database.Issue, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+)
+
+type PullRequestRepository interface {
+	StorePullRequests(remotePullRequests *[]dto.PullRequestResponseDTO, repo *entity.Repository) error
+	GetRepositoryPullRequests(repoID uint, state string) ([]*database.PullRequest, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+)
+
+type ReleaseRepository interface {
+	StoreReleases(remoteReleases *[]dto.ReleaseResponseDTO, repo *entity.Repository) error
+	GetRepositoryReleases(repoID uint) ([]*database.Release, error)
+}
@@ -13,4 +13,12 @@ type RepoRepository interface {
 
 	GetAllRepositories() ([]*database.Repository, error)
 	SearchRepository(ownerID uint, repoSearchParams *utils.RepositorySearchParams) ([]*database.Repository, error)
+
+	StoreRepositoryLanguages(languages map[string]int, repo *entity.Repository, updatedAt string) error
+	GetRepositoryLanguages(repoID uint) ([]*database.RepositoryLanguage, error)
+	GetOwnerLanguages(ownerID uint) ([]*database.RepositoryLanguage, error)
+
+	MarkContributorsSynced(repo *entity.Repository, updatedAt string) error
+	MarkPullRequestsSynced(repo *entity.Repository, updatedAt string) error
+	MarkIssuesSynced(repo *entity.Repository, updatedAt string) error
 }
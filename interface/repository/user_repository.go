@@ -8,4 +8,6 @@ import (
 type UserRepository interface {
 	CreateUser(createUserPaylod *dto.CreateUserPayloadDTO) (*database.User, error)
 	GetUser(username string) (*database.User, error)
+	GetUsers(ownerType string) ([]*database.User, error)
+	SetOwnerType(userID uint, ownerType string) error
 }
@@ -4,21 +4,36 @@ import (
 	"log"
 	"sync"
 	"time"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
 )
 
 func (t *TaskManager) GetAllRepoForUser(wg *sync.WaitGroup) {
 	//  logic to fetch all repositories for the given user
 	// Use the GetAllRepoForUserQueue channel to send and recieve the user to and from the worker pool
 	defer wg.Done()
-	for user := range t.GetAllRepoForUserQueue {
-
-		// Handover task to repository discovery
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			t.repoDiscovery.GetAllUserRepositories(user)
-			t.AddUserToGetAllRepoQueue(user)
-		}()
+	for {
+		select {
+		case <-t.ctx.Done():
+			log.Println("exiting fetching repositories for users...")
+			return
+		case user := <-t.GetAllRepoForUserQueue:
+			// Handover task to repository discovery
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := t.jobContext()
+				defer cancel()
+				started := time.Now()
+				t.repoDiscovery.GetAllUserRepositories(ctx, user)
+				// syncs of owners on forges without a rate limit never pause, so they are spaced out here
+				if utils.SleepWithContext(t.ctx, t.resyncInterval-time.Since(started)) != nil {
+					return
+				}
+				t.AddUserToGetAllRepoQueue(user)
+			}()
+		}
 	}
 }
 
@@ -27,45 +42,71 @@ func (t *TaskManager) FetchNewlyRequestedRepo(wg *sync.WaitGroup) {
 	defer wg.Done()
 	log.Println("waiting for newly requested repos...")
 
-	for repoRequest := range t.FetchNewlyRequestedRepoQueue {
-		log.Println("checking for newly requested repos...")
-		wg.Add(1)
-		go t.repoDiscovery.FetchNewlyRequestedRepo(repoRequest, wg)
+	for {
+		select {
+		case <-t.ctx.Done():
+			log.Println("exiting checking for newly requested repos...")
+			return
+		case repoRequest := <-t.FetchNewlyRequestedRepoQueue:
+			log.Println("checking for newly requested repos...")
+			wg.Add(1)
+			go func() {
+				ctx, cancel := t.jobContext()
+				defer cancel()
+				// someone is waiting on this repository, so it may use the budget reserved for interactive work
+				ctx = requester.WithPriority(ctx, requester.PriorityInteractive)
+				if err := t.repoDiscovery.FetchNewlyRequestedRepo(ctx, repoRequest, wg); err != nil {
+					t.repoRequestErrors.Store(repoRequestKey(repoRequest.Username, repoRequest.RepoName), err)
+				}
+			}()
+		}
 	}
-
-	log.Println("exiting checking for newly requested repos...")
 }
 
 func (t *TaskManager) HandleRequestedRepoReset(wg *sync.WaitGroup) {
-	//  logic to fetch a newly requested repo and commits for the given repository
+	//  logic to reset the commits of a repository to a requested SHA
 	defer wg.Done()
-	log.Println("waiting for newly requested repos...")
+	log.Println("waiting for repo reset requests...")
 
-	for repoResetRequest := range t.ResetRepositoryQueue {
-		log.Println("checking for newly requested repos...")
-		wg.Add(1)
-		go t.commitManager.ResetCommitToSHA(repoResetRequest.RepoName, repoResetRequest.ResetSHA)
+	for {
+		select {
+		case <-t.ctx.Done():
+			log.Println("exiting checking for repo reset requests...")
+			return
+		case repoResetRequest := <-t.ResetRepositoryQueue:
+			log.Println("checking for repo reset requests...")
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				ctx, cancel := t.jobContext()
+				defer cancel()
+				t.commitManager.ResetCommitToSHA(ctx, repoResetRequest)
+			}()
+		}
 	}
-
-	log.Println("exiting checking for newly requested repos...")
 }
 
 func (t *TaskManager) CheckForUpdateOnAllRepo(wg *sync.WaitGroup) {
 	//  logic to check for updates on all repositories in the database
 	defer wg.Done()
 	for {
-		_, ok := <-t.CheckForUpdateOnAllRepoQueue
-		if !ok {
+		select {
+		case <-t.ctx.Done():
 			log.Println("No more signal to check for updates on all repositories")
 			return
+		case <-t.CheckForUpdateOnAllRepoQueue:
 		}
-		err := t.repoDiscovery.CheckForUpdateOnAllRepo()
+		ctx, cancel := t.jobContext()
+		err := t.repoDiscovery.CheckForUpdateOnAllRepo(ctx)
+		cancel()
 		if err != nil {
 			log.Printf("Error in checking for updates on all repositories: %v", err)
 		}
 
 		// trigger the update again after 3days (currently passed as seconds)
-		time.Sleep(3 * time.Second)
+		if utils.SleepWithContext(t.ctx, 3*time.Second) != nil {
+			return
+		}
 		go t.AddSignalToCheckForUpdateOnAllRepoQueue()
 	}
 }
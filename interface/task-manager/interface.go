@@ -7,5 +7,6 @@ import (
 type Task interface {
 	AddUserToGetAllRepoQueue(user *entity.User)
 	AddRequestToFetchNewlyRequestedRepoQueue(username, repoName string)
-	AddRequestToResetRepositoryQueue(repoName, resetSHA string)
+	AddRequestToResetRepositoryQueue(repoID uint, repoName, resetSHA string)
+	TakeRepoRequestError(username, repoName string) error
 }
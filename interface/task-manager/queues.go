@@ -7,28 +7,42 @@ import (
 	"github.com/midedickson/github-service/entity"
 )
 
+// AddUserToGetAllRepoQueue queues a repository sync for user. Like every enqueue
+// below, it gives up once the task manager is shutting down so producers never block forever.
 func (t *TaskManager) AddUserToGetAllRepoQueue(user *entity.User) {
-	t.GetAllRepoForUserQueue <- user
+	select {
+	case t.GetAllRepoForUserQueue <- user:
+	case <-t.ctx.Done():
+	}
 }
 
 func (t *TaskManager) AddRequestToFetchNewlyRequestedRepoQueue(username, repoName string) {
 	log.Println("Adding request to fetch newly requested")
 
-	t.FetchNewlyRequestedRepoQueue <- &dto.RepoRequest{
+	select {
+	case t.FetchNewlyRequestedRepoQueue <- &dto.RepoRequest{
 		Username: username,
 		RepoName: repoName,
+	}:
+		log.Println("Added request to fetch newly requested")
+	case <-t.ctx.Done():
 	}
-	log.Println("Added request to fetch newly requested")
-
 }
 
 func (t *TaskManager) AddSignalToCheckForUpdateOnAllRepoQueue() {
-	t.CheckForUpdateOnAllRepoQueue <- "signal"
+	select {
+	case t.CheckForUpdateOnAllRepoQueue <- "signal":
+	case <-t.ctx.Done():
+	}
 }
 
-func (t *TaskManager) AddRequestToResetRepositoryQueue(repoName, resetSHA string) {
-	t.ResetRepositoryQueue <- &dto.RepoResetRequest{
+func (t *TaskManager) AddRequestToResetRepositoryQueue(repoID uint, repoName, resetSHA string) {
+	select {
+	case t.ResetRepositoryQueue <- &dto.RepoResetRequest{
+		RepoID:   repoID,
 		RepoName: repoName,
 		ResetSHA: resetSHA,
+	}:
+	case <-t.ctx.Done():
 	}
 }
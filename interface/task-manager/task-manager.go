@@ -1,6 +1,10 @@
 package tasks
 
 import (
+	"context"
+	"sync"
+	"time"
+
 	"github.com/midedickson/github-service/discovery"
 	"github.com/midedickson/github-service/dto"
 	"github.com/midedickson/github-service/entity"
@@ -13,9 +17,25 @@ type TaskManager struct {
 	ResetRepositoryQueue         chan *dto.RepoResetRequest
 	repoDiscovery                discovery.RepositoryDiscovery
 	commitManager                discovery.CommitDiscovery
+	// ctx is cancelled on shutdown to stop the workers and abort in-flight jobs
+	ctx context.Context
+	// jobTimeout bounds a single job; zero leaves jobs unbounded
+	jobTimeout time.Duration
+	// resyncInterval is the least time between the starts of two syncs of one owner's repositories
+	resyncInterval time.Duration
+	// repoRequestErrors holds why the last fetch of a requested repository failed, keyed by owner and name
+	repoRequestErrors sync.Map
 }
 
-func NewTaskManager(repoDiscovery discovery.RepositoryDiscovery, commitManager discovery.CommitDiscovery) *TaskManager {
+// defaultResyncInterval keeps owners on forges without a rate limit, whose syncs never pause, from
+// being synced back to back.
+const defaultResyncInterval = 10 * time.Minute
+
+// NewTaskManager creates the task manager; a non-positive resyncInterval uses the default of ten minutes.
+func NewTaskManager(ctx context.Context, repoDiscovery discovery.RepositoryDiscovery, commitManager discovery.CommitDiscovery, jobTimeout, resyncInterval time.Duration) *TaskManager {
+	if resyncInterval <= 0 {
+		resyncInterval = defaultResyncInterval
+	}
 	return &TaskManager{
 		GetAllRepoForUserQueue:       make(chan *entity.User),
 		FetchNewlyRequestedRepoQueue: make(chan *dto.RepoRequest),
@@ -23,5 +43,30 @@ func NewTaskManager(repoDiscovery discovery.RepositoryDiscovery, commitManager d
 		ResetRepositoryQueue:         make(chan *dto.RepoResetRequest),
 		repoDiscovery:                repoDiscovery,
 		commitManager:                commitManager,
+		ctx:                          ctx,
+		jobTimeout:                   jobTimeout,
+		resyncInterval:               resyncInterval,
+	}
+}
+
+// jobContext derives the context for a single job from the task manager's lifetime.
+func (t *TaskManager) jobContext() (context.Context, context.CancelFunc) {
+	if t.jobTimeout > 0 {
+		return context.WithTimeout(t.ctx, t.jobTimeout)
+	}
+	return context.WithCancel(t.ctx)
+}
+
+func repoRequestKey(username, repoName string) string {
+	return username + "/" + repoName
+}
+
+// TakeRepoRequestError returns why the last fetch of a requested repository
+// failed, if it did, and forgets it so the next request tries again.
+func (t *TaskManager) TakeRepoRequestError(username, repoName string) error {
+	err, ok := t.repoRequestErrors.LoadAndDelete(repoRequestKey(username, repoName))
+	if !ok {
+		return nil
 	}
+	return err.(error)
 }
@@ -0,0 +1,301 @@
+package requester
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime stays under the ten minutes GitHub accepts for an app JWT.
+	appJWTLifetime = 9 * time.Minute
+	// installationTokenRefreshMargin renews installation tokens this long before they expire.
+	installationTokenRefreshMargin = 5 * time.Minute
+	// installationRecheckInterval is how long an owner the app is not installed for is taken at
+	// its word before the installation is looked up again.
+	installationRecheckInterval = 10 * time.Minute
+)
+
+// ErrAppNotInstalled is returned for owners the GitHub App is not installed for.
+var ErrAppNotInstalled = errors.New("github app is not installed")
+
+// errInstallationGone is returned when a cached installation no longer takes tokens, because
+// the app was uninstalled, or reinstalled under another ID, since.
+var errInstallationGone = errors.New("github app installation is gone")
+
+type installationToken struct {
+	installationID int64
+	token          string
+	expiresAt      time.Time
+}
+
+// AppCredentialProvider authenticates as a GitHub App: it signs an RS256 JWT
+// with the app's private key and exchanges it for an installation access token
+// for each owner, caching tokens until shortly before they expire.
+type AppCredentialProvider struct {
+	client     *http.Client
+	appID      string
+	privateKey *rsa.PrivateKey
+	baseURL    string
+	// mu guards the maps only; it is never held across a request to GitHub
+	mu            sync.Mutex
+	installations map[string]*installationToken
+	// notInstalled holds when each owner the app is not installed for was last looked up
+	notInstalled map[string]time.Time
+	// refreshing holds one lock per owner, so concurrent callers wait for a single refresh
+	refreshing map[string]*sync.Mutex
+}
+
+// NewAppCredentialProvider creates a provider for the app with the given ID and
+// PEM encoded private key, talking to the REST API at baseURL.
+func NewAppCredentialProvider(appID string, privateKeyPEM []byte, baseURL string) (*AppCredentialProvider, error) {
+	privateKey, err := parseRSAPrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+	return &AppCredentialProvider{
+		client:        &http.Client{Timeout: defaultRequestTimeout},
+		appID:         appID,
+		privateKey:    privateKey,
+		baseURL:       strings.TrimSuffix(orDefault(baseURL, DefaultBaseURL), "/"),
+		installations: make(map[string]*installationToken),
+		notInstalled:  make(map[string]time.Time),
+		refreshing:    make(map[string]*sync.Mutex),
+	}, nil
+}
+
+// LoadAppCredentialProvider is NewAppCredentialProvider with the private key read from keyPath.
+func LoadAppCredentialProvider(appID, keyPath, baseURL string) (*AppCredentialProvider, error) {
+	privateKeyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading github app private key: %w", err)
+	}
+	return NewAppCredentialProvider(appID, privateKeyPEM, baseURL)
+}
+
+func parseRSAPrivateKey(privateKeyPEM []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, errors.New("github app private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("parsing github app private key: %w", err)
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("github app private key is not an RSA key")
+	}
+	return rsaKey, nil
+}
+
+// Token returns a valid installation access token for the app's installation on owner.
+func (p *AppCredentialProvider) Token(ctx context.Context, owner string) (string, error) {
+	if cached, ok := p.cachedToken(owner); ok && cached.valid() {
+		return cached.token, nil
+	}
+
+	refresh := p.refreshLock(owner)
+	refresh.Lock()
+	defer refresh.Unlock()
+	// another caller may have refreshed the token while we waited
+	cached, ok := p.cachedToken(owner)
+	if ok && cached.valid() {
+		return cached.token, nil
+	}
+	if p.recentlyNotInstalled(owner) {
+		return "", fmt.Errorf("github app %s is not installed for %s: %w", p.appID, owner, ErrAppNotInstalled)
+	}
+
+	jwt, err := p.signJWT(time.Now())
+	if err != nil {
+		return "", err
+	}
+	var token *installationToken
+	if ok {
+		token, err = p.createInstallationToken(ctx, jwt, cached.installationID)
+		if errors.Is(err, errInstallationGone) {
+			log.Printf("Github app installation %d for %s is gone, looking it up again", cached.installationID, owner)
+			p.forgetInstallation(owner)
+			ok = false
+		} else if err != nil {
+			return "", err
+		}
+	}
+	if !ok {
+		installationID, err := p.findInstallation(ctx, jwt, owner)
+		if err != nil {
+			if errors.Is(err, ErrAppNotInstalled) {
+				p.markNotInstalled(owner)
+			}
+			return "", err
+		}
+		token, err = p.createInstallationToken(ctx, jwt, installationID)
+		if err != nil {
+			return "", err
+		}
+	}
+	log.Printf("Refreshed github app installation token for %s, expires at %v", owner, token.expiresAt)
+	p.mu.Lock()
+	p.installations[owner] = token
+	delete(p.notInstalled, owner)
+	p.mu.Unlock()
+	return token.token, nil
+}
+
+// valid reports whether the token can still be used without refreshing it first.
+func (t *installationToken) valid() bool {
+	return time.Until(t.expiresAt) > installationTokenRefreshMargin
+}
+
+func (p *AppCredentialProvider) cachedToken(owner string) (*installationToken, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	cached, ok := p.installations[owner]
+	return cached, ok
+}
+
+func (p *AppCredentialProvider) forgetInstallation(owner string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.installations, owner)
+}
+
+func (p *AppCredentialProvider) markNotInstalled(owner string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.notInstalled[owner] = time.Now()
+}
+
+// recentlyNotInstalled reports whether owner was found without an installation too recently to look again.
+func (p *AppCredentialProvider) recentlyNotInstalled(owner string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	checkedAt, ok := p.notInstalled[owner]
+	return ok && time.Since(checkedAt) < installationRecheckInterval
+}
+
+// refreshLock returns the lock serializing token refreshes for owner.
+func (p *AppCredentialProvider) refreshLock(owner string) *sync.Mutex {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	refresh, ok := p.refreshing[owner]
+	if !ok {
+		refresh = &sync.Mutex{}
+		p.refreshing[owner] = refresh
+	}
+	return refresh
+}
+
+// Label identifies an installation token in logs without leaking it.
+func (p *AppCredentialProvider) Label(token string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for owner, installation := range p.installations {
+		if installation.token == token {
+			return "app installation for " + owner
+		}
+	}
+	return "app installation"
+}
+
+// signJWT builds the short lived RS256 token that authenticates as the app itself.
+func (p *AppCredentialProvider) signJWT(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		// backdated to allow for clock drift between us and GitHub
+		"iat": now.Add(-time.Minute).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": p.appID,
+	})
+	if err != nil {
+		return "", err
+	}
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, p.privateKey, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// findInstallation looks up the app's installation on a user account, then on an organization.
+func (p *AppCredentialProvider) findInstallation(ctx context.Context, jwt, owner string) (int64, error) {
+	var installation struct {
+		ID int64 `json:"id"`
+	}
+	for _, path := range []string{"/users/%s/installation", "/orgs/%s/installation"} {
+		status, err := p.appRequest(ctx, http.MethodGet, p.baseURL+fmt.Sprintf(path, owner), jwt, &installation)
+		if err != nil {
+			return 0, err
+		}
+		if status == http.StatusOK {
+			return installation.ID, nil
+		}
+		if status != http.StatusNotFound {
+			return 0, fmt.Errorf("looking up github app installation for %s: unexpected status %d", owner, status)
+		}
+	}
+	return 0, fmt.Errorf("github app %s is not installed for %s: %w", p.appID, owner, ErrAppNotInstalled)
+}
+
+func (p *AppCredentialProvider) createInstallationToken(ctx context.Context, jwt string, installationID int64) (*installationToken, error) {
+	var response struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	url := p.baseURL + fmt.Sprintf("/app/installations/%d/access_tokens", installationID)
+	status, err := p.appRequest(ctx, http.MethodPost, url, jwt, &response)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound || status == http.StatusUnauthorized {
+		return nil, fmt.Errorf("creating installation token for installation %d: status %d: %w", installationID, status, errInstallationGone)
+	}
+	if status != http.StatusCreated {
+		return nil, fmt.Errorf("creating installation token for installation %d: unexpected status %d", installationID, status)
+	}
+	return &installationToken{installationID: installationID, token: response.Token, expiresAt: response.ExpiresAt}, nil
+}
+
+// appRequest calls an endpoint authenticated as the app, decoding successful responses into result.
+func (p *AppCredentialProvider) appRequest(ctx context.Context, method, url, jwt string, result interface{}) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	req.Header.Set("Accept", "application/vnd.github+json")
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
+			return resp.StatusCode, err
+		}
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,181 @@
+package requester
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// modes of a CassetteTransport
+const (
+	CassetteRecord = "record"
+	CassetteReplay = "replay"
+)
+
+// ErrNoRecording is returned when replaying a request that was never recorded.
+var ErrNoRecording = errors.New("no recorded response for request")
+
+type recordedRequest struct {
+	Method string `json:"method"`
+	URL    string `json:"url"`
+	Body   string `json:"body,omitempty"`
+}
+
+type recordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+}
+
+// CassetteTransport records the upstream requests made through it and their responses to
+// cassette files, or serves recorded responses back without calling upstream at all. Each
+// distinct request, by method, URL and body, gets a cassette of its own holding every response
+// it got, in order; replaying serves them in the same order and repeats the last one once they
+// run out, so periodic checks keep working. Credentials are never written to a cassette.
+type CassetteTransport struct {
+	mode string
+	dir  string
+	next http.RoundTripper
+
+	mu sync.Mutex
+	// recorded holds the interactions of each cassette recorded by this transport so far
+	recorded map[string][]interaction
+	// played counts the responses replayed from each cassette
+	played map[string]int
+}
+
+// NewCassetteTransport creates a transport recording to or replaying from the cassettes in dir,
+// depending on mode. Recording sends requests on through next, http.DefaultTransport when nil.
+func NewCassetteTransport(mode, dir string, next http.RoundTripper) (*CassetteTransport, error) {
+	if mode != CassetteRecord && mode != CassetteReplay {
+		return nil, fmt.Errorf("unknown cassette mode %q, expected %s or %s", mode, CassetteRecord, CassetteReplay)
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	if mode == CassetteRecord {
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return nil, err
+		}
+	}
+	return &CassetteTransport{
+		mode:     mode,
+		dir:      dir,
+		next:     next,
+		recorded: make(map[string][]interaction),
+		played:   make(map[string]int),
+	}, nil
+}
+
+var unsafeCassetteName = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// cassetteName names the cassette of a request after its method and URL, readably enough to find
+// by hand, with a hash of the full request so long URLs and request bodies do not collide.
+func cassetteName(request recordedRequest) string {
+	sum := sha256.Sum256([]byte(request.Method + " " + request.URL + "\n" + request.Body))
+	readable := request.URL
+	if i := strings.Index(readable, "://"); i >= 0 {
+		readable = readable[i+len("://"):]
+	}
+	readable = strings.Trim(unsafeCassetteName.ReplaceAllString(readable, "_"), "_")
+	if len(readable) > 100 {
+		readable = readable[:100]
+	}
+	return request.Method + "_" + readable + "_" + hex.EncodeToString(sum[:6]) + ".json"
+}
+
+func (c *CassetteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	request := recordedRequest{Method: req.Method, URL: req.URL.String()}
+	if req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		request.Body = string(body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	name := cassetteName(request)
+	if c.mode == CassetteReplay {
+		return c.replay(req, name, request)
+	}
+	return c.record(req, name, request)
+}
+
+func (c *CassetteTransport) record(req *http.Request, name string, request recordedRequest) (*http.Response, error) {
+	resp, err := c.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// a cassette holds the responses of one recording session, so its first request overwrites it
+	c.recorded[name] = append(c.recorded[name], interaction{
+		Request:  request,
+		Response: recordedResponse{StatusCode: resp.StatusCode, Header: resp.Header.Clone(), Body: string(body)},
+	})
+	data, err := json.MarshalIndent(c.recorded[name], "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(c.dir, name), data, 0o644); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *CassetteTransport) replay(req *http.Request, name string, request recordedRequest) (*http.Response, error) {
+	data, err := os.ReadFile(filepath.Join(c.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoRecording, request.Method, request.URL)
+	}
+	if err != nil {
+		return nil, err
+	}
+	var interactions []interaction
+	if err := json.Unmarshal(data, &interactions); err != nil {
+		return nil, fmt.Errorf("reading cassette %s: %w", name, err)
+	}
+	if len(interactions) == 0 {
+		return nil, fmt.Errorf("%w: %s %s", ErrNoRecording, request.Method, request.URL)
+	}
+
+	c.mu.Lock()
+	played := c.played[name]
+	c.played[name]++
+	c.mu.Unlock()
+	recorded := interactions[min(played, len(interactions)-1)].Response
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        recorded.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(recorded.Body)),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
@@ -0,0 +1,36 @@
+package requester
+
+import (
+	"context"
+	"errors"
+)
+
+// CredentialProvider supplies the token used to authenticate requests made on
+// behalf of an owner. An empty token means the request is sent anonymously.
+type CredentialProvider interface {
+	Token(ctx context.Context, owner string) (string, error)
+	// Label identifies a token in logs without revealing it.
+	Label(token string) string
+}
+
+// fallbackCredentials authenticates with credentials, such as a GitHub App, and with
+// the personal tokens in tokens for the owners the app is not installed for.
+type fallbackCredentials struct {
+	credentials CredentialProvider
+	tokens      *tokenPool
+}
+
+func (f *fallbackCredentials) Token(ctx context.Context, owner string) (string, error) {
+	token, err := f.credentials.Token(ctx, owner)
+	if errors.Is(err, ErrAppNotInstalled) {
+		return f.tokens.Token(ctx, owner)
+	}
+	return token, err
+}
+
+func (f *fallbackCredentials) Label(token string) string {
+	if f.tokens.has(token) {
+		return f.tokens.Label(token)
+	}
+	return f.credentials.Label(token)
+}
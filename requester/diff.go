@@ -0,0 +1,50 @@
+package requester
+
+import "strings"
+
+// diffStats counts the lines a unified diff adds and removes. Only lines inside hunks are
+// counted, so file headers are skipped while removed lines that happen to start with "--" are not.
+func diffStats(diff string) (additions, deletions int) {
+	inHunk := false
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case strings.HasPrefix(line, "diff --git "):
+			inHunk = false
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
+
+// splitDiff splits a git diff of several files into the diff of each, keyed by the
+// file's path after the change, as named on its "diff --git a/... b/..." line.
+func splitDiff(patch string) map[string]string {
+	files := make(map[string]string)
+	var path string
+	var section strings.Builder
+	flush := func() {
+		if path != "" {
+			files[path] = section.String()
+		}
+		section.Reset()
+	}
+	for _, line := range strings.Split(patch, "\n") {
+		if strings.HasPrefix(line, "diff --git ") {
+			flush()
+			path = ""
+			if i := strings.LastIndex(line, " b/"); i >= 0 {
+				path = line[i+len(" b/"):]
+			}
+		}
+		section.WriteString(line)
+		section.WriteByte('\n')
+	}
+	flush()
+	return files
+}
@@ -0,0 +1,66 @@
+package requester
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+
+	"github.com/midedickson/github-service/utils"
+)
+
+// checkResponse returns nil for successful responses and a *utils.UpstreamError
+// describing any other status, using GitHub's message from the body when it has one.
+func checkResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	upstreamErr := &utils.UpstreamError{
+		Kind:       errorKind(resp),
+		StatusCode: resp.StatusCode,
+		URL:        resp.Request.URL.String(),
+	}
+	var body struct {
+		Message string `json:"message"`
+	}
+	if json.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&body) == nil {
+		upstreamErr.Message = body.Message
+	}
+	return upstreamErr
+}
+
+func errorKind(resp *http.Response) error {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return utils.ErrRepoNotFound
+	case resp.StatusCode == http.StatusUnauthorized:
+		return utils.ErrUnauthorized
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return utils.ErrRateLimited
+	case resp.StatusCode == http.StatusForbidden:
+		if _, ok := retryAfter(resp.Header); ok || resp.Header.Get("x-ratelimit-remaining") == "0" || isSecondaryRateLimit(resp) {
+			return utils.ErrRateLimited
+		}
+		return utils.ErrForbidden
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return utils.ErrUpstreamUnavailable
+	default:
+		return utils.ErrInvalidRequest
+	}
+}
+
+// transportError wraps a failure to get any response at all, leaving
+// cancellation by the caller's context and credential errors untouched.
+func transportError(req *http.Request, err error) error {
+	var urlErr *url.Error
+	if req.Context().Err() != nil || !errors.As(err, &urlErr) {
+		return err
+	}
+	return &utils.UpstreamError{Kind: utils.ErrUpstreamUnavailable, URL: req.URL.String(), Err: err}
+}
+
+// decodeError wraps a body that could not be decoded into the expected DTO.
+func decodeError(resp *http.Response, err error) error {
+	return &utils.UpstreamError{Kind: utils.ErrInvalidResponse, StatusCode: resp.StatusCode, URL: resp.Request.URL.String(), Err: err}
+}
@@ -0,0 +1,280 @@
+package requester
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	neturl "net/url"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/utils"
+)
+
+// GiteaRequester fetches repositories, commits, branches, tags, releases, pull requests and
+// issues from the API of a Gitea or Forgejo instance. Most of its payloads follow GitHub's and
+// decode into the same DTOs; repositories and branches differ and are mapped here. Gitea pages
+// with a limit parameter and Link headers, and takes personal access tokens as bearer tokens, so
+// the transport of a RepositoryRequester is reused. Gitea does not offer contributor totals.
+type GiteaRequester struct {
+	rest *RepositoryRequester
+}
+
+// NewGiteaRequester creates a Gitea requester; opts.BaseURL is the API root of the instance,
+// such as https://gitea.example.com/api/v1. Gitea caps page sizes at its own maximum, 50 by default.
+func NewGiteaRequester(opts *Options) *GiteaRequester {
+	return &GiteaRequester{rest: NewRepositoryRequester(opts)}
+}
+
+type giteaRepository struct {
+	ID              int      `json:"id"`
+	Name            string   `json:"name"`
+	FullName        string   `json:"full_name"`
+	HtmlUrl         string   `json:"html_url"`
+	Description     string   `json:"description"`
+	URL             string   `json:"url"`
+	Fork            bool     `json:"fork"`
+	Archived        bool     `json:"archived"`
+	Private         bool     `json:"private"`
+	Internal        bool     `json:"internal"`
+	Topics          []string `json:"topics"`
+	Language        string   `json:"language"`
+	ForksCount      int      `json:"forks_count"`
+	StarsCount      int      `json:"stars_count"`
+	OpenIssuesCount int      `json:"open_issues_count"`
+	OpenPRCounter   int      `json:"open_pr_counter"`
+	WatchersCount   int      `json:"watchers_count"`
+	Size            int      `json:"size"`
+	DefaultBranch   string   `json:"default_branch"`
+	CreatedAt       string   `json:"created_at"`
+	UpdatedAt       string   `json:"updated_at"`
+}
+
+// toDTO maps a repository onto the GitHub payload, whose open issue count includes open pull
+// requests. Gitea reports times in the instance's time zone, so they are converted to UTC. Gitea
+// does not say when a repository was last pushed to, and only recent versions detect licenses, so
+// neither is mapped.
+func (repo *giteaRepository) toDTO() dto.RepositoryInfoResponseDTO {
+	visibility := entity.VisibilityPublic
+	if repo.Internal {
+		visibility = entity.VisibilityInternal
+	}
+	if repo.Private {
+		visibility = entity.VisibilityPrivate
+	}
+	return dto.RepositoryInfoResponseDTO{
+		ID:            repo.ID,
+		Name:          repo.Name,
+		FullName:      repo.FullName,
+		HtmlUrl:       repo.HtmlUrl,
+		Description:   repo.Description,
+		URL:           repo.URL,
+		Fork:          repo.Fork,
+		Archived:      repo.Archived,
+		Private:       repo.Private,
+		Visibility:    visibility,
+		Topics:        repo.Topics,
+		Language:      repo.Language,
+		ForksCount:    repo.ForksCount,
+		StarsCount:    repo.StarsCount,
+		OpenIssues:    repo.OpenIssuesCount + repo.OpenPRCounter,
+		Watchers:      repo.WatchersCount,
+		Size:          repo.Size,
+		DefaultBranch: repo.DefaultBranch,
+		CreatedAt:     utcTimestamp(repo.CreatedAt),
+		UpdatedAt:     utcTimestamp(repo.UpdatedAt),
+	}
+}
+
+type giteaBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
+}
+
+// giteaFileStatus maps the status Gitea gives a changed file onto GitHub's.
+func giteaFileStatus(status string) string {
+	if status == "deleted" {
+		return "removed"
+	}
+	return status
+}
+
+func (g *GiteaRequester) GetRepositoryInfo(ctx context.Context, owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
+	var repository giteaRepository
+	if _, err := g.rest.fetchAndDecode(ctx, owner, g.rest.endpoint("/repos/%s/%s", owner, repo), &repository); err != nil {
+		return nil, err
+	}
+	info := repository.toDTO()
+	return &info, nil
+}
+
+func (g *GiteaRequester) GetAllUserRepositories(ctx context.Context, owner string, handlePage RepositoryPageHandler) error {
+	//  logic to fetch all repositories of a user, one page at a time
+	return fetchPages(ctx, g.rest, owner, g.rest.endpoint("/users/%s/repos?limit=%d", owner, g.rest.perPage), func(page []giteaRepository) error {
+		repositories := make([]dto.RepositoryInfoResponseDTO, len(page))
+		for i := range page {
+			repositories[i] = page[i].toDTO()
+		}
+		return handlePage(&repositories)
+	})
+}
+
+type giteaAccount struct {
+	ID       int    `json:"id"`
+	UserName string `json:"username"`
+}
+
+// GetOwner tells users from organizations. Gitea's account payloads do not say which they are,
+// but only organizations are found under /orgs.
+func (g *GiteaRequester) GetOwner(ctx context.Context, owner string) (*dto.OwnerResponseDTO, error) {
+	var account giteaAccount
+	_, err := g.rest.fetchAndDecode(ctx, owner, g.rest.endpoint("/orgs/%s", owner), &account)
+	if err == nil {
+		return &dto.OwnerResponseDTO{ID: account.ID, Login: account.UserName, Type: dto.OwnerTypeOrganization}, nil
+	}
+	if !errors.Is(err, utils.ErrRepoNotFound) {
+		return nil, err
+	}
+	if _, err := g.rest.fetchAndDecode(ctx, owner, g.rest.endpoint("/users/%s", owner), &account); err != nil {
+		return nil, err
+	}
+	return &dto.OwnerResponseDTO{ID: account.ID, Login: account.UserName, Type: dto.OwnerTypeUser}, nil
+}
+
+func (g *GiteaRequester) GetAllOrganizationRepositories(ctx context.Context, org string, handlePage RepositoryPageHandler) error {
+	//  logic to fetch all repositories of an organization the token can see, one page at a time
+	return fetchPages(ctx, g.rest, org, g.rest.endpoint("/orgs/%s/repos?limit=%d", org, g.rest.perPage), func(page []giteaRepository) error {
+		repositories := make([]dto.RepositoryInfoResponseDTO, len(page))
+		for i := range page {
+			repositories[i] = page[i].toDTO()
+		}
+		return handlePage(&repositories)
+	})
+}
+
+func (g *GiteaRequester) GetRepositoryCommits(ctx context.Context, owner, repo string, queryParams *dto.CommitQueryParams, handlePage CommitPageHandler) error {
+	// logic to fetch repository commits, one page at a time; the stats and files of each
+	// commit are left out of the listing, as GetCommit fetches them
+	query := neturl.Values{}
+	query.Set("limit", fmt.Sprint(g.rest.perPage))
+	query.Set("stat", "false")
+	query.Set("verification", "false")
+	query.Set("files", "false")
+	if queryParams != nil {
+		if queryParams.SHA != "" {
+			query.Set("sha", queryParams.SHA)
+		}
+		if queryParams.Since != "" {
+			query.Set("since", queryParams.Since)
+		}
+		if queryParams.Until != "" {
+			query.Set("until", queryParams.Until)
+		}
+	}
+	return fetchPages(ctx, g.rest, owner, g.rest.endpoint("/repos/%s/%s/commits?", owner, repo)+query.Encode(), func(commits []dto.CommitResponseDTO) error {
+		for i := range commits {
+			commits[i].Date = utcTimestamp(commits[i].Date)
+			commits[i].CommitterDate = utcTimestamp(commits[i].CommitterDate)
+		}
+		return handlePage(&commits)
+	})
+}
+
+func (g *GiteaRequester) GetCommit(ctx context.Context, owner, repo, sha string) (*dto.CommitDetailResponseDTO, error) {
+	// fetch a single commit with its stats and files; Gitea does not count the lines changed in
+	// each file, so they are counted from the commit's diff
+	var commit dto.CommitDetailResponseDTO
+	_, err := g.rest.fetchAndDecode(ctx, owner, g.rest.endpoint("/repos/%s/%s/git/commits/%s?stat=true&verification=false&files=true", owner, repo, sha), &commit)
+	if err != nil {
+		return nil, err
+	}
+	commit.Date = utcTimestamp(commit.Date)
+	commit.CommitterDate = utcTimestamp(commit.CommitterDate)
+	patch, err := g.rest.fetchRaw(ctx, owner, g.rest.endpoint("/repos/%s/%s/git/commits/%s.diff", owner, repo, sha))
+	if err != nil {
+		return nil, err
+	}
+	diffs := splitDiff(string(patch))
+	for i := range commit.Files {
+		file := &commit.Files[i]
+		file.Status = giteaFileStatus(file.Status)
+		file.Additions, file.Deletions = diffStats(diffs[file.Filename])
+		file.Changes = file.Additions + file.Deletions
+	}
+	return &commit, nil
+}
+
+func (g *GiteaRequester) GetRepositoryBranches(ctx context.Context, owner, repo string, handlePage BranchPageHandler) error {
+	return fetchPages(ctx, g.rest, owner, g.rest.endpoint("/repos/%s/%s/branches?limit=%d", owner, repo, g.rest.perPage), func(page []giteaBranch) error {
+		branches := make([]dto.BranchResponseDTO, len(page))
+		for i, branch := range page {
+			branches[i] = dto.BranchResponseDTO{Name: branch.Name, SHA: branch.Commit.ID, Protected: branch.Protected}
+		}
+		return handlePage(&branches)
+	})
+}
+
+func (g *GiteaRequester) GetRepositoryTags(ctx context.Context, owner, repo string, handlePage TagPageHandler) error {
+	return fetchPages(ctx, g.rest, owner, g.rest.endpoint("/repos/%s/%s/tags?limit=%d", owner, repo, g.rest.perPage), func(tags []dto.TagResponseDTO) error {
+		return handlePage(&tags)
+	})
+}
+
+func (g *GiteaRequester) GetRepositoryReleases(ctx context.Context, owner, repo string, handlePage ReleasePageHandler) error {
+	return fetchPages(ctx, g.rest, owner, g.rest.endpoint("/repos/%s/%s/releases?limit=%d", owner, repo, g.rest.perPage), func(releases []dto.ReleaseResponseDTO) error {
+		for i := range releases {
+			releases[i].CreatedAt = utcTimestamp(releases[i].CreatedAt)
+			releases[i].PublishedAt = utcTimestamp(releases[i].PublishedAt)
+		}
+		return handlePage(&releases)
+	})
+}
+
+func (g *GiteaRequester) GetRepositoryPullRequests(ctx context.Context, owner, repo string, handlePage PullRequestPageHandler) error {
+	//  logic to fetch pull requests in every state, most recently updated first, one page at a time
+	url := g.rest.endpoint("/repos/%s/%s/pulls?state=all&sort=recentupdate&limit=%d", owner, repo, g.rest.perPage)
+	return fetchPages(ctx, g.rest, owner, url, func(pullRequests []dto.PullRequestResponseDTO) error {
+		for i := range pullRequests {
+			pullRequest := &pullRequests[i]
+			pullRequest.CreatedAt = utcTimestamp(pullRequest.CreatedAt)
+			pullRequest.UpdatedAt = utcTimestamp(pullRequest.UpdatedAt)
+			pullRequest.MergedAt = utcTimestamp(pullRequest.MergedAt)
+			pullRequest.ClosedAt = utcTimestamp(pullRequest.ClosedAt)
+		}
+		return handlePage(&pullRequests)
+	})
+}
+
+func (g *GiteaRequester) GetRepositoryIssues(ctx context.Context, owner, repo, since string, handlePage IssuePageHandler) error {
+	//  logic to fetch issues in every state updated at or after since (all of them when empty), one page at a time;
+	// unlike GitHub, Gitea can leave pull requests out of the listing itself
+	url := g.rest.endpoint("/repos/%s/%s/issues?state=all&type=issues&limit=%d", owner, repo, g.rest.perPage)
+	if since != "" {
+		url += "&since=" + neturl.QueryEscape(since)
+	}
+	return fetchPages(ctx, g.rest, owner, url, func(issues []dto.IssueResponseDTO) error {
+		for i := range issues {
+			issue := &issues[i]
+			issue.CreatedAt = utcTimestamp(issue.CreatedAt)
+			issue.UpdatedAt = utcTimestamp(issue.UpdatedAt)
+			issue.ClosedAt = utcTimestamp(issue.ClosedAt)
+		}
+		return handlePage(&issues)
+	})
+}
+
+func (g *GiteaRequester) GetRepositoryContributors(ctx context.Context, owner, repo string, handlePage ContributorPageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (g *GiteaRequester) GetContributorStats(ctx context.Context, owner, repo string) ([]dto.ContributorStatsResponseDTO, error) {
+	return nil, utils.ErrNotSupported
+}
+
+func (g *GiteaRequester) GetRepositoryLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	// Gitea reports bytes per language the way GitHub does
+	return g.rest.GetRepositoryLanguages(ctx, owner, repo)
+}
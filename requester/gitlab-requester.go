@@ -0,0 +1,344 @@
+package requester
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/utils"
+)
+
+// DefaultGitLabBaseURL is the API root of gitlab.com, used when no self-hosted instance is configured.
+const DefaultGitLabBaseURL = "https://gitlab.com/api/v4"
+
+// GitLabRequester fetches projects, commits, branches and tags from the GitLab REST API and maps
+// them onto the same DTOs the GitHub requesters produce. GitLab paginates with Link headers and
+// accepts personal access tokens as bearer tokens, so the transport of a RepositoryRequester is
+// reused. Releases, merge requests, issues and contributors are not fetched from GitLab yet.
+type GitLabRequester struct {
+	rest *RepositoryRequester
+}
+
+// NewGitLabRequester creates a GitLab requester; opts.BaseURL is the API root, such as
+// https://gitlab.example.com/api/v4, and defaults to gitlab.com.
+func NewGitLabRequester(opts *Options) *GitLabRequester {
+	o := Options{}
+	if opts != nil {
+		o = *opts
+	}
+	o.BaseURL = orDefault(o.BaseURL, DefaultGitLabBaseURL)
+	return &GitLabRequester{rest: NewRepositoryRequester(&o)}
+}
+
+// projectPath is the URL-encoded "namespace/project" GitLab accepts in place of a project ID.
+func projectPath(owner, repo string) string {
+	return url.PathEscape(owner + "/" + repo)
+}
+
+type gitLabProject struct {
+	ID                int      `json:"id"`
+	Path              string   `json:"path"`
+	PathWithNamespace string   `json:"path_with_namespace"`
+	Description       string   `json:"description"`
+	WebURL            string   `json:"web_url"`
+	Topics            []string `json:"topics"`
+	Archived          bool     `json:"archived"`
+	Visibility        string   `json:"visibility"`
+	ForksCount        int      `json:"forks_count"`
+	StarCount         int      `json:"star_count"`
+	OpenIssuesCount   int      `json:"open_issues_count"`
+	DefaultBranch     string   `json:"default_branch"`
+	CreatedAt         string   `json:"created_at"`
+	LastActivityAt    string   `json:"last_activity_at"`
+	ForkedFromProject *struct {
+		ID int `json:"id"`
+	} `json:"forked_from_project"`
+}
+
+// toDTO maps a project onto the GitHub repository payload. The project's path, not its display
+// name, is used as the repository name, since that is what addresses it in the API. GitLab's
+// visibilities are named like GitHub's. Licenses and sizes are not part of project listings and
+// the last push is not reported, so those are left out.
+func (project *gitLabProject) toDTO(g *GitLabRequester) dto.RepositoryInfoResponseDTO {
+	return dto.RepositoryInfoResponseDTO{
+		ID:            project.ID,
+		Name:          project.Path,
+		FullName:      project.PathWithNamespace,
+		HtmlUrl:       project.WebURL,
+		Description:   project.Description,
+		URL:           g.rest.endpoint("/projects/%d", project.ID),
+		Fork:          project.ForkedFromProject != nil,
+		Archived:      project.Archived,
+		Private:       project.Visibility == entity.VisibilityPrivate,
+		Visibility:    project.Visibility,
+		Topics:        project.Topics,
+		ForksCount:    project.ForksCount,
+		StarsCount:    project.StarCount,
+		OpenIssues:    project.OpenIssuesCount,
+		DefaultBranch: project.DefaultBranch,
+		CreatedAt:     utcTimestamp(project.CreatedAt),
+		UpdatedAt:     utcTimestamp(project.LastActivityAt),
+	}
+}
+
+type gitLabCommit struct {
+	ID             string   `json:"id"`
+	Message        string   `json:"message"`
+	AuthorName     string   `json:"author_name"`
+	AuthorEmail    string   `json:"author_email"`
+	AuthoredDate   string   `json:"authored_date"`
+	CommitterName  string   `json:"committer_name"`
+	CommitterEmail string   `json:"committer_email"`
+	CommittedDate  string   `json:"committed_date"`
+	ParentIDs      []string `json:"parent_ids"`
+	WebURL         string   `json:"web_url"`
+	Stats          struct {
+		Additions int `json:"additions"`
+		Deletions int `json:"deletions"`
+	} `json:"stats"`
+}
+
+// toDTO maps a commit onto the GitHub payload. GitLab does not link commits to accounts, so
+// their authors are only known by email address.
+func (commit *gitLabCommit) toDTO() dto.CommitResponseDTO {
+	return dto.CommitResponseDTO{
+		SHA:            commit.ID,
+		Message:        commit.Message,
+		Author:         commit.AuthorName,
+		AuthorEmail:    commit.AuthorEmail,
+		Date:           utcTimestamp(commit.AuthoredDate),
+		Committer:      commit.CommitterName,
+		CommitterEmail: commit.CommitterEmail,
+		CommitterDate:  utcTimestamp(commit.CommittedDate),
+		Parents:        commit.ParentIDs,
+		URL:            commit.WebURL,
+	}
+}
+
+type gitLabDiff struct {
+	Diff        string `json:"diff"`
+	NewPath     string `json:"new_path"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+// toDTO maps a file diff onto the GitHub commit file payload; GitLab does not count the
+// changed lines of each file, so they are counted from the unified diff.
+func (diff *gitLabDiff) toDTO() dto.CommitFileDTO {
+	file := dto.CommitFileDTO{Filename: diff.NewPath, Status: "modified"}
+	switch {
+	case diff.NewFile:
+		file.Status = "added"
+	case diff.DeletedFile:
+		file.Status = "removed"
+	case diff.RenamedFile:
+		file.Status = "renamed"
+	}
+	file.Additions, file.Deletions = diffStats(diff.Diff)
+	file.Changes = file.Additions + file.Deletions
+	return file
+}
+
+type gitLabRef struct {
+	Name   string `json:"name"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
+}
+
+func (g *GitLabRequester) GetRepositoryInfo(ctx context.Context, owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
+	var project gitLabProject
+	if _, err := g.rest.fetchAndDecode(ctx, owner, g.rest.endpoint("/projects/%s", projectPath(owner, repo)), &project); err != nil {
+		return nil, err
+	}
+	info := project.toDTO(g)
+	return &info, nil
+}
+
+func (g *GitLabRequester) GetAllUserRepositories(ctx context.Context, owner string, handlePage RepositoryPageHandler) error {
+	//  logic to fetch all projects of a user, one page at a time
+	return g.fetchProjects(ctx, owner, g.rest.endpoint("/users/%s/projects?per_page=%d", url.PathEscape(owner), g.rest.perPage), handlePage)
+}
+
+type gitLabNamespace struct {
+	ID   int    `json:"id"`
+	Path string `json:"full_path"`
+	Kind string `json:"kind"`
+}
+
+// GetOwner tells users from groups, which stand in for organizations on GitLab.
+func (g *GitLabRequester) GetOwner(ctx context.Context, owner string) (*dto.OwnerResponseDTO, error) {
+	var namespace gitLabNamespace
+	if _, err := g.rest.fetchAndDecode(ctx, owner, g.rest.endpoint("/namespaces/%s", url.PathEscape(owner)), &namespace); err != nil {
+		return nil, err
+	}
+	account := &dto.OwnerResponseDTO{ID: namespace.ID, Login: namespace.Path, Type: dto.OwnerTypeUser}
+	if namespace.Kind == "group" {
+		account.Type = dto.OwnerTypeOrganization
+	}
+	return account, nil
+}
+
+func (g *GitLabRequester) GetAllOrganizationRepositories(ctx context.Context, org string, handlePage RepositoryPageHandler) error {
+	//  logic to fetch all projects of a group and its subgroups, one page at a time
+	return g.fetchProjects(ctx, org, g.rest.endpoint("/groups/%s/projects?include_subgroups=true&per_page=%d", url.PathEscape(org), g.rest.perPage), handlePage)
+}
+
+// fetchProjects pages through a project listing starting at next, handing each page over as repositories.
+func (g *GitLabRequester) fetchProjects(ctx context.Context, owner, next string, handlePage RepositoryPageHandler) error {
+	for next != "" {
+		var projects []gitLabProject
+		nextPage, err := g.rest.fetchAndDecode(ctx, owner, next, &projects)
+		if err != nil {
+			return err
+		}
+		if len(projects) == 0 {
+			break
+		}
+		repositories := make([]dto.RepositoryInfoResponseDTO, len(projects))
+		for i := range projects {
+			repositories[i] = projects[i].toDTO(g)
+		}
+		if err := handlePage(&repositories); err != nil {
+			return err
+		}
+		next = nextPage
+	}
+	return nil
+}
+
+func (g *GitLabRequester) GetRepositoryCommits(ctx context.Context, owner, repo string, queryParams *dto.CommitQueryParams, handlePage CommitPageHandler) error {
+	// logic to fetch project commits, one page at a time
+	query := url.Values{}
+	query.Set("per_page", fmt.Sprint(g.rest.perPage))
+	if queryParams != nil {
+		if queryParams.SHA != "" {
+			query.Set("ref_name", queryParams.SHA)
+		}
+		if queryParams.Since != "" {
+			query.Set("since", queryParams.Since)
+		}
+		if queryParams.Until != "" {
+			query.Set("until", queryParams.Until)
+		}
+	}
+	next := g.rest.endpoint("/projects/%s/repository/commits?", projectPath(owner, repo)) + query.Encode()
+	for next != "" {
+		var gitLabCommits []gitLabCommit
+		nextPage, err := g.rest.fetchAndDecode(ctx, owner, next, &gitLabCommits)
+		if err != nil {
+			return err
+		}
+		if len(gitLabCommits) == 0 {
+			break
+		}
+		commits := make([]dto.CommitResponseDTO, len(gitLabCommits))
+		for i := range gitLabCommits {
+			commits[i] = gitLabCommits[i].toDTO()
+		}
+		if err := handlePage(&commits); err != nil {
+			return err
+		}
+		next = nextPage
+	}
+	return nil
+}
+
+func (g *GitLabRequester) GetCommit(ctx context.Context, owner, repo, sha string) (*dto.CommitDetailResponseDTO, error) {
+	// fetch a single commit with its stats, then its diff for the files it touched
+	var commit gitLabCommit
+	_, err := g.rest.fetchAndDecode(ctx, owner, g.rest.endpoint("/projects/%s/repository/commits/%s?stats=true", projectPath(owner, repo), url.PathEscape(sha)), &commit)
+	if err != nil {
+		return nil, err
+	}
+	detail := &dto.CommitDetailResponseDTO{
+		CommitResponseDTO: commit.toDTO(),
+		Additions:         commit.Stats.Additions,
+		Deletions:         commit.Stats.Deletions,
+	}
+	next := g.rest.endpoint("/projects/%s/repository/commits/%s/diff?per_page=%d", projectPath(owner, repo), url.PathEscape(sha), g.rest.perPage)
+	for next != "" {
+		var diffs []gitLabDiff
+		next, err = g.rest.fetchAndDecode(ctx, owner, next, &diffs)
+		if err != nil {
+			return nil, err
+		}
+		if len(diffs) == 0 {
+			break
+		}
+		for i := range diffs {
+			detail.Files = append(detail.Files, diffs[i].toDTO())
+		}
+	}
+	return detail, nil
+}
+
+// fetchRefs pages through the branches or tags of a project.
+func (g *GitLabRequester) fetchRefs(ctx context.Context, owner, repo, kind string, handlePage func(refs []gitLabRef) error) error {
+	next := g.rest.endpoint("/projects/%s/repository/%s?per_page=%d", projectPath(owner, repo), kind, g.rest.perPage)
+	for next != "" {
+		var refs []gitLabRef
+		nextPage, err := g.rest.fetchAndDecode(ctx, owner, next, &refs)
+		if err != nil {
+			return err
+		}
+		if len(refs) == 0 {
+			break
+		}
+		if err := handlePage(refs); err != nil {
+			return err
+		}
+		next = nextPage
+	}
+	return nil
+}
+
+func (g *GitLabRequester) GetRepositoryBranches(ctx context.Context, owner, repo string, handlePage BranchPageHandler) error {
+	return g.fetchRefs(ctx, owner, repo, "branches", func(refs []gitLabRef) error {
+		branches := make([]dto.BranchResponseDTO, len(refs))
+		for i, ref := range refs {
+			branches[i] = dto.BranchResponseDTO{Name: ref.Name, SHA: ref.Commit.ID, Protected: ref.Protected}
+		}
+		return handlePage(&branches)
+	})
+}
+
+func (g *GitLabRequester) GetRepositoryTags(ctx context.Context, owner, repo string, handlePage TagPageHandler) error {
+	return g.fetchRefs(ctx, owner, repo, "tags", func(refs []gitLabRef) error {
+		tags := make([]dto.TagResponseDTO, len(refs))
+		for i, ref := range refs {
+			tags[i] = dto.TagResponseDTO{Name: ref.Name, SHA: ref.Commit.ID}
+		}
+		return handlePage(&tags)
+	})
+}
+
+func (g *GitLabRequester) GetRepositoryReleases(ctx context.Context, owner, repo string, handlePage ReleasePageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (g *GitLabRequester) GetRepositoryPullRequests(ctx context.Context, owner, repo string, handlePage PullRequestPageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (g *GitLabRequester) GetRepositoryIssues(ctx context.Context, owner, repo, since string, handlePage IssuePageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (g *GitLabRequester) GetRepositoryContributors(ctx context.Context, owner, repo string, handlePage ContributorPageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (g *GitLabRequester) GetContributorStats(ctx context.Context, owner, repo string) ([]dto.ContributorStatsResponseDTO, error) {
+	return nil, utils.ErrNotSupported
+}
+
+// GetRepositoryLanguages is not supported, as GitLab only reports the share of each language,
+// which cannot be added up across projects.
+func (g *GitLabRequester) GetRepositoryLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	return nil, utils.ErrNotSupported
+}
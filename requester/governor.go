@@ -0,0 +1,227 @@
+package requester
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/midedickson/github-service/utils"
+)
+
+// defaultInteractiveReserve is how many requests per credential background work leaves for interactive work.
+const defaultInteractiveReserve = 100
+
+// Priority orders requests competing for the same rate-limit budget.
+type Priority int
+
+const (
+	// PriorityBackground is used by periodic syncs; it never dips into the interactive reserve.
+	PriorityBackground Priority = iota
+	// PriorityInteractive is used for work a user is waiting on, such as a newly requested repository.
+	PriorityInteractive
+)
+
+type priorityKey struct{}
+
+// WithPriority marks every request made with ctx as having the given priority.
+func WithPriority(ctx context.Context, priority Priority) context.Context {
+	return context.WithValue(ctx, priorityKey{}, priority)
+}
+
+func priorityFrom(ctx context.Context) Priority {
+	if priority, ok := ctx.Value(priorityKey{}).(Priority); ok {
+		return priority
+	}
+	return PriorityBackground
+}
+
+// rateLimitState is the budget GitHub last reported for a single credential,
+// less the requests started since.
+type rateLimitState struct {
+	label     string
+	limit     int
+	remaining int
+	reset     time.Time
+	observed  bool
+}
+
+// available reports how many requests the credential can still make, treating one whose
+// window has already reset, or one we have not used yet, as fully available.
+func (s rateLimitState) available(now time.Time) int {
+	if !s.observed || now.After(s.reset) {
+		return int(^uint(0) >> 1)
+	}
+	return s.remaining
+}
+
+// RateLimitStatus is the governor's view of one credential's budget.
+type RateLimitStatus struct {
+	Credential string    `json:"credential"`
+	Limit      int       `json:"limit"`
+	Remaining  int       `json:"remaining"`
+	Reset      time.Time `json:"reset"`
+	Reserved   int       `json:"reservedForInteractive"`
+}
+
+// Governor is the single place every GitHub request acquires rate-limit budget
+// from. It is safe for concurrent use and can be shared by several requesters.
+type Governor struct {
+	mu      sync.Mutex
+	states  map[string]*rateLimitState
+	reserve int
+}
+
+// NewGovernor creates a governor that keeps reserve requests per credential for
+// interactive work; a non-positive reserve uses the default of 100.
+func NewGovernor(reserve int) *Governor {
+	if reserve <= 0 {
+		reserve = defaultInteractiveReserve
+	}
+	return &Governor{states: make(map[string]*rateLimitState), reserve: reserve}
+}
+
+// reserveFor caps the interactive reserve so small budgets, like the 60 requests
+// an anonymous client gets, are not entirely held back from background work.
+func (g *Governor) reserveFor(state *rateLimitState) int {
+	if state.limit > 0 && g.reserve > state.limit/4 {
+		return state.limit / 4
+	}
+	return g.reserve
+}
+
+// Acquire blocks until token has budget for a request at the priority carried by
+// ctx and takes one request from it, returning early if ctx is cancelled.
+func (g *Governor) Acquire(ctx context.Context, token string) error {
+	return g.acquire(ctx, token, 1)
+}
+
+// acquire takes cost from the budget kept under key, which is the token itself
+// for REST requests and a separate key for GraphQL points.
+func (g *Governor) acquire(ctx context.Context, key string, cost int) error {
+	priority := priorityFrom(ctx)
+	for {
+		g.mu.Lock()
+		state := g.state(key)
+		now := time.Now()
+		if !state.observed || now.After(state.reset) {
+			g.mu.Unlock()
+			return nil
+		}
+		floor := 0
+		if priority == PriorityBackground {
+			floor = g.reserveFor(state)
+		}
+		if state.remaining >= floor+cost {
+			state.remaining -= cost
+			g.mu.Unlock()
+			return nil
+		}
+		wait := time.Until(state.reset)
+		g.mu.Unlock()
+
+		if err := utils.SleepWithContext(ctx, wait+time.Second); err != nil {
+			return err
+		}
+	}
+}
+
+// Release hands back a request taken with Acquire that turned out to cost nothing, like a
+// conditional request GitHub answered with 304 Not Modified.
+func (g *Governor) Release(token string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	state := g.state(token)
+	if state.observed {
+		state.remaining++
+	}
+}
+
+// Observe records the rate-limit headers GitHub returned for a request made with token.
+func (g *Governor) Observe(token, label string, header http.Header) RateLimitStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	state := g.state(token)
+	state.label = label
+	if reset := header.Get("x-ratelimit-reset"); reset != "" {
+		resetTime, _ := strconv.ParseInt(reset, 10, 64)
+		if newReset := time.Unix(resetTime, 0); !newReset.Equal(state.reset) {
+			// a new window; drop what we counted against the old one
+			state.reset = newReset
+			state.observed = false
+		}
+	}
+	if limit := header.Get("x-ratelimit-limit"); limit != "" {
+		state.limit, _ = strconv.Atoi(limit)
+	}
+	if remaining := header.Get("x-ratelimit-remaining"); remaining != "" {
+		reported, _ := strconv.Atoi(remaining)
+		// responses can arrive out of order; within a window the budget only ever shrinks
+		if !state.observed || reported < state.remaining {
+			state.remaining = reported
+		}
+		state.observed = true
+	}
+	return g.status(state)
+}
+
+// observeBudget records a budget reported in a response body rather than in headers,
+// as GraphQL does with its rateLimit object.
+func (g *Governor) observeBudget(key, label string, limit, remaining int, reset time.Time) RateLimitStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	state := g.state(key)
+	state.label = label
+	if !reset.Equal(state.reset) {
+		state.reset = reset
+		state.observed = false
+	}
+	state.limit = limit
+	if !state.observed || remaining < state.remaining {
+		state.remaining = remaining
+	}
+	state.observed = true
+	return g.status(state)
+}
+
+// Snapshot returns the current budget of every credential the governor has seen.
+func (g *Governor) Snapshot() []RateLimitStatus {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	statuses := make([]RateLimitStatus, 0, len(g.states))
+	for _, state := range g.states {
+		if state.observed {
+			statuses = append(statuses, g.status(state))
+		}
+	}
+	sort.Slice(statuses, func(i, j int) bool { return statuses[i].Credential < statuses[j].Credential })
+	return statuses
+}
+
+func (g *Governor) available(token string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.state(token).available(time.Now())
+}
+
+func (g *Governor) status(state *rateLimitState) RateLimitStatus {
+	return RateLimitStatus{
+		Credential: state.label,
+		Limit:      state.limit,
+		Remaining:  state.remaining,
+		Reset:      state.reset,
+		Reserved:   g.reserveFor(state),
+	}
+}
+
+// state must be called with g.mu held.
+func (g *Governor) state(token string) *rateLimitState {
+	state, ok := g.states[token]
+	if !ok {
+		state = &rateLimitState{}
+		g.states[token] = state
+	}
+	return state
+}
@@ -0,0 +1,336 @@
+package requester
+
+import (
+	"context"
+	"strings"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/utils"
+)
+
+// graphQLBatchSize is how many repositories a single batched metadata query asks for.
+const graphQLBatchSize = 50
+
+// GraphQLRequester fetches repository metadata, repository listings and commit histories
+// through the GitHub GraphQL API, falling back to the REST requester it wraps for
+// everything else. Its points are budgeted by the same governor as the REST requests.
+type GraphQLRequester struct {
+	*RepositoryRequester
+	costs graphQLCosts
+}
+
+func NewGraphQLRequester(opts *Options) *GraphQLRequester {
+	return &GraphQLRequester{RepositoryRequester: NewRepositoryRequester(opts)}
+}
+
+const graphQLRepositoryFields = `
+	databaseId name nameWithOwner url description isFork isArchived isPrivate visibility
+	repositoryTopics(first: 20) { nodes { topic { name } } }
+	licenseInfo { key name spdxId }
+	primaryLanguage { name }
+	forkCount stargazerCount diskUsage
+	issues(states: OPEN) { totalCount }
+	pullRequests(states: OPEN) { totalCount }
+	defaultBranchRef { name }
+	createdAt updatedAt pushedAt`
+
+type graphQLRepository struct {
+	DatabaseID       int    `json:"databaseId"`
+	Name             string `json:"name"`
+	NameWithOwner    string `json:"nameWithOwner"`
+	URL              string `json:"url"`
+	Description      string `json:"description"`
+	IsFork           bool   `json:"isFork"`
+	IsArchived       bool   `json:"isArchived"`
+	IsPrivate        bool   `json:"isPrivate"`
+	Visibility       string `json:"visibility"`
+	RepositoryTopics struct {
+		Nodes []struct {
+			Topic struct {
+				Name string `json:"name"`
+			} `json:"topic"`
+		} `json:"nodes"`
+	} `json:"repositoryTopics"`
+	LicenseInfo *struct {
+		Key    string `json:"key"`
+		Name   string `json:"name"`
+		SpdxID string `json:"spdxId"`
+	} `json:"licenseInfo"`
+	PrimaryLanguage *struct {
+		Name string `json:"name"`
+	} `json:"primaryLanguage"`
+	ForkCount      int `json:"forkCount"`
+	StargazerCount int `json:"stargazerCount"`
+	DiskUsage      int `json:"diskUsage"`
+	Issues         struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"issues"`
+	PullRequests struct {
+		TotalCount int `json:"totalCount"`
+	} `json:"pullRequests"`
+	DefaultBranchRef *struct {
+		Name string `json:"name"`
+	} `json:"defaultBranchRef"`
+	CreatedAt string `json:"createdAt"`
+	UpdatedAt string `json:"updatedAt"`
+	PushedAt  string `json:"pushedAt"`
+}
+
+// toDTO maps a repository onto the REST payload, matching its quirks: the open issue
+// count includes open pull requests, watchers are the stargazers, and visibilities are lower case.
+// Only the first 20 topics are fetched, which is as many as GitHub allows.
+func (repo *graphQLRepository) toDTO(g *GraphQLRequester) *dto.RepositoryInfoResponseDTO {
+	info := &dto.RepositoryInfoResponseDTO{
+		ID:          repo.DatabaseID,
+		Name:        repo.Name,
+		FullName:    repo.NameWithOwner,
+		HtmlUrl:     repo.URL,
+		Description: repo.Description,
+		URL:         g.endpoint("/repos/%s", repo.NameWithOwner),
+		Fork:        repo.IsFork,
+		Archived:    repo.IsArchived,
+		Private:     repo.IsPrivate,
+		Visibility:  strings.ToLower(repo.Visibility),
+		ForksCount:  repo.ForkCount,
+		StarsCount:  repo.StargazerCount,
+		OpenIssues:  repo.Issues.TotalCount + repo.PullRequests.TotalCount,
+		Watchers:    repo.StargazerCount,
+		Size:        repo.DiskUsage,
+		CreatedAt:   repo.CreatedAt,
+		UpdatedAt:   repo.UpdatedAt,
+		PushedAt:    repo.PushedAt,
+	}
+	for _, node := range repo.RepositoryTopics.Nodes {
+		info.Topics = append(info.Topics, node.Topic.Name)
+	}
+	if repo.LicenseInfo != nil {
+		info.License = &dto.License{Key: repo.LicenseInfo.Key, Name: repo.LicenseInfo.Name, SPDXID: repo.LicenseInfo.SpdxID}
+	}
+	if repo.PrimaryLanguage != nil {
+		info.Language = repo.PrimaryLanguage.Name
+	}
+	if repo.DefaultBranchRef != nil {
+		info.DefaultBranch = repo.DefaultBranchRef.Name
+	}
+	return info
+}
+
+type graphQLPageInfo struct {
+	HasNextPage bool   `json:"hasNextPage"`
+	EndCursor   string `json:"endCursor"`
+}
+
+func (g *GraphQLRequester) GetRepositoryInfo(ctx context.Context, owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
+	infos, err := g.GetRepositoriesInfo(ctx, owner, []string{repo})
+	if err != nil {
+		return nil, err
+	}
+	info, ok := infos[repo]
+	if !ok {
+		return nil, &utils.UpstreamError{Kind: utils.ErrRepoNotFound, URL: g.graphQLURL, Message: "could not resolve repository " + owner + "/" + repo}
+	}
+	return info, nil
+}
+
+// GetRepositoriesInfo fetches the metadata of many repositories of owner, up to fifty
+// per query. Repositories that could not be found are left out of the result.
+func (g *GraphQLRequester) GetRepositoriesInfo(ctx context.Context, owner string, repos []string) (map[string]*dto.RepositoryInfoResponseDTO, error) {
+	infos := make(map[string]*dto.RepositoryInfoResponseDTO, len(repos))
+	for start := 0; start < len(repos); start += graphQLBatchSize {
+		batch := repos[start:min(start+graphQLBatchSize, len(repos))]
+
+		var query strings.Builder
+		query.WriteString("query { ")
+		for i, repo := range batch {
+			query.WriteString(graphQLAlias(i) + ": repository(owner: " + graphQLString(owner) + ", name: " + graphQLString(repo) + ") {" + graphQLRepositoryFields + " } ")
+		}
+		query.WriteString(graphQLRateLimit + " }")
+
+		var result map[string]*graphQLRepository
+		errs, err := g.query(ctx, owner, "repositories", query.String(), nil, &result)
+		if err != nil {
+			return nil, err
+		}
+		for i, repo := range batch {
+			alias := graphQLAlias(i)
+			if info := result[alias]; info != nil {
+				infos[repo] = info.toDTO(g)
+				continue
+			}
+			if queryErr := graphQLErrorFor(errs, alias); queryErr != nil && queryErr.Type != "NOT_FOUND" {
+				return nil, &utils.UpstreamError{Kind: queryErr.errorKind(), URL: g.graphQLURL, Message: queryErr.Message}
+			}
+		}
+	}
+	return infos, nil
+}
+
+const graphQLUserRepositoriesQuery = `query($owner: String!, $first: Int!, $cursor: String) {
+	repositoryOwner(login: $owner) {
+		repositories(first: $first, after: $cursor, privacy: PUBLIC, ownerAffiliations: [OWNER]) {
+			pageInfo { hasNextPage endCursor }
+			nodes {` + graphQLRepositoryFields + ` }
+		}
+	}
+	` + graphQLRateLimit + `
+}`
+
+func (g *GraphQLRequester) GetAllUserRepositories(ctx context.Context, owner string, handlePage RepositoryPageHandler) error {
+	//  logic to fetch all repositories for a user, one page at a time
+	variables := map[string]interface{}{"owner": owner, "first": g.perPage}
+	for {
+		var result struct {
+			RepositoryOwner *struct {
+				Repositories struct {
+					PageInfo graphQLPageInfo      `json:"pageInfo"`
+					Nodes    []*graphQLRepository `json:"nodes"`
+				} `json:"repositories"`
+			} `json:"repositoryOwner"`
+		}
+		if _, err := g.query(ctx, owner, "userRepositories", graphQLUserRepositoriesQuery, variables, &result); err != nil {
+			return err
+		}
+		if result.RepositoryOwner == nil {
+			return &utils.UpstreamError{Kind: utils.ErrRepoNotFound, URL: g.graphQLURL, Message: "could not resolve owner " + owner}
+		}
+		repositories := result.RepositoryOwner.Repositories
+		if len(repositories.Nodes) == 0 {
+			return nil
+		}
+		page := make([]dto.RepositoryInfoResponseDTO, len(repositories.Nodes))
+		for i, repo := range repositories.Nodes {
+			page[i] = *repo.toDTO(g)
+		}
+		if err := handlePage(&page); err != nil {
+			return err
+		}
+		if !repositories.PageInfo.HasNextPage {
+			return nil
+		}
+		variables["cursor"] = repositories.PageInfo.EndCursor
+	}
+}
+
+const graphQLCommitHistoryQuery = `query($owner: String!, $name: String!, $ref: String!, $first: Int!, $cursor: String, $since: GitTimestamp, $until: GitTimestamp) {
+	repository(owner: $owner, name: $name) {
+		object(expression: $ref) {
+			... on Commit {
+				history(first: $first, after: $cursor, since: $since, until: $until) {
+					pageInfo { hasNextPage endCursor }
+					nodes {
+						oid message url
+						author { name email date user { login databaseId } }
+						committer { name email date user { login databaseId } }
+						parents(first: 100) { nodes { oid } }
+					}
+				}
+			}
+		}
+	}
+	` + graphQLRateLimit + `
+}`
+
+type graphQLGitActor struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Date  string `json:"date"`
+	User  *struct {
+		Login      string `json:"login"`
+		DatabaseID int    `json:"databaseId"`
+	} `json:"user"`
+}
+
+type graphQLCommit struct {
+	OID       string          `json:"oid"`
+	Message   string          `json:"message"`
+	URL       string          `json:"url"`
+	Author    graphQLGitActor `json:"author"`
+	Committer graphQLGitActor `json:"committer"`
+	Parents   struct {
+		Nodes []struct {
+			OID string `json:"oid"`
+		} `json:"nodes"`
+	} `json:"parents"`
+}
+
+// toDTO maps a commit onto the REST payload. Git dates keep the author's offset in GraphQL
+// while REST reports them in UTC, which stored dates rely on to compare in time order.
+func (commit *graphQLCommit) toDTO() dto.CommitResponseDTO {
+	commitDTO := dto.CommitResponseDTO{
+		SHA:            commit.OID,
+		Message:        commit.Message,
+		Author:         commit.Author.Name,
+		AuthorEmail:    commit.Author.Email,
+		Date:           utcTimestamp(commit.Author.Date),
+		Committer:      commit.Committer.Name,
+		CommitterEmail: commit.Committer.Email,
+		CommitterDate:  utcTimestamp(commit.Committer.Date),
+		URL:            commit.URL,
+	}
+	for _, parent := range commit.Parents.Nodes {
+		commitDTO.Parents = append(commitDTO.Parents, parent.OID)
+	}
+	// databaseId is the account ID the REST API reports
+	if commit.Author.User != nil {
+		commitDTO.AuthorLogin = commit.Author.User.Login
+		commitDTO.AuthorID = commit.Author.User.DatabaseID
+	}
+	if commit.Committer.User != nil {
+		commitDTO.CommitterLogin = commit.Committer.User.Login
+		commitDTO.CommitterID = commit.Committer.User.DatabaseID
+	}
+	return commitDTO
+}
+
+func (g *GraphQLRequester) GetRepositoryCommits(ctx context.Context, owner, repo string, queryParams *dto.CommitQueryParams, handlePage CommitPageHandler) error {
+	// logic to walk a repository's commit history with cursor pagination, one page at a time
+	params := dto.CommitQueryParams{}
+	if queryParams != nil {
+		params = *queryParams
+	}
+	ref := params.SHA
+	if ref == "" {
+		ref = "HEAD"
+	}
+	variables := map[string]interface{}{"owner": owner, "name": repo, "ref": ref, "first": g.perPage}
+	if params.Since != "" {
+		variables["since"] = params.Since
+	}
+	if params.Until != "" {
+		variables["until"] = params.Until
+	}
+
+	for {
+		var result struct {
+			Repository *struct {
+				Object *struct {
+					History *struct {
+						PageInfo graphQLPageInfo `json:"pageInfo"`
+						Nodes    []graphQLCommit `json:"nodes"`
+					} `json:"history"`
+				} `json:"object"`
+			} `json:"repository"`
+		}
+		if _, err := g.query(ctx, owner, "commitHistory", graphQLCommitHistoryQuery, variables, &result); err != nil {
+			return err
+		}
+		if result.Repository == nil || result.Repository.Object == nil || result.Repository.Object.History == nil {
+			return &utils.UpstreamError{Kind: utils.ErrRepoNotFound, URL: g.graphQLURL, Message: "could not resolve " + ref + " in " + owner + "/" + repo}
+		}
+		history := result.Repository.Object.History
+		if len(history.Nodes) == 0 {
+			return nil
+		}
+		commits := make([]dto.CommitResponseDTO, len(history.Nodes))
+		for i := range history.Nodes {
+			commits[i] = history.Nodes[i].toDTO()
+		}
+		if err := handlePage(&commits); err != nil {
+			return err
+		}
+		if !history.PageInfo.HasNextPage {
+			return nil
+		}
+		variables["cursor"] = history.PageInfo.EndCursor
+	}
+}
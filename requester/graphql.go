@@ -0,0 +1,187 @@
+package requester
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/midedickson/github-service/utils"
+)
+
+// graphQLBudgetKey keeps a token's GraphQL points apart from its REST requests in
+// the governor; GitHub limits the two separately.
+func graphQLBudgetKey(token string) string {
+	return "graphql:" + token
+}
+
+// graphQLRateLimit is requested with every query, so the governor learns what each query cost.
+const graphQLRateLimit = `rateLimit { limit cost remaining resetAt }`
+
+type graphQLRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables,omitempty"`
+}
+
+type graphQLError struct {
+	Type    string        `json:"type"`
+	Path    []interface{} `json:"path"`
+	Message string        `json:"message"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+type graphQLRateLimitData struct {
+	RateLimit *struct {
+		Limit     int       `json:"limit"`
+		Cost      int       `json:"cost"`
+		Remaining int       `json:"remaining"`
+		ResetAt   time.Time `json:"resetAt"`
+	} `json:"rateLimit"`
+}
+
+// graphQLCosts remembers the point cost GitHub last reported for each named query,
+// which is what the next run of the query acquires from the governor up front.
+type graphQLCosts struct {
+	mu    sync.Mutex
+	costs map[string]int
+}
+
+func (c *graphQLCosts) estimate(queryName string) int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cost, ok := c.costs[queryName]; ok && cost > 0 {
+		return cost
+	}
+	return 1
+}
+
+func (c *graphQLCosts) record(queryName string, cost int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.costs == nil {
+		c.costs = make(map[string]int)
+	}
+	c.costs[queryName] = cost
+}
+
+// errorKind maps the type of a GraphQL error onto the error kinds used for REST responses.
+func (e graphQLError) errorKind() error {
+	switch e.Type {
+	case "NOT_FOUND":
+		return utils.ErrRepoNotFound
+	case "FORBIDDEN":
+		return utils.ErrForbidden
+	case "RATE_LIMITED":
+		return utils.ErrRateLimited
+	default:
+		return utils.ErrInvalidRequest
+	}
+}
+
+// query runs a GraphQL query on behalf of owner and decodes its data into result. Errors that only
+// concern some of the aliased fields are returned to the caller, which decides whether the partial
+// data is still of use; any other error fails the query.
+func (g *GraphQLRequester) query(ctx context.Context, owner, queryName, query string, variables map[string]interface{}, result interface{}) ([]graphQLError, error) {
+	body, err := json.Marshal(graphQLRequest{Query: query, Variables: variables})
+	if err != nil {
+		return nil, err
+	}
+	resp, token, err := g.sendGraphQL(ctx, owner, queryName, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+
+	var response graphQLResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, decodeError(resp, err)
+	}
+	var rateLimit graphQLRateLimitData
+	if len(response.Data) > 0 && json.Unmarshal(response.Data, &rateLimit) == nil && rateLimit.RateLimit != nil {
+		limit := rateLimit.RateLimit
+		g.costs.record(queryName, limit.Cost)
+		status := g.governor.observeBudget(graphQLBudgetKey(token), g.credentials.Label(token)+" (graphql)", limit.Limit, limit.Remaining, limit.ResetAt)
+		log.Printf("GraphQL rate limit (%s): %d, Remaining: %d, Cost of %s: %d", status.Credential, status.Limit, status.Remaining, queryName, limit.Cost)
+	}
+
+	if len(response.Errors) > 0 && (len(response.Data) == 0 || string(response.Data) == "null") {
+		first := response.Errors[0]
+		return nil, &utils.UpstreamError{Kind: first.errorKind(), StatusCode: resp.StatusCode, URL: g.graphQLURL, Message: first.Message}
+	}
+	if err := json.Unmarshal(response.Data, result); err != nil {
+		return nil, decodeError(resp, err)
+	}
+	return response.Errors, nil
+}
+
+// sendGraphQL posts body, acquiring the query's estimated cost in points first and
+// retrying transient failures like doRequest does for REST calls.
+func (g *GraphQLRequester) sendGraphQL(ctx context.Context, owner, queryName string, body []byte) (*http.Response, string, error) {
+	for attempt := 1; ; attempt++ {
+		token, err := g.credentials.Token(ctx, owner)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := g.governor.acquire(ctx, graphQLBudgetKey(token), g.costs.estimate(queryName)); err != nil {
+			return nil, "", err
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, g.graphQLURL, bytes.NewReader(body))
+		if err != nil {
+			return nil, "", err
+		}
+		req.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := g.Do(req)
+		delay, reason, retry := g.retry.shouldRetry(ctx, attempt, resp, err)
+		if !retry {
+			if err != nil {
+				return nil, "", transportError(req, err)
+			}
+			return resp, token, nil
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("Retrying GraphQL query %s in %v after %s (attempt %d of %d)", queryName, delay, reason, attempt+1, g.retry.MaxAttempts)
+		if err := utils.SleepWithContext(ctx, delay); err != nil {
+			return nil, "", err
+		}
+	}
+}
+
+// graphQLAlias names the field a batched query fetches the i-th item into.
+func graphQLAlias(i int) string {
+	return fmt.Sprintf("r%d", i)
+}
+
+// graphQLString quotes s as a GraphQL string literal, for values inlined into batched queries.
+func graphQLString(s string) string {
+	quoted, _ := json.Marshal(s)
+	return string(quoted)
+}
+
+// graphQLErrorFor returns the first error whose path starts at field, if any.
+func graphQLErrorFor(errs []graphQLError, field string) *graphQLError {
+	for i, err := range errs {
+		if len(err.Path) > 0 && fmt.Sprint(err.Path[0]) == field {
+			return &errs[i]
+		}
+	}
+	return nil
+}
@@ -1,11 +1,55 @@
 package requester
 
 import (
+	"context"
+
 	"github.com/midedickson/github-service/dto"
 )
 
+// CommitPageHandler receives each page of commits as it is fetched.
+type CommitPageHandler func(commits *[]dto.CommitResponseDTO) error
+
+// RepositoryPageHandler receives each page of repositories as it is fetched.
+type RepositoryPageHandler func(repositories *[]dto.RepositoryInfoResponseDTO) error
+
+// BranchPageHandler receives each page of branches as it is fetched.
+type BranchPageHandler func(branches *[]dto.BranchResponseDTO) error
+
+// TagPageHandler receives each page of tags as it is fetched.
+type TagPageHandler func(tags *[]dto.TagResponseDTO) error
+
+// ReleasePageHandler receives each page of releases as it is fetched.
+type ReleasePageHandler func(releases *[]dto.ReleaseResponseDTO) error
+
+// PullRequestPageHandler receives each page of pull requests as it is fetched.
+type PullRequestPageHandler func(pullRequests *[]dto.PullRequestResponseDTO) error
+
+// IssuePageHandler receives each page of issues as it is fetched.
+type IssuePageHandler func(issues *[]dto.IssueResponseDTO) error
+
+// ContributorPageHandler receives each page of contributors as it is fetched.
+type ContributorPageHandler func(contributors *[]dto.ContributorResponseDTO) error
+
 type Requester interface {
-	GetRepositoryInfo(owner, repo string) (*dto.RepositoryInfoResponseDTO, error)
-	GetRepositoryCommits(owner, repo string, queryParams *dto.CommitQueryParams) (*[]dto.CommitResponseDTO, error)
-	GetAllUserRepositories(owner string) (*[]dto.RepositoryInfoResponseDTO, error)
+	GetRepositoryInfo(ctx context.Context, owner, repo string) (*dto.RepositoryInfoResponseDTO, error)
+	GetRepositoryCommits(ctx context.Context, owner, repo string, queryParams *dto.CommitQueryParams, handlePage CommitPageHandler) error
+	GetAllUserRepositories(ctx context.Context, owner string, handlePage RepositoryPageHandler) error
+	GetOwner(ctx context.Context, owner string) (*dto.OwnerResponseDTO, error)
+	GetAllOrganizationRepositories(ctx context.Context, org string, handlePage RepositoryPageHandler) error
+	GetCommit(ctx context.Context, owner, repo, sha string) (*dto.CommitDetailResponseDTO, error)
+	GetRepositoryBranches(ctx context.Context, owner, repo string, handlePage BranchPageHandler) error
+	GetRepositoryTags(ctx context.Context, owner, repo string, handlePage TagPageHandler) error
+	GetRepositoryReleases(ctx context.Context, owner, repo string, handlePage ReleasePageHandler) error
+	GetRepositoryPullRequests(ctx context.Context, owner, repo string, handlePage PullRequestPageHandler) error
+	GetRepositoryIssues(ctx context.Context, owner, repo, since string, handlePage IssuePageHandler) error
+	GetRepositoryContributors(ctx context.Context, owner, repo string, handlePage ContributorPageHandler) error
+	GetContributorStats(ctx context.Context, owner, repo string) ([]dto.ContributorStatsResponseDTO, error)
+	// GetRepositoryLanguages returns the bytes of code in each language of repo
+	GetRepositoryLanguages(ctx context.Context, owner, repo string) (map[string]int, error)
+}
+
+// BatchRequester is implemented by requesters that can fetch the metadata of many
+// repositories of an owner at once. Repositories that were not found are left out.
+type BatchRequester interface {
+	GetRepositoriesInfo(ctx context.Context, owner string, repos []string) (map[string]*dto.RepositoryInfoResponseDTO, error)
 }
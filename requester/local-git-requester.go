@@ -0,0 +1,444 @@
+package requester
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/utils"
+)
+
+// localCommitFormat is the git log format commits are read in: the fields of each commit are
+// separated by NUL bytes and commits are terminated by a record separator, since messages span lines.
+const localCommitFormat = "--format=%H%x00%an%x00%ae%x00%aI%x00%cn%x00%ce%x00%cI%x00%P%x00%B%x1e"
+
+// LocalGitRequester reads repositories and their history straight from git clones on disk,
+// laid out as {root}/{owner}/{repo}.git, or {root}/{owner}/{repo} for clones with a work tree.
+// It runs the git command line, so it makes no API calls and has no rate limit to respect.
+// Git knows nothing of releases, pull requests, issues, accounts or languages, so those are not supported.
+type LocalGitRequester struct {
+	root    string
+	perPage int
+}
+
+// NewLocalGitRequester creates a requester reading the clones under root, handing commits
+// to page handlers perPage at a time; zero uses the same default as the API requesters.
+func NewLocalGitRequester(root string, perPage int) *LocalGitRequester {
+	if perPage <= 0 {
+		perPage = maxPerPage
+	}
+	return &LocalGitRequester{root: root, perPage: perPage}
+}
+
+// notFound reports a repository, owner or commit missing from disk the way a 404 from an API is reported.
+func notFound(path, message string) error {
+	return &utils.UpstreamError{Kind: utils.ErrRepoNotFound, URL: path, Message: message}
+}
+
+// isPathSegment guards against names from requests reaching outside of the root directory.
+func isPathSegment(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// gitDir finds the git directory of repo, whether it is a bare clone or has a work tree.
+func (l *LocalGitRequester) gitDir(owner, repo string) (string, error) {
+	if !isPathSegment(owner) || !isPathSegment(repo) {
+		return "", notFound(owner+"/"+repo, "not a repository name")
+	}
+	base := filepath.Join(l.root, owner, repo)
+	for _, dir := range []string{base + ".git", filepath.Join(base, ".git"), base} {
+		if _, err := os.Stat(filepath.Join(dir, "HEAD")); err == nil {
+			return dir, nil
+		}
+	}
+	return "", notFound(base, "no git repository for "+owner+"/"+repo)
+}
+
+// git runs a git command against the repository in dir and returns what it printed.
+func git(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"--git-dir", dir}, args...)...)
+	out, err := cmd.Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return nil, fmt.Errorf("git %s in %s: %w: %s", args[0], dir, err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+		return nil, fmt.Errorf("git %s in %s: %w", args[0], dir, err)
+	}
+	return out, nil
+}
+
+// repositoryID derives a stable ID for a repository from its name, standing in for the ID an API would assign.
+func repositoryID(owner, repo string) int {
+	hash := fnv.New32a()
+	hash.Write([]byte(owner + "/" + repo))
+	return int(hash.Sum32() & 0x7fffffff)
+}
+
+func (l *LocalGitRequester) GetRepositoryInfo(ctx context.Context, owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
+	dir, err := l.gitDir(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	info := &dto.RepositoryInfoResponseDTO{
+		ID:       repositoryID(owner, repo),
+		Name:     repo,
+		FullName: owner + "/" + repo,
+		// a clone has no web page, and its path on this server is not for the API's clients to see
+	}
+	if head, err := git(ctx, dir, "symbolic-ref", "--short", "HEAD"); err == nil {
+		info.DefaultBranch = strings.TrimSpace(string(head))
+	}
+	// the repository counts as updated whenever any branch or tag moves to a newer commit
+	updated, err := git(ctx, dir, "for-each-ref", "--sort=-committerdate", "--count=1", "--format=%(committerdate:iso-strict)", "refs/heads", "refs/tags")
+	if err != nil {
+		return nil, err
+	}
+	info.UpdatedAt = utcTimestamp(strings.TrimSpace(string(updated)))
+	// refs only move when something is pushed or fetched into the clone
+	info.PushedAt = info.UpdatedAt
+	if description, err := os.ReadFile(filepath.Join(dir, "description")); err == nil && !bytes.HasPrefix(description, []byte("Unnamed repository")) {
+		info.Description = strings.TrimSpace(string(description))
+	}
+	return info, nil
+}
+
+func (l *LocalGitRequester) GetAllUserRepositories(ctx context.Context, owner string, handlePage RepositoryPageHandler) error {
+	//  logic to list every clone in the owner's directory; there are few enough to hand over in one page
+	if !isPathSegment(owner) {
+		return notFound(owner, "not an owner name")
+	}
+	entries, err := os.ReadDir(filepath.Join(l.root, owner))
+	if errors.Is(err, os.ErrNotExist) {
+		return notFound(filepath.Join(l.root, owner), "no directory for "+owner)
+	}
+	if err != nil {
+		return err
+	}
+	repositories := []dto.RepositoryInfoResponseDTO{}
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		info, err := l.GetRepositoryInfo(ctx, owner, strings.TrimSuffix(entry.Name(), ".git"))
+		if errors.Is(err, utils.ErrRepoNotFound) {
+			// not every directory is a clone
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		repositories = append(repositories, *info)
+	}
+	if len(repositories) == 0 {
+		return nil
+	}
+	return handlePage(&repositories)
+}
+
+// parseLocalCommit parses a commit printed in localCommitFormat.
+func parseLocalCommit(record string) (dto.CommitResponseDTO, error) {
+	fields := strings.SplitN(strings.TrimLeft(record, "\n"), "\x00", 9)
+	if len(fields) != 9 {
+		return dto.CommitResponseDTO{}, fmt.Errorf("unexpected git log output %q", record)
+	}
+	return dto.CommitResponseDTO{
+		SHA:            fields[0],
+		Author:         fields[1],
+		AuthorEmail:    fields[2],
+		Date:           utcTimestamp(fields[3]),
+		Committer:      fields[4],
+		CommitterEmail: fields[5],
+		CommitterDate:  utcTimestamp(fields[6]),
+		Parents:        strings.Fields(fields[7]),
+		Message:        strings.TrimRight(fields[8], "\n"),
+	}, nil
+}
+
+// splitCommitRecords splits git log output at the record separator ending each commit.
+func splitCommitRecords(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexByte(data, '\x1e'); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(bytes.TrimSpace(data)) > 0 {
+		return len(data), data, nil
+	}
+	if atEOF {
+		return len(data), nil, nil
+	}
+	return 0, nil, nil
+}
+
+func (l *LocalGitRequester) GetRepositoryCommits(ctx context.Context, owner, repo string, queryParams *dto.CommitQueryParams, handlePage CommitPageHandler) error {
+	// logic to stream the history of a ref through git log, newest first, handing it over a page at a time
+	dir, err := l.gitDir(owner, repo)
+	if err != nil {
+		return err
+	}
+	params := dto.CommitQueryParams{}
+	if queryParams != nil {
+		params = *queryParams
+	}
+	ref := params.SHA
+	if ref == "" {
+		ref = "HEAD"
+	}
+	args := []string{"--git-dir", dir, "log", localCommitFormat}
+	if params.Since != "" {
+		args = append(args, "--since="+params.Since)
+	}
+	if params.Until != "" {
+		args = append(args, "--until="+params.Until)
+	}
+	args = append(args, ref, "--")
+
+	// stop git as soon as we stop reading, e.g. once a handler catches up with the last sync
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	cmd := exec.CommandContext(ctx, "git", args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	err = l.readCommitPages(stdout, handlePage)
+	if err != nil {
+		cancel()
+		cmd.Wait()
+		return err
+	}
+	if err := cmd.Wait(); err != nil {
+		if strings.Contains(stderr.String(), "unknown revision") {
+			return notFound(dir, "no ref "+ref+" in "+owner+"/"+repo)
+		}
+		return fmt.Errorf("git log in %s: %w: %s", dir, err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// readCommitPages parses git log output as it is printed, handing it over a page at a time.
+func (l *LocalGitRequester) readCommitPages(stdout io.Reader, handlePage CommitPageHandler) error {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 16*1024*1024)
+	scanner.Split(splitCommitRecords)
+	commits := make([]dto.CommitResponseDTO, 0, l.perPage)
+	for scanner.Scan() {
+		commit, err := parseLocalCommit(scanner.Text())
+		if err != nil {
+			return err
+		}
+		commits = append(commits, commit)
+		if len(commits) == l.perPage {
+			if err := handlePage(&commits); err != nil {
+				return err
+			}
+			commits = make([]dto.CommitResponseDTO, 0, l.perPage)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if len(commits) == 0 {
+		return nil
+	}
+	return handlePage(&commits)
+}
+
+// localFileStatuses maps the status letters of git diff --name-status onto GitHub's file statuses.
+var localFileStatuses = map[byte]string{
+	'A': "added",
+	'D': "removed",
+	'M': "modified",
+	'R': "renamed",
+	'C': "copied",
+	'T': "changed",
+}
+
+func (l *LocalGitRequester) GetCommit(ctx context.Context, owner, repo, sha string) (*dto.CommitDetailResponseDTO, error) {
+	// read a single commit with the lines it changed in each file; merges are compared with their
+	// first parent, as GitHub does
+	dir, err := l.gitDir(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := git(ctx, dir, "cat-file", "-e", sha+"^{commit}"); err != nil {
+		return nil, notFound(dir, "no commit "+sha+" in "+owner+"/"+repo)
+	}
+	header, err := git(ctx, dir, "show", "-s", localCommitFormat, sha)
+	if err != nil {
+		return nil, err
+	}
+	commit, err := parseLocalCommit(strings.TrimSuffix(string(header), "\x1e\n"))
+	if err != nil {
+		return nil, err
+	}
+	detail := &dto.CommitDetailResponseDTO{CommitResponseDTO: commit}
+
+	diffArgs := []string{"show", "--format=", "-z", "-M", "--diff-merges=first-parent"}
+	statuses, err := git(ctx, dir, append(diffArgs, "--name-status", sha)...)
+	if err != nil {
+		return nil, err
+	}
+	numstat, err := git(ctx, dir, append(diffArgs, "--numstat", sha)...)
+	if err != nil {
+		return nil, err
+	}
+	lineCounts := parseNumstat(string(numstat))
+	for _, file := range parseNameStatus(string(statuses)) {
+		counts := lineCounts[file.Filename]
+		file.Additions, file.Deletions = counts[0], counts[1]
+		file.Changes = file.Additions + file.Deletions
+		detail.Additions += file.Additions
+		detail.Deletions += file.Deletions
+		detail.Files = append(detail.Files, file)
+	}
+	return detail, nil
+}
+
+// parseNameStatus parses git diff --name-status -z output, where renamed and copied files list
+// their old path before their new one.
+func parseNameStatus(out string) []dto.CommitFileDTO {
+	fields := strings.Split(strings.TrimLeft(out, "\n"), "\x00")
+	files := []dto.CommitFileDTO{}
+	for i := 0; i+1 < len(fields); i += 2 {
+		status := fields[i]
+		if status == "" {
+			break
+		}
+		if status[0] == 'R' || status[0] == 'C' {
+			i++
+		}
+		if i+1 >= len(fields) {
+			break
+		}
+		name, ok := localFileStatuses[status[0]]
+		if !ok {
+			name = "modified"
+		}
+		files = append(files, dto.CommitFileDTO{Filename: fields[i+1], Status: name})
+	}
+	return files
+}
+
+// parseNumstat parses git diff --numstat -z output into the lines added and removed per file,
+// keyed by the file's new path. Binary files are reported with "-" and count as no lines.
+func parseNumstat(out string) map[string][2]int {
+	fields := strings.Split(strings.TrimLeft(out, "\n"), "\x00")
+	counts := make(map[string][2]int)
+	for i := 0; i < len(fields); i++ {
+		parts := strings.SplitN(fields[i], "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		additions, _ := strconv.Atoi(parts[0])
+		deletions, _ := strconv.Atoi(parts[1])
+		path := parts[2]
+		if path == "" && i+2 < len(fields) {
+			// a rename: the old and the new path follow as fields of their own
+			path = fields[i+2]
+			i += 2
+		}
+		counts[path] = [2]int{additions, deletions}
+	}
+	return counts
+}
+
+// forEachRef lists the refs under prefix with the commit each points at, peeling annotated tags.
+func forEachRef(ctx context.Context, dir, prefix string) ([][2]string, error) {
+	out, err := git(ctx, dir, "for-each-ref", "--format=%(refname:lstrip=2)%00%(objectname)%00%(*objectname)", prefix)
+	if err != nil {
+		return nil, err
+	}
+	refs := [][2]string{}
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+		sha := fields[1]
+		if fields[2] != "" {
+			sha = fields[2]
+		}
+		refs = append(refs, [2]string{fields[0], sha})
+	}
+	return refs, nil
+}
+
+func (l *LocalGitRequester) GetRepositoryBranches(ctx context.Context, owner, repo string, handlePage BranchPageHandler) error {
+	dir, err := l.gitDir(owner, repo)
+	if err != nil {
+		return err
+	}
+	refs, err := forEachRef(ctx, dir, "refs/heads")
+	if err != nil || len(refs) == 0 {
+		return err
+	}
+	branches := make([]dto.BranchResponseDTO, len(refs))
+	for i, ref := range refs {
+		branches[i] = dto.BranchResponseDTO{Name: ref[0], SHA: ref[1]}
+	}
+	return handlePage(&branches)
+}
+
+func (l *LocalGitRequester) GetRepositoryTags(ctx context.Context, owner, repo string, handlePage TagPageHandler) error {
+	dir, err := l.gitDir(owner, repo)
+	if err != nil {
+		return err
+	}
+	refs, err := forEachRef(ctx, dir, "refs/tags")
+	if err != nil || len(refs) == 0 {
+		return err
+	}
+	tags := make([]dto.TagResponseDTO, len(refs))
+	for i, ref := range refs {
+		tags[i] = dto.TagResponseDTO{Name: ref[0], SHA: ref[1]}
+	}
+	return handlePage(&tags)
+}
+
+// GetOwner is not supported: a directory of clones is neither a user nor an organization.
+func (l *LocalGitRequester) GetOwner(ctx context.Context, owner string) (*dto.OwnerResponseDTO, error) {
+	return nil, utils.ErrNotSupported
+}
+
+func (l *LocalGitRequester) GetAllOrganizationRepositories(ctx context.Context, org string, handlePage RepositoryPageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (l *LocalGitRequester) GetRepositoryReleases(ctx context.Context, owner, repo string, handlePage ReleasePageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (l *LocalGitRequester) GetRepositoryPullRequests(ctx context.Context, owner, repo string, handlePage PullRequestPageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (l *LocalGitRequester) GetRepositoryIssues(ctx context.Context, owner, repo, since string, handlePage IssuePageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (l *LocalGitRequester) GetRepositoryContributors(ctx context.Context, owner, repo string, handlePage ContributorPageHandler) error {
+	return utils.ErrNotSupported
+}
+
+func (l *LocalGitRequester) GetContributorStats(ctx context.Context, owner, repo string) ([]dto.ContributorStatsResponseDTO, error) {
+	return nil, utils.ErrNotSupported
+}
+
+func (l *LocalGitRequester) GetRepositoryLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	return nil, utils.ErrNotSupported
+}
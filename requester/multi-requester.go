@@ -0,0 +1,169 @@
+package requester
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/utils"
+)
+
+type providerKey struct{}
+
+// WithProvider marks every request made with ctx as meant for the given forge.
+func WithProvider(ctx context.Context, provider string) context.Context {
+	return context.WithValue(ctx, providerKey{}, provider)
+}
+
+// ProviderFrom returns the forge requests made with ctx are meant for, GitHub unless marked otherwise.
+func ProviderFrom(ctx context.Context) string {
+	if provider, ok := ctx.Value(providerKey{}).(string); ok && provider != "" {
+		return provider
+	}
+	return entity.ProviderGitHub
+}
+
+// MultiRequester sends each request to the requester of the forge its context is marked for.
+type MultiRequester struct {
+	requesters map[string]Requester
+}
+
+// NewMultiRequester creates a requester dispatching to requesters, keyed by provider.
+func NewMultiRequester(requesters map[string]Requester) *MultiRequester {
+	return &MultiRequester{requesters: requesters}
+}
+
+func (m *MultiRequester) requester(ctx context.Context) (Requester, error) {
+	provider := ProviderFrom(ctx)
+	requester, ok := m.requesters[provider]
+	if !ok {
+		return nil, fmt.Errorf("no requester configured for %s: %w", provider, utils.ErrNotSupported)
+	}
+	return requester, nil
+}
+
+func (m *MultiRequester) GetRepositoryInfo(ctx context.Context, owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return requester.GetRepositoryInfo(ctx, owner, repo)
+}
+
+func (m *MultiRequester) GetRepositoryCommits(ctx context.Context, owner, repo string, queryParams *dto.CommitQueryParams, handlePage CommitPageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetRepositoryCommits(ctx, owner, repo, queryParams, handlePage)
+}
+
+func (m *MultiRequester) GetAllUserRepositories(ctx context.Context, owner string, handlePage RepositoryPageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetAllUserRepositories(ctx, owner, handlePage)
+}
+
+func (m *MultiRequester) GetOwner(ctx context.Context, owner string) (*dto.OwnerResponseDTO, error) {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return requester.GetOwner(ctx, owner)
+}
+
+func (m *MultiRequester) GetAllOrganizationRepositories(ctx context.Context, org string, handlePage RepositoryPageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetAllOrganizationRepositories(ctx, org, handlePage)
+}
+
+func (m *MultiRequester) GetCommit(ctx context.Context, owner, repo, sha string) (*dto.CommitDetailResponseDTO, error) {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return requester.GetCommit(ctx, owner, repo, sha)
+}
+
+func (m *MultiRequester) GetRepositoryBranches(ctx context.Context, owner, repo string, handlePage BranchPageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetRepositoryBranches(ctx, owner, repo, handlePage)
+}
+
+func (m *MultiRequester) GetRepositoryTags(ctx context.Context, owner, repo string, handlePage TagPageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetRepositoryTags(ctx, owner, repo, handlePage)
+}
+
+func (m *MultiRequester) GetRepositoryReleases(ctx context.Context, owner, repo string, handlePage ReleasePageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetRepositoryReleases(ctx, owner, repo, handlePage)
+}
+
+func (m *MultiRequester) GetRepositoryPullRequests(ctx context.Context, owner, repo string, handlePage PullRequestPageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetRepositoryPullRequests(ctx, owner, repo, handlePage)
+}
+
+func (m *MultiRequester) GetRepositoryIssues(ctx context.Context, owner, repo, since string, handlePage IssuePageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetRepositoryIssues(ctx, owner, repo, since, handlePage)
+}
+
+func (m *MultiRequester) GetRepositoryContributors(ctx context.Context, owner, repo string, handlePage ContributorPageHandler) error {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return err
+	}
+	return requester.GetRepositoryContributors(ctx, owner, repo, handlePage)
+}
+
+func (m *MultiRequester) GetContributorStats(ctx context.Context, owner, repo string) ([]dto.ContributorStatsResponseDTO, error) {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return requester.GetContributorStats(ctx, owner, repo)
+}
+
+func (m *MultiRequester) GetRepositoryLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return requester.GetRepositoryLanguages(ctx, owner, repo)
+}
+
+// GetRepositoriesInfo batches the request when the forge's requester can, and returns utils.ErrNotSupported otherwise.
+func (m *MultiRequester) GetRepositoriesInfo(ctx context.Context, owner string, repos []string) (map[string]*dto.RepositoryInfoResponseDTO, error) {
+	requester, err := m.requester(ctx)
+	if err != nil {
+		return nil, err
+	}
+	batchRequester, ok := requester.(BatchRequester)
+	if !ok {
+		return nil, utils.ErrNotSupported
+	}
+	return batchRequester.GetRepositoriesInfo(ctx, owner, repos)
+}
@@ -0,0 +1,73 @@
+package requester
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/midedickson/github-service/interface/repository"
+)
+
+// defaultRequestTimeout bounds a single HTTP round trip when no timeout is configured.
+const defaultRequestTimeout = 30 * time.Second
+
+// endpoints of the public GitHub API, used when no override is configured
+const (
+	DefaultBaseURL    = "https://api.github.com"
+	DefaultUploadURL  = "https://uploads.github.com"
+	DefaultGraphQLURL = "https://api.github.com/graphql"
+)
+
+// Options configures a RepositoryRequester. Zero values fall back to the
+// public GitHub endpoints and the largest page size GitHub allows.
+type Options struct {
+	// BaseURL is the REST API root, e.g. https://github.example.com/api/v3 for GitHub Enterprise Server.
+	BaseURL string
+	// UploadURL is the root for upload endpoints, e.g. https://github.example.com/api/uploads.
+	UploadURL string
+	// GraphQLURL is the GraphQL endpoint, e.g. https://github.example.com/api/graphql.
+	GraphQLURL string
+	// PerPage is the page size requested from paginated listings, capped at 100.
+	PerPage int
+	// Tokens are personal access tokens used to authenticate requests; requests are anonymous when empty.
+	Tokens []string
+	// Credentials overrides Tokens with another way of authenticating, such as a GitHub App.
+	// Requests for owners a GitHub App is not installed for fall back to Tokens when there are any.
+	Credentials CredentialProvider
+	// Cache stores ETag and Last-Modified validators so unchanged resources are
+	// re-fetched with conditional requests; requests are unconditional when nil.
+	Cache repository.HTTPCacheRepository
+	// RequestTimeout bounds each HTTP round trip, defaulting to 30 seconds.
+	RequestTimeout time.Duration
+	// Governor is the rate-limit governor to acquire budget from; share one between
+	// requesters that use the same credentials. A private one is created when nil.
+	Governor *Governor
+	// Retry controls how transient failures are retried; zero fields use DefaultRetryPolicy.
+	Retry RetryPolicy
+	// Transport sends the HTTP requests, such as a CassetteTransport; http.DefaultTransport when nil.
+	Transport http.RoundTripper
+}
+
+func (o *Options) withDefaults() Options {
+	opts := Options{}
+	if o != nil {
+		opts = *o
+	}
+	opts.BaseURL = strings.TrimSuffix(orDefault(opts.BaseURL, DefaultBaseURL), "/")
+	opts.UploadURL = strings.TrimSuffix(orDefault(opts.UploadURL, DefaultUploadURL), "/")
+	opts.GraphQLURL = orDefault(opts.GraphQLURL, DefaultGraphQLURL)
+	if opts.RequestTimeout <= 0 {
+		opts.RequestTimeout = defaultRequestTimeout
+	}
+	if opts.PerPage <= 0 || opts.PerPage > maxPerPage {
+		opts.PerPage = maxPerPage
+	}
+	return opts
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,47 @@
+package requester
+
+import (
+	"context"
+	"strings"
+)
+
+// maxPerPage is the largest page size the GitHub REST API accepts.
+const maxPerPage = 100
+
+// nextPageURL extracts the rel="next" target from a GitHub Link header,
+// returning an empty string once the last page has been reached.
+func nextPageURL(linkHeader string) string {
+	for _, link := range strings.Split(linkHeader, ",") {
+		parts := strings.Split(link, ";")
+		if len(parts) < 2 {
+			continue
+		}
+		for _, param := range parts[1:] {
+			if strings.TrimSpace(param) == `rel="next"` {
+				return strings.Trim(strings.TrimSpace(parts[0]), "<>")
+			}
+		}
+	}
+	return ""
+}
+
+// fetchPages pages through a listing from url by its Link headers, handing each page to handlePage
+// as it arrives. Paging stops after the last page, at the first empty page, or at the first error
+// from a request or from handlePage, which is returned as is.
+func fetchPages[T any](ctx context.Context, r *RepositoryRequester, owner, url string, handlePage func(page []T) error) error {
+	for url != "" {
+		var page []T
+		next, err := r.fetchAndDecode(ctx, owner, url, &page)
+		if err != nil {
+			return err
+		}
+		if len(page) == 0 {
+			break
+		}
+		if err := handlePage(page); err != nil {
+			return err
+		}
+		url = next
+	}
+	return nil
+}
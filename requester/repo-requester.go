@@ -1,123 +1,385 @@
 package requester
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"net/http"
-	"strconv"
-	"time"
+	neturl "net/url"
 
 	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/interface/repository"
 	"github.com/midedickson/github-service/utils"
 )
 
 type RepositoryRequester struct {
 	http.Client
-	rateLimit          int
-	rateLimitRemaining int
-	rateLimitReset     time.Time
+	baseURL     string
+	uploadURL   string
+	graphQLURL  string
+	perPage     int
+	credentials CredentialProvider
+	governor    *Governor
+	retry       RetryPolicy
+	cache       repository.HTTPCacheRepository
 }
 
-func NewRepositoryRequester() *RepositoryRequester {
-	return &RepositoryRequester{}
-}
-
-// handling rate limit
-func (r *RepositoryRequester) checkRateLimit(resp *http.Response) {
-	if limit := resp.Header.Get("x-ratelimit-limit"); limit != "" {
-		r.rateLimit, _ = strconv.Atoi(limit)
+func NewRepositoryRequester(opts *Options) *RepositoryRequester {
+	o := opts.withDefaults()
+	governor := o.Governor
+	if governor == nil {
+		governor = NewGovernor(0)
 	}
-	if remaining := resp.Header.Get("x-ratelimit-remaining"); remaining != "" {
-		r.rateLimitRemaining, _ = strconv.Atoi(remaining)
+	tokens := newTokenPool(o.Tokens, governor)
+	var credentials CredentialProvider = tokens
+	if o.Credentials != nil {
+		credentials = o.Credentials
+		if tokens.authenticated() {
+			credentials = &fallbackCredentials{credentials: o.Credentials, tokens: tokens}
+		}
 	}
-	if reset := resp.Header.Get("x-ratelimit-reset"); reset != "" {
-		resetTime, _ := strconv.ParseInt(reset, 10, 64)
-		r.rateLimitReset = time.Unix(resetTime, 0)
+	return &RepositoryRequester{
+		Client:      http.Client{Timeout: o.RequestTimeout, Transport: o.Transport},
+		baseURL:     o.BaseURL,
+		uploadURL:   o.UploadURL,
+		graphQLURL:  o.GraphQLURL,
+		perPage:     o.PerPage,
+		credentials: credentials,
+		governor:    governor,
+		retry:       o.Retry.withDefaults(),
+		cache:       o.Cache,
 	}
-	log.Printf("Rate limit: %d, Remaining: %d, Reset: %v", r.rateLimit, r.rateLimitRemaining, r.rateLimitReset)
+}
+
+// endpoint builds an absolute REST API URL from a path relative to the configured base URL.
+func (r *RepositoryRequester) endpoint(format string, args ...interface{}) string {
+	return r.baseURL + fmt.Sprintf(format, args...)
+}
 
+// RateLimits reports the budget of every credential this requester's governor has seen.
+func (r *RepositoryRequester) RateLimits() []RateLimitStatus {
+	return r.governor.Snapshot()
 }
 
-func (r *RepositoryRequester) waitForRateLimitReset() {
-	if r.rateLimitRemaining == 0 && time.Now().Before(r.rateLimitReset) {
-		log.Println("Waiting for rate limit reset")
-		time.Sleep(time.Until(r.rateLimitReset))
+// handling rate limit
+func (r *RepositoryRequester) checkRateLimit(token string, resp *http.Response) {
+	status := r.governor.Observe(token, r.credentials.Label(token), resp.Header)
+	log.Printf("Rate limit (%s): %d, Remaining: %d, Reset: %v", status.Credential, status.Limit, status.Remaining, status.Reset)
+}
+
+// waitForRateLimitReset asks the credential provider for a token to use on behalf
+// of owner, then waits for the governor to grant budget for it.
+func (r *RepositoryRequester) waitForRateLimitReset(ctx context.Context, owner string) (string, error) {
+	token, err := r.credentials.Token(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+	if err := r.governor.Acquire(ctx, token); err != nil {
+		return "", err
 	}
+	return token, nil
 }
 
-func (r *RepositoryRequester) doRequest(req *http.Request) (*http.Response, error) {
-	r.waitForRateLimitReset()
+func (r *RepositoryRequester) send(owner string, req *http.Request) (*http.Response, string, error) {
+	token, err := r.waitForRateLimitReset(req.Context(), owner)
+	if err != nil {
+		return nil, "", err
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	} else {
+		req.Header.Del("Authorization")
+	}
 
 	resp, err := r.Do(req)
 	if err != nil {
 		log.Printf("Error whilke making request: %v", err)
-		return nil, err
+		return nil, token, err
 	}
+	if resp.StatusCode == http.StatusNotModified {
+		// answered from the client's cache, which GitHub does not charge for
+		r.governor.Release(token)
+	}
+	r.checkRateLimit(token, resp)
+	return resp, token, nil
+}
 
-	r.checkRateLimit(resp)
-
-	if resp.StatusCode == http.StatusForbidden && resp.Header.Get("x-ratelimit-remaining") == "0" {
-		r.waitForRateLimitReset()
-		resp, err = r.Do(req)
-		if err != nil {
+// doRequest sends req on behalf of owner, retrying transient failures according to the retry policy.
+// Once the attempts run out, the last response is returned for the caller to handle.
+func (r *RepositoryRequester) doRequest(owner string, req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		resp, _, err := r.send(owner, req)
+		delay, reason, retry := r.retry.shouldRetry(ctx, attempt, resp, err)
+		if !retry {
+			if err != nil {
+				return nil, transportError(req, err)
+			}
+			return resp, nil
+		}
+		if resp != nil {
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		log.Printf("Retrying %s in %v after %s (attempt %d of %d)", req.URL.Path, delay, reason, attempt+1, r.retry.MaxAttempts)
+		if err := utils.SleepWithContext(ctx, delay); err != nil {
 			return nil, err
 		}
-		r.checkRateLimit(resp)
 	}
-
-	return resp, nil
 }
 
-func (r *RepositoryRequester) fetchAndDecode(url string, result interface{}) error {
-	req, err := http.NewRequest(http.MethodGet, url, nil)
+// fetchAndDecode decodes a single page from url into result, authenticating on
+// behalf of owner, and returns the URL of the following page, which is empty
+// when there are no more pages.
+func (r *RepositoryRequester) fetchAndDecode(ctx context.Context, owner, url string, result interface{}) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return err
+		return "", err
 	}
-	resp, err := r.doRequest(req)
+	resp, err := r.doRequest(owner, req)
 	if err != nil {
-		return err
+		return "", err
 	}
 	defer resp.Body.Close()
-	if resp.StatusCode == http.StatusNotFound {
-		return utils.ErrRepoNotFound
+	if err := checkResponse(resp); err != nil {
+		return "", err
 	}
 	if err := json.NewDecoder(resp.Body).Decode(result); err != nil {
-		return err
+		return "", decodeError(resp, err)
+	}
+	return nextPageURL(resp.Header.Get("Link")), nil
+}
+
+// fetchRaw returns the body of url as is, for the endpoints that do not answer in JSON.
+func (r *RepositoryRequester) fetchRaw(ctx context.Context, owner, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
 	}
-	return nil
+	resp, err := r.doRequest(owner, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+	return io.ReadAll(resp.Body)
 }
 
-func (r *RepositoryRequester) GetRepositoryInfo(owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
-	// fetch repository info for owner
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s", owner, repo)
+// fetchAndDecodeCached fetches a single resource conditionally, sending the
+// validators stored from the last response to url. When GitHub answers
+// 304 Not Modified, result is decoded from the stored body and the returned
+// flag is true; such responses do not count against the rate limit. The
+// validators are stored as soon as a response arrives, so the flag only says
+// the resource is unchanged since then, not that the caller has stored it.
+func (r *RepositoryRequester) fetchAndDecodeCached(ctx context.Context, owner, url string, result interface{}) (bool, error) {
+	if r.cache == nil {
+		_, err := r.fetchAndDecode(ctx, owner, url, result)
+		return false, err
+	}
+	cached, err := r.cache.GetHTTPCacheEntry(url)
+	if err != nil {
+		log.Printf("Error reading cached validators for %s: %v", url, err)
+		cached = nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return false, err
+	}
+	if cached != nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	resp, err := r.doRequest(owner, req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		return true, json.Unmarshal([]byte(cached.Body), result)
+	}
+	if err := checkResponse(resp); err != nil {
+		return false, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, transportError(req, err)
+	}
+	if err := json.Unmarshal(body, result); err != nil {
+		return false, decodeError(resp, err)
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode == http.StatusOK && (etag != "" || lastModified != "") {
+		if err := r.cache.StoreHTTPCacheEntry(url, etag, lastModified, string(body)); err != nil {
+			log.Printf("Error caching validators for %s: %v", url, err)
+		}
+	}
+	return false, nil
+}
+
+func (r *RepositoryRequester) GetRepositoryInfo(ctx context.Context, owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
+	// fetch repository info for owner, reusing the cached copy when it has not changed
+	url := r.endpoint("/repos/%s/%s", owner, repo)
 	var repository dto.RepositoryInfoResponseDTO
-	if err := r.fetchAndDecode(url, &repository); err != nil {
+	notModified, err := r.fetchAndDecodeCached(ctx, owner, url, &repository)
+	if err != nil {
 		return nil, err
 	}
+	repository.NotModified = notModified
 	return &repository, nil
 }
-func (r *RepositoryRequester) GetRepositoryCommits(owner, repo string, queryParams *dto.CommitQueryParams) (*[]dto.CommitResponseDTO, error) {
-	// logic to fetch repository commits
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits", owner, repo)
+
+func (r *RepositoryRequester) GetRepositoryCommits(ctx context.Context, owner, repo string, queryParams *dto.CommitQueryParams, handlePage CommitPageHandler) error {
+	// logic to fetch repository commits, one page at a time
+	params := dto.CommitQueryParams{}
 	if queryParams != nil {
-		query := queryParams.String()
-		url += query
+		params = *queryParams
 	}
-	var commits []dto.CommitResponseDTO
+	params.PerPage = r.perPage
+	url := r.endpoint("/repos/%s/%s/commits", owner, repo) + params.String()
+	return fetchPages(ctx, r, owner, url, func(commits []dto.CommitResponseDTO) error {
+		return handlePage(&commits)
+	})
+}
 
-	if err := r.fetchAndDecode(url, &commits); err != nil {
+func (r *RepositoryRequester) GetCommit(ctx context.Context, owner, repo, sha string) (*dto.CommitDetailResponseDTO, error) {
+	// fetch a single commit with its stats; GitHub pages the file list of very large commits
+	url := r.endpoint("/repos/%s/%s/commits/%s", owner, repo, sha)
+	var commit dto.CommitDetailResponseDTO
+	next, err := r.fetchAndDecode(ctx, owner, url, &commit)
+	if err != nil {
 		return nil, err
 	}
-	return &commits, nil
+	for next != "" {
+		var page dto.CommitDetailResponseDTO
+		next, err = r.fetchAndDecode(ctx, owner, next, &page)
+		if err != nil {
+			return nil, err
+		}
+		if len(page.Files) == 0 {
+			break
+		}
+		commit.Files = append(commit.Files, page.Files...)
+	}
+	return &commit, nil
+}
+
+func (r *RepositoryRequester) GetAllUserRepositories(ctx context.Context, owner string, handlePage RepositoryPageHandler) error {
+	//  logic to fetch all repositories for a user, one page at a time
+	url := r.endpoint("/users/%s/repos?per_page=%d", owner, r.perPage)
+	return fetchPages(ctx, r, owner, url, func(repositories []dto.RepositoryInfoResponseDTO) error {
+		return handlePage(&repositories)
+	})
+}
+
+// GetOwner tells whether owner is a user or an organization; /users/{owner} answers for both.
+func (r *RepositoryRequester) GetOwner(ctx context.Context, owner string) (*dto.OwnerResponseDTO, error) {
+	var account dto.OwnerResponseDTO
+	if _, err := r.fetchAndDecode(ctx, owner, r.endpoint("/users/%s", owner), &account); err != nil {
+		return nil, err
+	}
+	return &account, nil
+}
+
+func (r *RepositoryRequester) GetAllOrganizationRepositories(ctx context.Context, org string, handlePage RepositoryPageHandler) error {
+	//  logic to fetch all repositories of an organization, one page at a time; unlike a user's
+	//  listing, this includes the private and internal repositories the token can see
+	url := r.endpoint("/orgs/%s/repos?type=all&per_page=%d", org, r.perPage)
+	return fetchPages(ctx, r, org, url, func(repositories []dto.RepositoryInfoResponseDTO) error {
+		return handlePage(&repositories)
+	})
+}
+
+func (r *RepositoryRequester) GetRepositoryBranches(ctx context.Context, owner, repo string, handlePage BranchPageHandler) error {
+	//  logic to fetch all branches of a repository, one page at a time
+	url := r.endpoint("/repos/%s/%s/branches?per_page=%d", owner, repo, r.perPage)
+	return fetchPages(ctx, r, owner, url, func(branches []dto.BranchResponseDTO) error {
+		return handlePage(&branches)
+	})
+}
+
+func (r *RepositoryRequester) GetRepositoryTags(ctx context.Context, owner, repo string, handlePage TagPageHandler) error {
+	//  logic to fetch all tags of a repository, one page at a time
+	url := r.endpoint("/repos/%s/%s/tags?per_page=%d", owner, repo, r.perPage)
+	return fetchPages(ctx, r, owner, url, func(tags []dto.TagResponseDTO) error {
+		return handlePage(&tags)
+	})
+}
+
+func (r *RepositoryRequester) GetRepositoryReleases(ctx context.Context, owner, repo string, handlePage ReleasePageHandler) error {
+	//  logic to fetch all releases of a repository, newest first, one page at a time
+	url := r.endpoint("/repos/%s/%s/releases?per_page=%d", owner, repo, r.perPage)
+	return fetchPages(ctx, r, owner, url, func(releases []dto.ReleaseResponseDTO) error {
+		return handlePage(&releases)
+	})
+}
+
+func (r *RepositoryRequester) GetRepositoryPullRequests(ctx context.Context, owner, repo string, handlePage PullRequestPageHandler) error {
+	//  logic to fetch pull requests in every state, most recently updated first, one page at a time
+	url := r.endpoint("/repos/%s/%s/pulls?state=all&sort=updated&direction=desc&per_page=%d", owner, repo, r.perPage)
+	return fetchPages(ctx, r, owner, url, func(pullRequests []dto.PullRequestResponseDTO) error {
+		return handlePage(&pullRequests)
+	})
+}
+
+func (r *RepositoryRequester) GetRepositoryIssues(ctx context.Context, owner, repo, since string, handlePage IssuePageHandler) error {
+	//  logic to fetch issues in every state updated at or after since (all of them when empty), one page at a time
+	url := r.endpoint("/repos/%s/%s/issues?state=all&per_page=%d", owner, repo, r.perPage)
+	if since != "" {
+		url += "&since=" + neturl.QueryEscape(since)
+	}
+	return fetchPages(ctx, r, owner, url, func(issues []dto.IssueResponseDTO) error {
+		return handlePage(&issues)
+	})
+}
+
+func (r *RepositoryRequester) GetRepositoryContributors(ctx context.Context, owner, repo string, handlePage ContributorPageHandler) error {
+	//  logic to fetch the contributors of a repository with their commit counts, one page at a time
+	url := r.endpoint("/repos/%s/%s/contributors?per_page=%d", owner, repo, r.perPage)
+	return fetchPages(ctx, r, owner, url, func(contributors []dto.ContributorResponseDTO) error {
+		return handlePage(&contributors)
+	})
+}
+
+// GetContributorStats fetches the commit and line totals of every contributor of a repository.
+// GitHub computes these in the background and answers 202 Accepted until they are ready,
+// in which case utils.ErrStatsPending is returned.
+func (r *RepositoryRequester) GetContributorStats(ctx context.Context, owner, repo string) ([]dto.ContributorStatsResponseDTO, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.endpoint("/repos/%s/%s/stats/contributors", owner, repo), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.doRequest(owner, req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if err := checkResponse(resp); err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusAccepted {
+		return nil, utils.ErrStatsPending
+	}
+	var stats []dto.ContributorStatsResponseDTO
+	if err := json.NewDecoder(resp.Body).Decode(&stats); err != nil {
+		return nil, decodeError(resp, err)
+	}
+	return stats, nil
 }
 
-func (r *RepositoryRequester) GetAllUserRepositories(owner string) (*[]dto.RepositoryInfoResponseDTO, error) {
-	//  logic to fetch all repositories for a user
-	url := fmt.Sprintf("https://api.github.com/users/%s/repos", owner)
-	var repositories []dto.RepositoryInfoResponseDTO
-	if err := r.fetchAndDecode(url, &repositories); err != nil {
+func (r *RepositoryRequester) GetRepositoryLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	languages := map[string]int{}
+	if _, err := r.fetchAndDecode(ctx, owner, r.endpoint("/repos/%s/%s/languages", owner, repo), &languages); err != nil {
 		return nil, err
 	}
-	return &repositories, nil
+	return languages, nil
 }
@@ -0,0 +1,123 @@
+package requester
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// secondaryRateLimitDelay is how long GitHub asks clients to back off after a
+// secondary rate limit response that carries no Retry-After header.
+const secondaryRateLimitDelay = time.Minute
+
+// RetryPolicy decides whether and when a failed request is retried.
+type RetryPolicy struct {
+	// MaxAttempts caps the total number of attempts, including the first one.
+	MaxAttempts int
+	// BaseDelay is the backoff before the first retry; it doubles with each attempt.
+	BaseDelay time.Duration
+	// MaxDelay caps the computed backoff. Delays GitHub asks for through Retry-After are honoured as given.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy is used for any RetryPolicy field left at its zero value.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 4,
+	BaseDelay:   time.Second,
+	MaxDelay:    time.Minute,
+}
+
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.MaxAttempts <= 0 {
+		p.MaxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	if p.BaseDelay <= 0 {
+		p.BaseDelay = DefaultRetryPolicy.BaseDelay
+	}
+	if p.MaxDelay <= 0 {
+		p.MaxDelay = DefaultRetryPolicy.MaxDelay
+	}
+	return p
+}
+
+// backoff returns an exponential delay with full jitter for the given attempt, counted from 1.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.BaseDelay << (attempt - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return time.Duration(rand.Int63n(int64(delay)) + 1)
+}
+
+// shouldRetry inspects the outcome of an attempt and returns how long to wait
+// before the next one, along with a short reason for the logs.
+func (p RetryPolicy) shouldRetry(ctx context.Context, attempt int, resp *http.Response, err error) (time.Duration, string, bool) {
+	if attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return 0, "", false
+	}
+	if err != nil {
+		var urlErr *url.Error
+		// a missing recording stays missing, however often it is asked for
+		if errors.As(err, &urlErr) && !errors.Is(err, ErrNoRecording) {
+			return p.backoff(attempt), "network error", true
+		}
+		return 0, "", false
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusTooManyRequests:
+		if delay, ok := retryAfter(resp.Header); ok {
+			return delay, "too many requests", true
+		}
+		return p.backoff(attempt), "too many requests", true
+	case resp.StatusCode == http.StatusForbidden:
+		if delay, ok := retryAfter(resp.Header); ok {
+			return delay, "secondary rate limit", true
+		}
+		if resp.Header.Get("x-ratelimit-remaining") == "0" {
+			// the primary limit: the governor now knows this token is spent and
+			// will either rotate to another one or wait for the window to reset
+			return 0, "rate limit exhausted", true
+		}
+		if isSecondaryRateLimit(resp) {
+			return secondaryRateLimitDelay, "secondary rate limit", true
+		}
+	case resp.StatusCode >= http.StatusInternalServerError:
+		return p.backoff(attempt), resp.Status, true
+	}
+	return 0, "", false
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(header http.Header) (time.Duration, bool) {
+	value := header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date), true
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit peeks at a 403 body for GitHub's secondary rate limit
+// message, leaving the body readable for the caller.
+func isSecondaryRateLimit(resp *http.Response) bool {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
@@ -0,0 +1,14 @@
+package requester
+
+import "time"
+
+// utcTimestamp rewrites an RFC 3339 timestamp in UTC and to the second, the way the GitHub REST API
+// reports them; stored dates rely on that format to compare in time order. Anything unparseable is
+// returned as is.
+func utcTimestamp(timestamp string) string {
+	parsed, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return timestamp
+	}
+	return parsed.UTC().Format(time.RFC3339)
+}
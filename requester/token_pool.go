@@ -0,0 +1,69 @@
+package requester
+
+import (
+	"context"
+	"strconv"
+)
+
+// tokenPool hands out personal access tokens, preferring the one with the most
+// remaining rate-limit budget. An empty pool makes anonymous requests.
+type tokenPool struct {
+	tokens   []string
+	governor *Governor
+}
+
+func newTokenPool(tokens []string, governor *Governor) *tokenPool {
+	pool := &tokenPool{governor: governor}
+	seen := make(map[string]bool)
+	for _, token := range tokens {
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		pool.tokens = append(pool.tokens, token)
+	}
+	if len(pool.tokens) == 0 {
+		// anonymous requests share a single budget keyed by the empty token
+		pool.tokens = []string{""}
+	}
+	return pool
+}
+
+// Token returns the token with the most remaining budget; personal tokens are not tied to an owner.
+func (p *tokenPool) Token(ctx context.Context, owner string) (string, error) {
+	best := p.tokens[0]
+	bestAvailable := p.governor.available(best)
+	for _, token := range p.tokens[1:] {
+		if available := p.governor.available(token); available > bestAvailable {
+			best, bestAvailable = token, available
+		}
+	}
+	return best, nil
+}
+
+// authenticated reports whether the pool holds any personal tokens, rather than only making anonymous requests.
+func (p *tokenPool) authenticated() bool {
+	return p.tokens[0] != ""
+}
+
+func (p *tokenPool) has(token string) bool {
+	for _, t := range p.tokens {
+		if t == token {
+			return true
+		}
+	}
+	return false
+}
+
+// Label identifies a token in logs without leaking it.
+func (p *tokenPool) Label(token string) string {
+	if token == "" {
+		return "anonymous"
+	}
+	for i, t := range p.tokens {
+		if t == token {
+			return "token #" + strconv.Itoa(i+1)
+		}
+	}
+	return "unknown token"
+}
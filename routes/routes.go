@@ -7,9 +7,27 @@ import (
 
 func ConnectRoutes(r *mux.Router, controller *controllers.Controller) {
 	r.HandleFunc("/register", controller.CreateUser).Methods("POST")
+	r.HandleFunc("/users", controller.GetUsers).Methods("GET")
 	r.HandleFunc("/{owner}/repos", controller.GetRepositories).Methods("GET")
 	r.HandleFunc("/{owner}/repos/{repo}", controller.GetRepositoryInfo).Methods("GET")
+	r.HandleFunc("/{owner}/languages", controller.GetOwnerLanguages).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/languages", controller.GetRepositoryLanguages).Methods("GET")
 	r.HandleFunc("/{owner}/repos/{repo}/commits", controller.GetRepositoryCommits).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/branches", controller.GetRepositoryBranches).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/tags", controller.GetRepositoryTags).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/releases", controller.GetRepositoryReleases).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/releases/timeline", controller.GetReleaseTimeline).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/pulls", controller.GetPullRequests).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/pulls/metrics", controller.GetPullRequestMetrics).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/issues", controller.GetIssues).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/issues/stats", controller.GetIssueStatistics).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/contributors", controller.GetContributors).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/contributors/reconciliation", controller.GetContributorReconciliation).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/contributors/reconciliation", controller.CorrectAuthorCommitCounts).Methods("POST")
 	r.HandleFunc("/{owner}/repos/{repo}/commits/reset/{reset_sha}", controller.RequestRepositoryReset).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/churn", controller.GetRepositoryChurn).Methods("GET")
+	r.HandleFunc("/{owner}/repos/{repo}/files/top/{top_n}", controller.GetMostChangedFiles).Methods("GET")
 	r.HandleFunc("/authors/top/{top_n}", controller.GetTopNAuthorsByCommits).Methods("GET")
+	r.HandleFunc("/authors/top/{top_n}/churn", controller.GetTopNAuthorsByChurn).Methods("GET")
+	r.HandleFunc("/ratelimit", controller.GetRateLimitStatus).Methods("GET")
 }
@@ -0,0 +1,280 @@
+package fakegithub
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"time"
+)
+
+// epoch is when the first commit of a fixture is made, unless its date is given.
+var epoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// Commit is a commit in a fixture repository. Only Message is required; the SHA, the parent and
+// the date are filled in when a commit is made.
+type Commit struct {
+	SHA         string
+	Message     string
+	AuthorName  string
+	AuthorEmail string
+	// AuthorLogin is the account the commit is attributed to; it is anonymous when empty
+	AuthorLogin string
+	Date        time.Time
+	// the committer is the author, and the commit date its author date, unless they are set
+	CommitterName  string
+	CommitterEmail string
+	CommitterLogin string
+	CommitDate     time.Time
+	Parents        []string
+	Files          []File
+}
+
+// File is a file changed by a commit.
+type File struct {
+	Filename  string
+	Status    string
+	Additions int
+	Deletions int
+}
+
+type Release struct {
+	ID          int
+	TagName     string
+	Name        string
+	Body        string
+	Draft       bool
+	Prerelease  bool
+	Author      string
+	CreatedAt   time.Time
+	PublishedAt time.Time
+}
+
+type PullRequest struct {
+	ID             int
+	Number         int
+	Title          string
+	State          string
+	Draft          bool
+	Author         string
+	BaseRef        string
+	HeadRef        string
+	MergeCommitSHA string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	MergedAt       time.Time
+	ClosedAt       time.Time
+}
+
+type Issue struct {
+	ID        int
+	Number    int
+	Title     string
+	State     string
+	Author    string
+	Labels    []string
+	Assignees []string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ClosedAt  time.Time
+}
+
+// Repo is a repository served by the fake. Change it through its methods once the server is
+// running, as those hold the server's lock.
+type Repo struct {
+	server *Server
+
+	ID          int
+	Owner       string
+	Name        string
+	Description string
+	Language    string
+	// Languages are the bytes of code in each language, as served by /languages
+	Languages map[string]int
+	Fork      bool
+	Archived  bool
+	Topics    []string
+	// License is an SPDX identifier; the repository has no license when it is empty
+	License string
+	// Private repositories are only served to requests made with a token
+	Private       bool
+	Stars         int
+	Forks         int
+	Size          int
+	DefaultBranch string
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	// PushedAt is UpdatedAt unless it is set
+	PushedAt time.Time
+	// StatsPending is how many more times /stats/contributors answers 202 Accepted, as
+	// GitHub does while it computes the statistics, before it answers with them.
+	StatsPending int
+
+	branches     map[string]string
+	tags         map[string]string
+	commits      map[string]*Commit
+	order        map[string]int
+	releases     []*Release
+	pullRequests []*PullRequest
+	issues       []*Issue
+}
+
+func newRepo(server *Server, id int, owner, name string) *Repo {
+	return &Repo{
+		server:        server,
+		ID:            id,
+		Owner:         owner,
+		Name:          name,
+		DefaultBranch: "main",
+		CreatedAt:     epoch,
+		UpdatedAt:     epoch,
+		branches:      make(map[string]string),
+		tags:          make(map[string]string),
+		commits:       make(map[string]*Commit),
+		order:         make(map[string]int),
+	}
+}
+
+// resolve returns the commit a branch, a tag or a SHA points at.
+func (r *Repo) resolve(ref string) (*Commit, bool) {
+	if sha, ok := r.branches[ref]; ok {
+		ref = sha
+	} else if sha, ok := r.tags[ref]; ok {
+		ref = sha
+	}
+	commit, ok := r.commits[ref]
+	return commit, ok
+}
+
+// Commit makes commit on branch, creating the branch if needed, and returns it with its SHA,
+// parent and date filled in. Commits without a date are made an hour after the previous one.
+func (r *Repo) Commit(branch string, commit Commit) *Commit {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	if head, ok := r.branches[branch]; ok && commit.Parents == nil {
+		commit.Parents = []string{head}
+	}
+	if commit.Date.IsZero() {
+		commit.Date = r.UpdatedAt.Add(time.Hour)
+		if len(r.commits) == 0 {
+			commit.Date = epoch
+		}
+	}
+	if commit.AuthorName == "" {
+		commit.AuthorName = commit.AuthorLogin
+	}
+	if commit.SHA == "" {
+		sum := sha1.Sum([]byte(fmt.Sprintf("%s/%s %v %s %s %d", r.Owner, r.Name, commit.Parents, commit.Message, commit.AuthorName, len(r.commits))))
+		commit.SHA = hex.EncodeToString(sum[:])
+	}
+	stored := commit
+	r.commits[stored.SHA] = &stored
+	r.order[stored.SHA] = len(r.order)
+	r.branches[branch] = stored.SHA
+	if stored.Date.After(r.UpdatedAt) {
+		r.UpdatedAt = stored.Date
+	}
+	return &stored
+}
+
+// Branch creates or moves branch to the commit ref points at.
+func (r *Repo) Branch(branch, ref string) {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	if commit, ok := r.resolve(ref); ok {
+		r.branches[branch] = commit.SHA
+	}
+}
+
+// DeleteBranch removes branch; its commits stay reachable through the other refs pointing at them.
+func (r *Repo) DeleteBranch(branch string) {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	delete(r.branches, branch)
+}
+
+// Tag creates a tag at the commit ref points at.
+func (r *Repo) Tag(tag, ref string) {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	if commit, ok := r.resolve(ref); ok {
+		r.tags[tag] = commit.SHA
+	}
+}
+
+// AddRelease publishes release, giving it an ID.
+func (r *Repo) AddRelease(release Release) *Release {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	release.ID = r.server.newID()
+	if release.CreatedAt.IsZero() {
+		release.CreatedAt = r.UpdatedAt
+	}
+	if release.PublishedAt.IsZero() && !release.Draft {
+		release.PublishedAt = release.CreatedAt
+	}
+	r.releases = append(r.releases, &release)
+	return &release
+}
+
+// AddPullRequest opens pullRequest, giving it an ID and the next issue number.
+func (r *Repo) AddPullRequest(pullRequest PullRequest) *PullRequest {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	pullRequest.ID = r.server.newID()
+	pullRequest.Number = r.nextNumber()
+	if pullRequest.State == "" {
+		pullRequest.State = "open"
+	}
+	if pullRequest.CreatedAt.IsZero() {
+		pullRequest.CreatedAt = r.UpdatedAt
+	}
+	if pullRequest.UpdatedAt.IsZero() {
+		pullRequest.UpdatedAt = pullRequest.CreatedAt
+	}
+	r.pullRequests = append(r.pullRequests, &pullRequest)
+	return &pullRequest
+}
+
+// AddIssue opens issue, giving it an ID and the next issue number.
+func (r *Repo) AddIssue(issue Issue) *Issue {
+	r.server.mu.Lock()
+	defer r.server.mu.Unlock()
+	issue.ID = r.server.newID()
+	issue.Number = r.nextNumber()
+	if issue.State == "" {
+		issue.State = "open"
+	}
+	if issue.CreatedAt.IsZero() {
+		issue.CreatedAt = r.UpdatedAt
+	}
+	if issue.UpdatedAt.IsZero() {
+		issue.UpdatedAt = issue.CreatedAt
+	}
+	r.issues = append(r.issues, &issue)
+	return &issue
+}
+
+// nextNumber numbers pull requests and issues from one sequence, as GitHub does.
+func (r *Repo) nextNumber() int {
+	return len(r.pullRequests) + len(r.issues) + 1
+}
+
+// history lists the commits reachable from head, newest first.
+func (r *Repo) history(head string) []*Commit {
+	seen := map[string]bool{}
+	commits := []*Commit{}
+	pending := []string{head}
+	for len(pending) > 0 {
+		sha := pending[0]
+		pending = pending[1:]
+		commit, ok := r.commits[sha]
+		if !ok || seen[sha] {
+			continue
+		}
+		seen[sha] = true
+		commits = append(commits, commit)
+		pending = append(pending, commit.Parents...)
+	}
+	sortNewestFirst(commits, func(c *Commit) time.Time { return c.Date }, func(c *Commit) int { return r.order[c.SHA] })
+	return commits
+}
@@ -0,0 +1,813 @@
+// Package fakegithub is an in-process stand-in for the parts of the GitHub REST API this service
+// uses. It serves repositories, commits, refs, releases, pull requests, issues, contributors and
+// languages from fixtures built in Go, with GitHub's pagination Link headers, rate-limit headers, ETags and
+// error bodies, so requesters, discovery services and the task manager can be exercised end to
+// end against a real HTTP server.
+package fakegithub
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPerPage = 30
+	maxPerPage     = 100
+	// DefaultRateLimit is the hourly budget of every token until SetRateLimit changes it.
+	DefaultRateLimit = 5000
+	documentationURL = "https://docs.github.com/rest"
+)
+
+type failure struct {
+	status int
+	times  int
+}
+
+// Server is a fake GitHub API listening on a local address. Point a requester's BaseURL at its URL.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	lastID   int
+	users    map[string]int
+	orgs     map[string]bool
+	repos    map[string]*Repo
+	limit    int
+	reset    time.Time
+	used     map[string]int
+	failures map[string]*failure
+	requests []string
+}
+
+// NewServer starts a fake GitHub with no users or repositories. Close it when done.
+func NewServer() *Server {
+	s := &Server{
+		users:    make(map[string]int),
+		orgs:     make(map[string]bool),
+		repos:    make(map[string]*Repo),
+		limit:    DefaultRateLimit,
+		reset:    time.Now().Add(time.Hour).Truncate(time.Second),
+		used:     make(map[string]int),
+		failures: make(map[string]*failure),
+	}
+
+	mux := http.NewServeMux()
+	s.handle(mux, "GET /users/{owner}", s.getUser)
+	s.handle(mux, "GET /users/{owner}/repos", s.listUserRepos)
+	s.handle(mux, "GET /orgs/{owner}/repos", s.listOrgRepos)
+	s.handle(mux, "GET /repos/{owner}/{repo}", s.getRepo)
+	s.handle(mux, "GET /repos/{owner}/{repo}/commits", s.listCommits)
+	s.handle(mux, "GET /repos/{owner}/{repo}/commits/{sha}", s.getCommit)
+	s.handle(mux, "GET /repos/{owner}/{repo}/branches", s.listBranches)
+	s.handle(mux, "GET /repos/{owner}/{repo}/tags", s.listTags)
+	s.handle(mux, "GET /repos/{owner}/{repo}/releases", s.listReleases)
+	s.handle(mux, "GET /repos/{owner}/{repo}/pulls", s.listPullRequests)
+	s.handle(mux, "GET /repos/{owner}/{repo}/issues", s.listIssues)
+	s.handle(mux, "GET /repos/{owner}/{repo}/contributors", s.listContributors)
+	s.handle(mux, "GET /repos/{owner}/{repo}/stats/contributors", s.getContributorStats)
+	s.handle(mux, "GET /repos/{owner}/{repo}/languages", s.getLanguages)
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		s.record(r)
+		writeJSON(w, http.StatusNotFound, notFound)
+	})
+	s.Server = httptest.NewServer(mux)
+	return s
+}
+
+// newID hands out IDs for repositories, users, releases, pull requests and issues. The caller holds s.mu.
+func (s *Server) newID() int {
+	s.lastID++
+	return s.lastID
+}
+
+// userID returns the ID of login, registering it on first sight. The caller holds s.mu.
+func (s *Server) userID(login string) int {
+	id, ok := s.users[login]
+	if !ok {
+		id = s.newID()
+		s.users[login] = id
+	}
+	return id
+}
+
+// AddUser registers a GitHub account so /users/{login}/repos answers for it even without repositories.
+func (s *Server) AddUser(login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.userID(login)
+}
+
+// AddOrg registers an organization; repositories added under its login belong to it.
+func (s *Server) AddOrg(login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.userID(login)
+	s.orgs[login] = true
+}
+
+// AddRepo creates an empty repository owned by owner, registering the owner if needed.
+// Its default branch is main.
+func (s *Server) AddRepo(owner, name string) *Repo {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.userID(owner)
+	repo := newRepo(s, s.newID(), owner, name)
+	s.repos[owner+"/"+name] = repo
+	return repo
+}
+
+// SetRateLimit gives every token limit requests until reset, forgetting what was used so far.
+func (s *Server) SetRateLimit(limit int, reset time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit = limit
+	s.reset = reset.Truncate(time.Second)
+	s.used = make(map[string]int)
+}
+
+// Remaining reports how many requests token has left; the empty token is the anonymous client.
+func (s *Server) Remaining(token string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.remaining(token)
+}
+
+func (s *Server) remaining(token string) int {
+	if remaining := s.limit - s.used[token]; remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// FailNext makes the next times requests for path, such as /repos/octocat/hello/commits,
+// answer status with GitHub's error body for it instead of their usual response.
+func (s *Server) FailNext(path string, status, times int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures[path] = &failure{status: status, times: times}
+}
+
+// Requests lists every request received so far as "GET /path?query", in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+func (s *Server) record(r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+}
+
+// reply is what a route answers, before rate limiting, pagination and ETags are applied.
+// A list is paginated; anything else in body is sent as is.
+type reply struct {
+	status int
+	body   interface{}
+	list   []interface{}
+	isList bool
+}
+
+func ok(body interface{}) reply { return reply{status: http.StatusOK, body: body} }
+
+func list(items []interface{}) reply { return reply{status: http.StatusOK, list: items, isList: true} }
+
+func fail(status int, message string) reply {
+	return reply{status: status, body: map[string]string{"message": message, "documentation_url": documentationURL}}
+}
+
+var notFound = map[string]string{"message": "Not Found", "documentation_url": documentationURL}
+
+// handle registers route under pattern. Routes read the fixtures under s.mu and get the
+// repository named in the path, if any; a missing one is answered with a 404 before they run.
+func (s *Server) handle(mux *http.ServeMux, pattern string, route func(r *http.Request, repo *Repo) reply) {
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.Method+" "+r.URL.RequestURI())
+		token := tokenOf(r)
+		if s.remaining(token) == 0 {
+			s.writeRateLimit(w.Header(), token)
+			s.mu.Unlock()
+			writeJSON(w, http.StatusForbidden, map[string]string{
+				"message":           "API rate limit exceeded. Check out the documentation for more details.",
+				"documentation_url": documentationURL,
+			})
+			return
+		}
+		if f, ok := s.failures[r.URL.Path]; ok && f.times > 0 {
+			f.times--
+			s.used[token]++
+			s.writeRateLimit(w.Header(), token)
+			s.mu.Unlock()
+			writeJSON(w, f.status, map[string]string{"message": http.StatusText(f.status), "documentation_url": documentationURL})
+			return
+		}
+
+		var result reply
+		repo, found := s.repos[r.PathValue("owner")+"/"+r.PathValue("repo")]
+		// private repositories are hidden from anonymous clients, as if they did not exist
+		if r.PathValue("repo") != "" && (!found || (repo.Private && token == "")) {
+			result = reply{status: http.StatusNotFound, body: notFound}
+		} else {
+			result = route(r, repo)
+		}
+		if result.isList {
+			result.body = s.paginate(w.Header(), r, result.list)
+		}
+		body, err := json.Marshal(result.body)
+		if err != nil {
+			s.mu.Unlock()
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		sum := sha256.Sum256(body)
+		etag := `"` + hex.EncodeToString(sum[:]) + `"`
+		if result.status == http.StatusOK {
+			w.Header().Set("ETag", etag)
+			if r.Header.Get("If-None-Match") == etag {
+				// conditional requests answered from the client's cache are free
+				s.writeRateLimit(w.Header(), token)
+				s.mu.Unlock()
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+		}
+		s.used[token]++
+		s.writeRateLimit(w.Header(), token)
+		s.mu.Unlock()
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.WriteHeader(result.status)
+		w.Write(body)
+	})
+}
+
+// tokenOf returns the token a request is authenticated with, empty for anonymous ones.
+func tokenOf(r *http.Request) string {
+	return strings.TrimPrefix(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), "token ")
+}
+
+// writeRateLimit sets GitHub's rate-limit headers for token. The caller holds s.mu.
+func (s *Server) writeRateLimit(header http.Header, token string) {
+	header.Set("x-ratelimit-limit", strconv.Itoa(s.limit))
+	header.Set("x-ratelimit-remaining", strconv.Itoa(s.remaining(token)))
+	header.Set("x-ratelimit-reset", strconv.FormatInt(s.reset.Unix(), 10))
+	header.Set("x-ratelimit-used", strconv.Itoa(s.used[token]))
+	header.Set("x-ratelimit-resource", "core")
+}
+
+// paginate returns the page of items asked for by the page and per_page parameters and sets
+// a Link header to the other pages the way GitHub does, with absolute URLs.
+func (s *Server) paginate(header http.Header, r *http.Request, items []interface{}) []interface{} {
+	query := r.URL.Query()
+	perPage, err := strconv.Atoi(query.Get("per_page"))
+	if err != nil || perPage <= 0 {
+		perPage = defaultPerPage
+	}
+	if perPage > maxPerPage {
+		perPage = maxPerPage
+	}
+	page, err := strconv.Atoi(query.Get("page"))
+	if err != nil || page <= 0 {
+		page = 1
+	}
+	last := (len(items) + perPage - 1) / perPage
+
+	link := func(page int, rel string) string {
+		query.Set("page", strconv.Itoa(page))
+		return fmt.Sprintf(`<%s%s?%s>; rel="%s"`, s.URL, r.URL.Path, query.Encode(), rel)
+	}
+	var links []string
+	if page > 1 {
+		links = append(links, link(page-1, "prev"))
+	}
+	if page < last {
+		links = append(links, link(page+1, "next"), link(last, "last"))
+	}
+	if page > 1 {
+		links = append(links, link(1, "first"))
+	}
+	if len(links) > 0 {
+		header.Set("Link", strings.Join(links, ", "))
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(items) {
+		return []interface{}{}
+	}
+	end := start + perPage
+	if end > len(items) {
+		end = len(items)
+	}
+	return items[start:end]
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// timestamp formats t the way GitHub does, with the zero time standing for null.
+func timestamp(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+// parseTimestamp reads a since or until parameter; a missing or malformed one is the zero time.
+func parseTimestamp(value string) time.Time {
+	t, _ := time.Parse(time.RFC3339, value)
+	return t
+}
+
+func sortNewestFirst[T any](items []T, at func(T) time.Time, seq func(T) int) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if !at(items[i]).Equal(at(items[j])) {
+			return at(items[i]).After(at(items[j]))
+		}
+		return seq(items[i]) > seq(items[j])
+	})
+}
+
+func (s *Server) account(login string) interface{} {
+	if login == "" {
+		return nil
+	}
+	accountType := "User"
+	if s.orgs[login] {
+		accountType = "Organization"
+	}
+	return map[string]interface{}{
+		"login":    login,
+		"id":       s.userID(login),
+		"type":     accountType,
+		"html_url": s.URL + "/" + login,
+	}
+}
+
+func (s *Server) repoJSON(repo *Repo) map[string]interface{} {
+	openIssues := 0
+	for _, issue := range repo.issues {
+		if issue.State == "open" {
+			openIssues++
+		}
+	}
+	for _, pullRequest := range repo.pullRequests {
+		if pullRequest.State == "open" {
+			openIssues++
+		}
+	}
+	fullName := repo.Owner + "/" + repo.Name
+	visibility := "public"
+	if repo.Private {
+		visibility = "private"
+	}
+	pushedAt := repo.PushedAt
+	if pushedAt.IsZero() {
+		pushedAt = repo.UpdatedAt
+	}
+	topics := repo.Topics
+	if topics == nil {
+		topics = []string{}
+	}
+	var license interface{}
+	if repo.License != "" {
+		license = map[string]string{"key": strings.ToLower(repo.License), "name": repo.License, "spdx_id": repo.License}
+	}
+	return map[string]interface{}{
+		"id":                repo.ID,
+		"name":              repo.Name,
+		"full_name":         fullName,
+		"owner":             s.account(repo.Owner),
+		"html_url":          s.URL + "/" + fullName,
+		"description":       repo.Description,
+		"url":               s.URL + "/repos/" + fullName,
+		"private":           repo.Private,
+		"visibility":        visibility,
+		"fork":              repo.Fork,
+		"archived":          repo.Archived,
+		"topics":            topics,
+		"license":           license,
+		"size":              repo.Size,
+		"language":          repo.Language,
+		"forks_count":       repo.Forks,
+		"stargazers_count":  repo.Stars,
+		"watchers_count":    repo.Stars,
+		"open_issues_count": openIssues,
+		"default_branch":    repo.DefaultBranch,
+		"created_at":        timestamp(repo.CreatedAt),
+		"updated_at":        timestamp(repo.UpdatedAt),
+		"pushed_at":         timestamp(pushedAt),
+	}
+}
+
+func (s *Server) commitJSON(repo *Repo, commit *Commit) map[string]interface{} {
+	parents := make([]interface{}, 0, len(commit.Parents))
+	for _, parent := range commit.Parents {
+		parents = append(parents, map[string]string{"sha": parent})
+	}
+	committer := *commit
+	if committer.CommitterName == "" {
+		committer.CommitterName, committer.CommitterEmail, committer.CommitterLogin = commit.AuthorName, commit.AuthorEmail, commit.AuthorLogin
+	}
+	if committer.CommitDate.IsZero() {
+		committer.CommitDate = commit.Date
+	}
+	return map[string]interface{}{
+		"sha": commit.SHA,
+		"commit": map[string]interface{}{
+			"message":   commit.Message,
+			"author":    map[string]interface{}{"name": commit.AuthorName, "email": commit.AuthorEmail, "date": timestamp(commit.Date)},
+			"committer": map[string]interface{}{"name": committer.CommitterName, "email": committer.CommitterEmail, "date": timestamp(committer.CommitDate)},
+		},
+		"author":    s.account(commit.AuthorLogin),
+		"committer": s.account(committer.CommitterLogin),
+		"parents":   parents,
+		"html_url":  s.URL + "/" + repo.Owner + "/" + repo.Name + "/commit/" + commit.SHA,
+	}
+}
+
+func (s *Server) getUser(r *http.Request, _ *Repo) reply {
+	login := r.PathValue("owner")
+	if _, ok := s.users[login]; !ok {
+		return reply{status: http.StatusNotFound, body: notFound}
+	}
+	return ok(s.account(login))
+}
+
+func (s *Server) listUserRepos(r *http.Request, _ *Repo) reply {
+	owner := r.PathValue("owner")
+	if _, ok := s.users[owner]; !ok {
+		return reply{status: http.StatusNotFound, body: notFound}
+	}
+	// this listing only ever shows public repositories, whoever asks
+	return list(s.ownedRepos(owner, false))
+}
+
+// listOrgRepos lists an organization's repositories, including the private ones to authenticated clients.
+func (s *Server) listOrgRepos(r *http.Request, _ *Repo) reply {
+	org := r.PathValue("owner")
+	if !s.orgs[org] {
+		return reply{status: http.StatusNotFound, body: notFound}
+	}
+	return list(s.ownedRepos(org, tokenOf(r) != ""))
+}
+
+// ownedRepos lists the repositories of owner by name, as GitHub does unless told otherwise.
+func (s *Server) ownedRepos(owner string, withPrivate bool) []interface{} {
+	var repos []*Repo
+	for _, repo := range s.repos {
+		if repo.Owner == owner && (withPrivate || !repo.Private) {
+			repos = append(repos, repo)
+		}
+	}
+	sort.Slice(repos, func(i, j int) bool { return repos[i].Name < repos[j].Name })
+	items := make([]interface{}, 0, len(repos))
+	for _, repo := range repos {
+		items = append(items, s.repoJSON(repo))
+	}
+	return items
+}
+
+func (s *Server) getRepo(_ *http.Request, repo *Repo) reply {
+	return ok(s.repoJSON(repo))
+}
+
+func (s *Server) listCommits(r *http.Request, repo *Repo) reply {
+	if len(repo.commits) == 0 {
+		return fail(http.StatusConflict, "Git Repository is empty.")
+	}
+	query := r.URL.Query()
+	ref := query.Get("sha")
+	if ref == "" {
+		ref = repo.DefaultBranch
+	}
+	head, found := repo.resolve(ref)
+	if !found {
+		return fail(http.StatusNotFound, "No commit found for SHA: "+ref)
+	}
+	since, until := parseTimestamp(query.Get("since")), parseTimestamp(query.Get("until"))
+	items := []interface{}{}
+	for _, commit := range repo.history(head.SHA) {
+		if (!since.IsZero() && commit.Date.Before(since)) || (!until.IsZero() && commit.Date.After(until)) {
+			continue
+		}
+		items = append(items, s.commitJSON(repo, commit))
+	}
+	return list(items)
+}
+
+func (s *Server) getCommit(r *http.Request, repo *Repo) reply {
+	commit, found := repo.resolve(r.PathValue("sha"))
+	if !found {
+		return fail(http.StatusUnprocessableEntity, "No commit found for SHA: "+r.PathValue("sha"))
+	}
+	body := s.commitJSON(repo, commit)
+	additions, deletions := 0, 0
+	files := make([]interface{}, 0, len(commit.Files))
+	for _, file := range commit.Files {
+		status := file.Status
+		if status == "" {
+			status = "modified"
+		}
+		additions += file.Additions
+		deletions += file.Deletions
+		files = append(files, map[string]interface{}{
+			"filename":  file.Filename,
+			"status":    status,
+			"additions": file.Additions,
+			"deletions": file.Deletions,
+			"changes":   file.Additions + file.Deletions,
+		})
+	}
+	body["stats"] = map[string]int{"additions": additions, "deletions": deletions, "total": additions + deletions}
+	body["files"] = files
+	return ok(body)
+}
+
+// refs lists names and the SHAs they point at, sorted by name.
+func (s *Server) refs(repo *Repo, refs map[string]string, descending bool) []interface{} {
+	names := make([]string, 0, len(refs))
+	for name := range refs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if descending {
+		sort.Sort(sort.Reverse(sort.StringSlice(names)))
+	}
+	items := make([]interface{}, 0, len(names))
+	for _, name := range names {
+		items = append(items, map[string]interface{}{
+			"name": name,
+			"commit": map[string]string{
+				"sha": refs[name],
+				"url": s.URL + "/repos/" + repo.Owner + "/" + repo.Name + "/commits/" + refs[name],
+			},
+			"protected": false,
+		})
+	}
+	return items
+}
+
+func (s *Server) listBranches(_ *http.Request, repo *Repo) reply {
+	return list(s.refs(repo, repo.branches, false))
+}
+
+func (s *Server) listTags(_ *http.Request, repo *Repo) reply {
+	// GitHub lists the most recent looking tags first
+	return list(s.refs(repo, repo.tags, true))
+}
+
+func (s *Server) listReleases(_ *http.Request, repo *Repo) reply {
+	releases := append([]*Release(nil), repo.releases...)
+	sortNewestFirst(releases, func(r *Release) time.Time { return r.CreatedAt }, func(r *Release) int { return r.ID })
+	items := make([]interface{}, 0, len(releases))
+	for _, release := range releases {
+		items = append(items, map[string]interface{}{
+			"id":           release.ID,
+			"tag_name":     release.TagName,
+			"name":         release.Name,
+			"body":         release.Body,
+			"draft":        release.Draft,
+			"prerelease":   release.Prerelease,
+			"author":       s.account(release.Author),
+			"created_at":   timestamp(release.CreatedAt),
+			"published_at": timestamp(release.PublishedAt),
+			"html_url":     s.URL + "/" + repo.Owner + "/" + repo.Name + "/releases/tag/" + url.PathEscape(release.TagName),
+		})
+	}
+	return list(items)
+}
+
+// matchesState filters on the state parameter of the pull request and issue listings, which defaults to open.
+func matchesState(query url.Values, state string) bool {
+	switch query.Get("state") {
+	case "all":
+		return true
+	case "closed":
+		return state == "closed"
+	default:
+		return state == "open"
+	}
+}
+
+// sortByQuery orders items by the sort and direction parameters, newest created first by default.
+func sortByQuery[T any](items []T, query url.Values, created, updated func(T) time.Time, seq func(T) int) {
+	at := created
+	if query.Get("sort") == "updated" {
+		at = updated
+	}
+	sortNewestFirst(items, at, seq)
+	if query.Get("direction") == "asc" {
+		for i, j := 0, len(items)-1; i < j; i, j = i+1, j-1 {
+			items[i], items[j] = items[j], items[i]
+		}
+	}
+}
+
+func (s *Server) listPullRequests(r *http.Request, repo *Repo) reply {
+	query := r.URL.Query()
+	var pullRequests []*PullRequest
+	for _, pullRequest := range repo.pullRequests {
+		if matchesState(query, pullRequest.State) {
+			pullRequests = append(pullRequests, pullRequest)
+		}
+	}
+	sortByQuery(pullRequests, query,
+		func(p *PullRequest) time.Time { return p.CreatedAt },
+		func(p *PullRequest) time.Time { return p.UpdatedAt },
+		func(p *PullRequest) int { return p.Number })
+	items := make([]interface{}, 0, len(pullRequests))
+	for _, pullRequest := range pullRequests {
+		var mergeCommitSHA interface{}
+		if pullRequest.MergeCommitSHA != "" {
+			mergeCommitSHA = pullRequest.MergeCommitSHA
+		}
+		items = append(items, map[string]interface{}{
+			"id":               pullRequest.ID,
+			"number":           pullRequest.Number,
+			"title":            pullRequest.Title,
+			"state":            pullRequest.State,
+			"draft":            pullRequest.Draft,
+			"user":             s.account(pullRequest.Author),
+			"created_at":       timestamp(pullRequest.CreatedAt),
+			"updated_at":       timestamp(pullRequest.UpdatedAt),
+			"merged_at":        timestamp(pullRequest.MergedAt),
+			"closed_at":        timestamp(pullRequest.ClosedAt),
+			"base":             map[string]string{"ref": pullRequest.BaseRef},
+			"head":             map[string]string{"ref": pullRequest.HeadRef},
+			"merge_commit_sha": mergeCommitSHA,
+			"html_url":         fmt.Sprintf("%s/%s/%s/pull/%d", s.URL, repo.Owner, repo.Name, pullRequest.Number),
+		})
+	}
+	return list(items)
+}
+
+// listIssues answers with issues and, as GitHub does, pull requests, which carry a pull_request key.
+func (s *Server) listIssues(r *http.Request, repo *Repo) reply {
+	query := r.URL.Query()
+	since := parseTimestamp(query.Get("since"))
+	issues := append([]*Issue(nil), repo.issues...)
+	pullRequests := map[int]bool{}
+	for _, pullRequest := range repo.pullRequests {
+		pullRequests[pullRequest.Number] = true
+		issues = append(issues, &Issue{
+			ID:        pullRequest.ID,
+			Number:    pullRequest.Number,
+			Title:     pullRequest.Title,
+			State:     pullRequest.State,
+			Author:    pullRequest.Author,
+			CreatedAt: pullRequest.CreatedAt,
+			UpdatedAt: pullRequest.UpdatedAt,
+			ClosedAt:  pullRequest.ClosedAt,
+		})
+	}
+	var matching []*Issue
+	for _, issue := range issues {
+		if matchesState(query, issue.State) && (since.IsZero() || !issue.UpdatedAt.Before(since)) {
+			matching = append(matching, issue)
+		}
+	}
+	sortByQuery(matching, query,
+		func(i *Issue) time.Time { return i.CreatedAt },
+		func(i *Issue) time.Time { return i.UpdatedAt },
+		func(i *Issue) int { return i.Number })
+
+	items := make([]interface{}, 0, len(matching))
+	for _, issue := range matching {
+		labels := make([]interface{}, 0, len(issue.Labels))
+		for _, label := range issue.Labels {
+			labels = append(labels, map[string]string{"name": label})
+		}
+		assignees := make([]interface{}, 0, len(issue.Assignees))
+		for _, assignee := range issue.Assignees {
+			assignees = append(assignees, s.account(assignee))
+		}
+		htmlURL := fmt.Sprintf("%s/%s/%s/issues/%d", s.URL, repo.Owner, repo.Name, issue.Number)
+		item := map[string]interface{}{
+			"id":         issue.ID,
+			"number":     issue.Number,
+			"title":      issue.Title,
+			"state":      issue.State,
+			"labels":     labels,
+			"user":       s.account(issue.Author),
+			"assignees":  assignees,
+			"created_at": timestamp(issue.CreatedAt),
+			"updated_at": timestamp(issue.UpdatedAt),
+			"closed_at":  timestamp(issue.ClosedAt),
+			"html_url":   htmlURL,
+		}
+		if pullRequests[issue.Number] {
+			item["html_url"] = fmt.Sprintf("%s/%s/%s/pull/%d", s.URL, repo.Owner, repo.Name, issue.Number)
+			item["pull_request"] = map[string]string{"html_url": item["html_url"].(string)}
+		}
+		items = append(items, item)
+	}
+	return list(items)
+}
+
+// contributions counts the commits on the default branch of every account they are attributed to.
+func contributions(repo *Repo) map[string][]*Commit {
+	byLogin := map[string][]*Commit{}
+	if head, ok := repo.branches[repo.DefaultBranch]; ok {
+		for _, commit := range repo.history(head) {
+			if commit.AuthorLogin != "" {
+				byLogin[commit.AuthorLogin] = append(byLogin[commit.AuthorLogin], commit)
+			}
+		}
+	}
+	return byLogin
+}
+
+func (s *Server) listContributors(_ *http.Request, repo *Repo) reply {
+	byLogin := contributions(repo)
+	logins := make([]string, 0, len(byLogin))
+	for login := range byLogin {
+		logins = append(logins, login)
+	}
+	sort.Slice(logins, func(i, j int) bool {
+		if len(byLogin[logins[i]]) != len(byLogin[logins[j]]) {
+			return len(byLogin[logins[i]]) > len(byLogin[logins[j]])
+		}
+		return logins[i] < logins[j]
+	})
+	items := make([]interface{}, 0, len(logins))
+	for _, login := range logins {
+		items = append(items, map[string]interface{}{
+			"login":         login,
+			"id":            s.userID(login),
+			"type":          "User",
+			"contributions": len(byLogin[login]),
+		})
+	}
+	return list(items)
+}
+
+// getContributorStats answers 202 Accepted while the repository's StatsPending runs down, then
+// the weekly additions and deletions of every contributor, least active first as GitHub sorts them.
+func (s *Server) getLanguages(_ *http.Request, repo *Repo) reply {
+	languages := map[string]int{}
+	for language, bytes := range repo.Languages {
+		languages[language] = bytes
+	}
+	return ok(languages)
+}
+
+func (s *Server) getContributorStats(_ *http.Request, repo *Repo) reply {
+	if repo.StatsPending > 0 {
+		repo.StatsPending--
+		return reply{status: http.StatusAccepted, body: map[string]interface{}{}}
+	}
+	type week struct {
+		Start     int64 `json:"w"`
+		Additions int   `json:"a"`
+		Deletions int   `json:"d"`
+		Commits   int   `json:"c"`
+	}
+	type contributorStats struct {
+		Total  int         `json:"total"`
+		Author interface{} `json:"author"`
+		Weeks  []*week     `json:"weeks"`
+		login  string
+	}
+	stats := []contributorStats{}
+	for login, commits := range contributions(repo) {
+		byStart := map[int64]*week{}
+		var weeks []*week
+		for _, commit := range commits {
+			day := commit.Date.UTC().Truncate(24 * time.Hour)
+			start := day.AddDate(0, 0, -int(day.Weekday())).Unix()
+			if byStart[start] == nil {
+				byStart[start] = &week{Start: start}
+				weeks = append(weeks, byStart[start])
+			}
+			byStart[start].Commits++
+			for _, file := range commit.Files {
+				byStart[start].Additions += file.Additions
+				byStart[start].Deletions += file.Deletions
+			}
+		}
+		sort.Slice(weeks, func(i, j int) bool { return weeks[i].Start < weeks[j].Start })
+		stats = append(stats, contributorStats{Total: len(commits), Author: s.account(login), Weeks: weeks, login: login})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Total != stats[j].Total {
+			return stats[i].Total < stats[j].Total
+		}
+		return stats[i].login < stats[j].login
+	})
+	return ok(stats)
+}
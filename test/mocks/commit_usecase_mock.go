@@ -9,8 +9,8 @@ type MockCommitUseCase struct {
 	mock.Mock
 }
 
-func (m *MockCommitUseCase) GetRepositoryCommits(repoName string) ([]*entity.Commit, error) {
-	args := m.Called(repoName)
+func (m *MockCommitUseCase) GetRepositoryCommits(owner, repoName string) ([]*entity.Commit, error) {
+	args := m.Called(owner, repoName)
 	var commits []*entity.Commit
 	if args.Get(0) != nil {
 		commits = args.Get(0).([]*entity.Commit)
@@ -31,3 +31,39 @@ func (m *MockCommitUseCase) GetTopNAuthorsByCommits(topN int) ([]*entity.AuthorC
 	}
 	return authorCounts, args.Error(1)
 }
+
+func (m *MockCommitUseCase) GetTopNAuthorsByChurn(topN int) ([]*entity.AuthorChurn, error) {
+	args := m.Called(topN)
+	var authorChurn []*entity.AuthorChurn
+	if args.Get(0) != nil {
+		authorChurn = args.Get(0).([]*entity.AuthorChurn)
+	}
+	return authorChurn, args.Error(1)
+}
+
+func (m *MockCommitUseCase) GetRepositoryChurn(owner, repoName string) (*entity.RepositoryChurn, error) {
+	args := m.Called(owner, repoName)
+	var repoChurn *entity.RepositoryChurn
+	if args.Get(0) != nil {
+		repoChurn = args.Get(0).(*entity.RepositoryChurn)
+	}
+	return repoChurn, args.Error(1)
+}
+
+func (m *MockCommitUseCase) GetMostChangedFiles(owner, repoName string, topN int) ([]*entity.FileChurn, error) {
+	args := m.Called(owner, repoName, topN)
+	var files []*entity.FileChurn
+	if args.Get(0) != nil {
+		files = args.Get(0).([]*entity.FileChurn)
+	}
+	return files, args.Error(1)
+}
+
+func (m *MockCommitUseCase) GetBranchCommits(owner, repoName, branchName string) ([]*entity.Commit, error) {
+	args := m.Called(owner, repoName, branchName)
+	var commits []*entity.Commit
+	if args.Get(0) != nil {
+		commits = args.Get(0).([]*entity.Commit)
+	}
+	return commits, args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockContributorUseCase struct {
+	mock.Mock
+}
+
+func (m *MockContributorUseCase) GetContributors(owner, repoName string) ([]*entity.Contributor, error) {
+	args := m.Called(owner, repoName)
+	var contributors []*entity.Contributor
+	if args.Get(0) != nil {
+		contributors = args.Get(0).([]*entity.Contributor)
+	}
+	return contributors, args.Error(1)
+}
+
+func (m *MockContributorUseCase) ReconcileAuthorCommitCounts(owner, repoName string, correct bool) (*entity.ContributorReconciliation, error) {
+	args := m.Called(owner, repoName, correct)
+	var reconciliation *entity.ContributorReconciliation
+	if args.Get(0) != nil {
+		reconciliation = args.Get(0).(*entity.ContributorReconciliation)
+	}
+	return reconciliation, args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"context"
+	"sync"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockRepositoryDiscovery struct {
+	mock.Mock
+}
+
+func (m *MockRepositoryDiscovery) GetAllUserRepositories(ctx context.Context, user *entity.User) {
+	m.Called(ctx, user)
+}
+
+func (m *MockRepositoryDiscovery) FetchNewlyRequestedRepo(ctx context.Context, repoRequest *dto.RepoRequest, wg *sync.WaitGroup) error {
+	args := m.Called(ctx, repoRequest, wg)
+	return args.Error(0)
+}
+
+func (m *MockRepositoryDiscovery) CheckForUpdateOnAllRepo(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
@@ -0,0 +1,29 @@
+package mocks
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockIssueUseCase struct {
+	mock.Mock
+}
+
+func (m *MockIssueUseCase) GetIssues(owner, repoName string, issueSearchParams *utils.IssueSearchParams) ([]*entity.Issue, error) {
+	args := m.Called(owner, repoName, issueSearchParams)
+	var issues []*entity.Issue
+	if args.Get(0) != nil {
+		issues = args.Get(0).([]*entity.Issue)
+	}
+	return issues, args.Error(1)
+}
+
+func (m *MockIssueUseCase) GetIssueStatistics(owner, repoName string) (*entity.IssueStatistics, error) {
+	args := m.Called(owner, repoName)
+	var statistics *entity.IssueStatistics
+	if args.Get(0) != nil {
+		statistics = args.Get(0).(*entity.IssueStatistics)
+	}
+	return statistics, args.Error(1)
+}
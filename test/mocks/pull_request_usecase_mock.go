@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPullRequestUseCase struct {
+	mock.Mock
+}
+
+func (m *MockPullRequestUseCase) GetPullRequests(owner, repoName, state string) ([]*entity.PullRequest, error) {
+	args := m.Called(owner, repoName, state)
+	var pullRequests []*entity.PullRequest
+	if args.Get(0) != nil {
+		pullRequests = args.Get(0).([]*entity.PullRequest)
+	}
+	return pullRequests, args.Error(1)
+}
+
+func (m *MockPullRequestUseCase) GetPullRequestMetrics(owner, repoName string) (*entity.RepositoryPullRequestMetrics, error) {
+	args := m.Called(owner, repoName)
+	var metrics *entity.RepositoryPullRequestMetrics
+	if args.Get(0) != nil {
+		metrics = args.Get(0).(*entity.RepositoryPullRequestMetrics)
+	}
+	return metrics, args.Error(1)
+}
@@ -0,0 +1,28 @@
+package mocks
+
+import (
+	"github.com/midedickson/github-service/entity"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockReleaseUseCase struct {
+	mock.Mock
+}
+
+func (m *MockReleaseUseCase) GetRepositoryReleases(owner, repoName string) ([]*entity.Release, error) {
+	args := m.Called(owner, repoName)
+	var releases []*entity.Release
+	if args.Get(0) != nil {
+		releases = args.Get(0).([]*entity.Release)
+	}
+	return releases, args.Error(1)
+}
+
+func (m *MockReleaseUseCase) GetReleaseTimeline(owner, repoName string) ([]*entity.ReleaseTimelineEntry, error) {
+	args := m.Called(owner, repoName)
+	var timeline []*entity.ReleaseTimelineEntry
+	if args.Get(0) != nil {
+		timeline = args.Get(0).([]*entity.ReleaseTimelineEntry)
+	}
+	return timeline, args.Error(1)
+}
@@ -24,6 +24,16 @@ func (m *MockDBRepository) GetUser(username string) (*database.User, error) {
 	return args.Get(0).(*database.User), args.Error(1)
 }
 
+func (m *MockDBRepository) GetUsers(ownerType string) ([]*database.User, error) {
+	args := m.Called(ownerType)
+	return args.Get(0).([]*database.User), args.Error(1)
+}
+
+func (m *MockDBRepository) SetOwnerType(userID uint, ownerType string) error {
+	args := m.Called(userID, ownerType)
+	return args.Error(0)
+}
+
 func (m *MockDBRepository) StoreRepositoryInfo(remoteRepoInfo *dto.RepositoryInfoResponseDTO, owner *database.User) (*database.Repository, error) {
 	args := m.Called(remoteRepoInfo, owner)
 	return args.Get(0).(*database.Repository), args.Error(1)
@@ -27,3 +27,39 @@ func (m *MockRepoUseCase) GetUserRepositories(username string, repoSearchParams
 	}
 	return repositories, args.Error(1)
 }
+
+func (m *MockRepoUseCase) GetRepositoryBranches(owner, repoName string) ([]*entity.Branch, error) {
+	args := m.Called(owner, repoName)
+	var branches []*entity.Branch
+	if args.Get(0) != nil {
+		branches = args.Get(0).([]*entity.Branch)
+	}
+	return branches, args.Error(1)
+}
+
+func (m *MockRepoUseCase) GetRepositoryTags(owner, repoName string) ([]*entity.Tag, error) {
+	args := m.Called(owner, repoName)
+	var tags []*entity.Tag
+	if args.Get(0) != nil {
+		tags = args.Get(0).([]*entity.Tag)
+	}
+	return tags, args.Error(1)
+}
+
+func (m *MockRepoUseCase) GetRepositoryLanguages(owner, repoName string) (*entity.LanguageBreakdown, error) {
+	args := m.Called(owner, repoName)
+	var languages *entity.LanguageBreakdown
+	if args.Get(0) != nil {
+		languages = args.Get(0).(*entity.LanguageBreakdown)
+	}
+	return languages, args.Error(1)
+}
+
+func (m *MockRepoUseCase) GetOwnerLanguages(owner string) (*entity.LanguageBreakdown, error) {
+	args := m.Called(owner)
+	var languages *entity.LanguageBreakdown
+	if args.Get(0) != nil {
+		languages = args.Get(0).(*entity.LanguageBreakdown)
+	}
+	return languages, args.Error(1)
+}
@@ -10,7 +10,10 @@
 package mocks
 
 import (
+	"context"
+
 	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/requester"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -19,26 +22,97 @@ type MockRequester struct {
 	mock.Mock
 }
 
-
-
-
 // GetAllUserRepositories mocks base method.
-func (m *MockRequester) GetAllUserRepositories(owner string) (*[]dto.RepositoryInfoResponseDTO, error) {
-	args := m.Called(owner)
-	return args.Get(0).(*[]dto.RepositoryInfoResponseDTO), args.Error(1)
+func (m *MockRequester) GetAllUserRepositories(ctx context.Context, owner string, handlePage requester.RepositoryPageHandler) error {
+	args := m.Called(ctx, owner, handlePage)
+	return args.Error(0)
 }
 
+// GetOwner mocks base method.
+func (m *MockRequester) GetOwner(ctx context.Context, owner string) (*dto.OwnerResponseDTO, error) {
+	args := m.Called(ctx, owner)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OwnerResponseDTO), args.Error(1)
+}
 
+// GetAllOrganizationRepositories mocks base method.
+func (m *MockRequester) GetAllOrganizationRepositories(ctx context.Context, org string, handlePage requester.RepositoryPageHandler) error {
+	args := m.Called(ctx, org, handlePage)
+	return args.Error(0)
+}
 
 // GetRepositoryCommits mocks base method.
-func (m *MockRequester) GetRepositoryCommits(owner, repo string) (*[]dto.CommitResponseDTO, error) {
-	args := m.Called(owner, repo)
-	return args.Get(0).(*[]dto.CommitResponseDTO), args.Error(1)
+func (m *MockRequester) GetRepositoryCommits(ctx context.Context, owner, repo string, queryParams *dto.CommitQueryParams, handlePage requester.CommitPageHandler) error {
+	args := m.Called(ctx, owner, repo, queryParams, handlePage)
+	return args.Error(0)
 }
 
-
 // GetRepositoryInfo mocks base method.
-func (m *MockRequester) GetRepositoryInfo(owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
-	args := m.Called(owner, repo)
+func (m *MockRequester) GetRepositoryInfo(ctx context.Context, owner, repo string) (*dto.RepositoryInfoResponseDTO, error) {
+	args := m.Called(ctx, owner, repo)
 	return args.Get(0).(*dto.RepositoryInfoResponseDTO), args.Error(1)
-}
\ No newline at end of file
+}
+
+// GetCommit mocks base method.
+func (m *MockRequester) GetCommit(ctx context.Context, owner, repo, sha string) (*dto.CommitDetailResponseDTO, error) {
+	args := m.Called(ctx, owner, repo, sha)
+	return args.Get(0).(*dto.CommitDetailResponseDTO), args.Error(1)
+}
+
+// GetRepositoryBranches mocks base method.
+func (m *MockRequester) GetRepositoryBranches(ctx context.Context, owner, repo string, handlePage requester.BranchPageHandler) error {
+	args := m.Called(ctx, owner, repo, handlePage)
+	return args.Error(0)
+}
+
+// GetRepositoryTags mocks base method.
+func (m *MockRequester) GetRepositoryTags(ctx context.Context, owner, repo string, handlePage requester.TagPageHandler) error {
+	args := m.Called(ctx, owner, repo, handlePage)
+	return args.Error(0)
+}
+
+// GetRepositoryReleases mocks base method.
+func (m *MockRequester) GetRepositoryReleases(ctx context.Context, owner, repo string, handlePage requester.ReleasePageHandler) error {
+	args := m.Called(ctx, owner, repo, handlePage)
+	return args.Error(0)
+}
+
+// GetRepositoryPullRequests mocks base method.
+func (m *MockRequester) GetRepositoryPullRequests(ctx context.Context, owner, repo string, handlePage requester.PullRequestPageHandler) error {
+	args := m.Called(ctx, owner, repo, handlePage)
+	return args.Error(0)
+}
+
+// GetRepositoryIssues mocks base method.
+func (m *MockRequester) GetRepositoryIssues(ctx context.Context, owner, repo, since string, handlePage requester.IssuePageHandler) error {
+	args := m.Called(ctx, owner, repo, since, handlePage)
+	return args.Error(0)
+}
+
+// GetRepositoryContributors mocks base method.
+func (m *MockRequester) GetRepositoryContributors(ctx context.Context, owner, repo string, handlePage requester.ContributorPageHandler) error {
+	args := m.Called(ctx, owner, repo, handlePage)
+	return args.Error(0)
+}
+
+// GetContributorStats mocks base method.
+func (m *MockRequester) GetContributorStats(ctx context.Context, owner, repo string) ([]dto.ContributorStatsResponseDTO, error) {
+	args := m.Called(ctx, owner, repo)
+	var stats []dto.ContributorStatsResponseDTO
+	if args.Get(0) != nil {
+		stats = args.Get(0).([]dto.ContributorStatsResponseDTO)
+	}
+	return stats, args.Error(1)
+}
+
+// GetRepositoryLanguages mocks base method.
+func (m *MockRequester) GetRepositoryLanguages(ctx context.Context, owner, repo string) (map[string]int, error) {
+	args := m.Called(ctx, owner, repo)
+	var languages map[string]int
+	if args.Get(0) != nil {
+		languages = args.Get(0).(map[string]int)
+	}
+	return languages, args.Error(1)
+}
@@ -16,3 +16,8 @@ func (m *MockTask) AddUserToGetAllRepoQueue(user *database.User) {
 func (m *MockTask) AddRequestToFetchNewlyRequestedRepoQueue(username, repoName string) {
 	m.Called(username, repoName)
 }
+
+func (m *MockTask) TakeRepoRequestError(username, repoName string) error {
+	args := m.Called(username, repoName)
+	return args.Error(0)
+}
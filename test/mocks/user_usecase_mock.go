@@ -25,3 +25,11 @@ func (m *MockUserUseCase) GetUser(username string) (*entity.User, error) {
 	}
 	return args.Get(0).(*entity.User), args.Error(1)
 }
+
+func (m *MockUserUseCase) GetUsers(ownerType string) ([]*entity.User, error) {
+	args := m.Called(ownerType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*entity.User), args.Error(1)
+}
@@ -0,0 +1,114 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/controllers"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTopNAuthorsByChurn(t *testing.T) {
+	mockCommitUseCase := new(mocks.MockCommitUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, mockCommitUseCase, nil, nil, nil, nil)
+
+	t.Run("successful fetch top authors by churn", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/authors/top/{top_n}/churn", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"top_n": "2"})
+
+		rr := httptest.NewRecorder()
+		authors := []*entity.AuthorChurn{
+			{Author: "bob", Commits: 1, Additions: 300},
+			{Author: "ada", Commits: 2, Additions: 110, Deletions: 30},
+		}
+		mockCommitUseCase.On("GetTopNAuthorsByChurn", 2).Return(authors, nil)
+
+		http.HandlerFunc(controller.GetTopNAuthorsByChurn).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, true, response.Success)
+		assert.Equal(t, "Top Authors by Churn Fetched Successfully", response.Message)
+		mockCommitUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid payload - top_n is not a positive number", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/authors/top/{top_n}/churn", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"top_n": "0"})
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(controller.GetTopNAuthorsByChurn).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetRepositoryChurn(t *testing.T) {
+	mockCommitUseCase := new(mocks.MockCommitUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, mockCommitUseCase, nil, nil, nil, nil)
+
+	t.Run("successful fetch repository churn", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/churn", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		churn := &entity.RepositoryChurn{Repository: "testrepo", Commits: 3, Additions: 410, Deletions: 30}
+		mockCommitUseCase.On("GetRepositoryChurn", "testuser", "testrepo").Return(churn, nil)
+
+		http.HandlerFunc(controller.GetRepositoryChurn).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, true, response.Success)
+		assert.Equal(t, "Repository Churn Fetched Successfully", response.Message)
+		mockCommitUseCase.AssertExpectations(t)
+	})
+
+	t.Run("internal server error", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/churn", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepox"})
+
+		rr := httptest.NewRecorder()
+		mockCommitUseCase.On("GetRepositoryChurn", "testuser", "testrepox").Return(nil, errors.New("some error"))
+
+		http.HandlerFunc(controller.GetRepositoryChurn).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusInternalServerError, rr.Code)
+		mockCommitUseCase.AssertExpectations(t)
+	})
+}
+
+func TestGetMostChangedFiles(t *testing.T) {
+	mockCommitUseCase := new(mocks.MockCommitUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, mockCommitUseCase, nil, nil, nil, nil)
+
+	req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/files/top/{top_n}", nil)
+	assert.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo", "top_n": "5"})
+
+	rr := httptest.NewRecorder()
+	files := []*entity.FileChurn{{Filename: "main.go", Changes: 2}}
+	mockCommitUseCase.On("GetMostChangedFiles", "testuser", "testrepo", 5).Return(files, nil)
+
+	http.HandlerFunc(controller.GetMostChangedFiles).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response utils.APIResponse
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.Equal(t, "Most Changed Files Fetched Successfully", response.Message)
+	mockCommitUseCase.AssertExpectations(t)
+}
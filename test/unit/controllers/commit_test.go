@@ -17,7 +17,7 @@ import (
 
 func TestGetRepositoryCommits(t *testing.T) {
 	mockCommitUseCase := new(mocks.MockCommitUseCase)
-	controller := controllers.NewController(nil, nil, nil, mockCommitUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, mockCommitUseCase, nil, nil, nil, nil)
 
 	t.Run("successful fetch repository commits", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/commits", nil)
@@ -29,7 +29,7 @@ func TestGetRepositoryCommits(t *testing.T) {
 			{Message: "Initial commit", Author: "testuserx"},
 			{Message: "Added new feature", Author: "testuserx"},
 		}
-		mockCommitUseCase.On("GetRepositoryCommits", "testrepo").Return(commits, nil)
+		mockCommitUseCase.On("GetRepositoryCommits", "testuserx", "testrepo").Return(commits, nil)
 
 		http.HandlerFunc(controller.GetRepositoryCommits).ServeHTTP(rr, req)
 
@@ -41,6 +41,27 @@ func TestGetRepositoryCommits(t *testing.T) {
 		mockCommitUseCase.AssertExpectations(t)
 	})
 
+	t.Run("successful fetch branch commits", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/commits?branch=feature", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuserx", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		commits := []*entity.Commit{
+			{Message: "Work in progress", Author: "testuserx"},
+		}
+		mockCommitUseCase.On("GetBranchCommits", "testuserx", "testrepo", "feature").Return(commits, nil)
+
+		http.HandlerFunc(controller.GetRepositoryCommits).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, true, response.Success)
+		assert.Len(t, response.Data, 1)
+		mockCommitUseCase.AssertExpectations(t)
+	})
+
 	t.Run("invalid payload - missing repo", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "/{owner}/repos//commits", nil)
 		assert.NoError(t, err)
@@ -64,7 +85,7 @@ func TestGetRepositoryCommits(t *testing.T) {
 
 		rr := httptest.NewRecorder()
 
-		mockCommitUseCase.On("GetRepositoryCommits", "testrepox").Return(nil, errors.New("some error"))
+		mockCommitUseCase.On("GetRepositoryCommits", "testuserx", "testrepox").Return(nil, errors.New("some error"))
 
 		http.HandlerFunc(controller.GetRepositoryCommits).ServeHTTP(rr, req)
 
@@ -79,7 +100,7 @@ func TestGetRepositoryCommits(t *testing.T) {
 
 func TestRequestRepositoryReset(t *testing.T) {
 	mockCommitUseCase := new(mocks.MockCommitUseCase)
-	controller := controllers.NewController(nil, nil, nil, mockCommitUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, mockCommitUseCase, nil, nil, nil, nil)
 
 	t.Run("successful repository reset request", func(t *testing.T) {
 		req, err := http.NewRequest("POST", "/{owner}/repos/{repo}/reset/{reset_sha}", nil)
@@ -170,7 +191,7 @@ func TestRequestRepositoryReset(t *testing.T) {
 
 func TestGetTopNAuthorsByCommits(t *testing.T) {
 	mockCommitUseCase := new(mocks.MockCommitUseCase)
-	controller := controllers.NewController(nil, nil, nil, mockCommitUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, mockCommitUseCase, nil, nil, nil, nil)
 
 	t.Run("successful fetch top N authors by commits", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "/authors/top/{top_n}", nil)
@@ -0,0 +1,57 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/controllers"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestContributorReconciliation(t *testing.T) {
+	mockContributorUseCase := new(mocks.MockContributorUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, nil, nil, nil, nil, mockContributorUseCase)
+
+	t.Run("reports without correcting", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/contributors/reconciliation", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		reconciliation := &entity.ContributorReconciliation{Repository: "testrepo", Contributors: []*entity.ContributorReconciliationEntry{
+			{Login: "ada", Author: "Ada", Upstream: 10, Stored: 8, Difference: 2},
+		}}
+		mockContributorUseCase.On("ReconcileAuthorCommitCounts", "testuser", "testrepo", false).Return(reconciliation, nil)
+
+		http.HandlerFunc(controller.GetContributorReconciliation).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "Contributor Reconciliation Fetched Successfully", response.Message)
+		mockContributorUseCase.AssertExpectations(t)
+	})
+
+	t.Run("corrects the counts", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/{owner}/repos/{repo}/contributors/reconciliation", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		mockContributorUseCase.On("ReconcileAuthorCommitCounts", "testuser", "testrepo", true).Return(&entity.ContributorReconciliation{Repository: "testrepo", Corrected: true}, nil)
+
+		http.HandlerFunc(controller.CorrectAuthorCommitCounts).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "Author Commit Counts Corrected Successfully", response.Message)
+		mockContributorUseCase.AssertExpectations(t)
+	})
+}
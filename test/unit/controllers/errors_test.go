@@ -0,0 +1,51 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/midedickson/github-service/interface/controllers"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamErrorStatuses(t *testing.T) {
+	mockRepoUseCase := new(mocks.MockRepoUseCase)
+	controller := controllers.NewController(nil, nil, nil, mockRepoUseCase, nil, nil, nil, nil, nil)
+
+	cases := []struct {
+		repo   string
+		err    error
+		status int
+		code   string
+	}{
+		{"missing", &utils.UpstreamError{Kind: utils.ErrRepoNotFound, StatusCode: http.StatusNotFound}, http.StatusNotFound, "not_found"},
+		{"limited", &utils.UpstreamError{Kind: utils.ErrRateLimited, StatusCode: http.StatusTooManyRequests}, http.StatusTooManyRequests, "rate_limited"},
+		{"private", &utils.UpstreamError{Kind: utils.ErrForbidden, StatusCode: http.StatusForbidden}, http.StatusForbidden, "forbidden"},
+		{"down", &utils.UpstreamError{Kind: utils.ErrUpstreamUnavailable, StatusCode: http.StatusBadGateway}, http.StatusServiceUnavailable, "upstream_unavailable"},
+		{"nouser", fmt.Errorf("user with the username testuser: %w", utils.ErrUserNotFound), http.StatusNotFound, "user_not_found"},
+	}
+	for _, c := range cases {
+		t.Run(c.code, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/{owner}/repos/{repo}", nil)
+			req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": c.repo})
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			mockRepoUseCase.On("GetRepositoryInfo", "testuser", c.repo).Return(nil, c.err)
+
+			http.HandlerFunc(controller.GetRepositoryInfo).ServeHTTP(rr, req)
+
+			assert.Equal(t, c.status, rr.Code)
+			var response utils.APIResponse
+			json.Unmarshal(rr.Body.Bytes(), &response)
+			assert.Equal(t, false, response.Success)
+			assert.Equal(t, c.code, response.Code)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/controllers"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetIssues(t *testing.T) {
+	mockIssueUseCase := new(mocks.MockIssueUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, nil, nil, nil, mockIssueUseCase, nil)
+
+	t.Run("lists open issues matching the filters", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/issues?label=bug&assignee=ada", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		issues := []*entity.Issue{{Number: 7, State: "open", Labels: []string{"bug"}, Assignees: []string{"ada"}}}
+		mockIssueUseCase.On("GetIssues", "testuser", "testrepo", &utils.IssueSearchParams{State: "open", Label: "bug", Assignee: "ada"}).Return(issues, nil)
+
+		http.HandlerFunc(controller.GetIssues).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, true, response.Success)
+		assert.Equal(t, "Issues Fetched Successfully", response.Message)
+		mockIssueUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid payload - unknown state", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/issues?state=stale", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(controller.GetIssues).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetIssueStatistics(t *testing.T) {
+	mockIssueUseCase := new(mocks.MockIssueUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, nil, nil, nil, mockIssueUseCase, nil)
+
+	t.Run("not found", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/issues/stats", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "missing"})
+
+		rr := httptest.NewRecorder()
+		mockIssueUseCase.On("GetIssueStatistics", "testuser", "missing").Return(nil, utils.ErrRepoNotFound)
+
+		http.HandlerFunc(controller.GetIssueStatistics).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockIssueUseCase.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,75 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/controllers"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLanguages(t *testing.T) {
+	mockRepoUseCase := new(mocks.MockRepoUseCase)
+	controller := controllers.NewController(nil, nil, nil, mockRepoUseCase, nil, nil, nil, nil, nil)
+
+	t.Run("of a repository", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/languages", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		breakdown := &entity.LanguageBreakdown{Owner: "testuser", Repository: "testrepo", Bytes: 100, Languages: []*entity.LanguageShare{
+			{Language: "Go", Bytes: 75, Percentage: 75},
+			{Language: "Shell", Bytes: 25, Percentage: 25},
+		}}
+		mockRepoUseCase.On("GetRepositoryLanguages", "testuser", "testrepo").Return(breakdown, nil)
+
+		http.HandlerFunc(controller.GetRepositoryLanguages).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "Repository Languages Fetched Successfully", response.Message)
+		mockRepoUseCase.AssertExpectations(t)
+	})
+
+	t.Run("of a missing repository", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/languages", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "missing"})
+
+		rr := httptest.NewRecorder()
+		mockRepoUseCase.On("GetRepositoryLanguages", "testuser", "missing").Return(nil, fmt.Errorf("repository testuser/missing: %w", utils.ErrRepoNotFound))
+
+		http.HandlerFunc(controller.GetRepositoryLanguages).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("of an owner", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/languages", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser"})
+
+		rr := httptest.NewRecorder()
+		breakdown := &entity.LanguageBreakdown{Owner: "testuser", Bytes: 100, Languages: []*entity.LanguageShare{
+			{Language: "Go", Bytes: 100, Percentage: 100, Repositories: 2},
+		}}
+		mockRepoUseCase.On("GetOwnerLanguages", "testuser").Return(breakdown, nil)
+
+		http.HandlerFunc(controller.GetOwnerLanguages).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "Languages Fetched Successfully", response.Message)
+		mockRepoUseCase.AssertExpectations(t)
+	})
+}
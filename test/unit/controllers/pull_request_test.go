@@ -0,0 +1,88 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/controllers"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPullRequests(t *testing.T) {
+	mockPullRequestUseCase := new(mocks.MockPullRequestUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, nil, nil, mockPullRequestUseCase, nil, nil)
+
+	t.Run("lists open pull requests by default", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/pulls", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		pullRequests := []*entity.PullRequest{{Number: 2, State: "open"}}
+		mockPullRequestUseCase.On("GetPullRequests", "testuser", "testrepo", "open").Return(pullRequests, nil)
+
+		http.HandlerFunc(controller.GetPullRequests).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, true, response.Success)
+		assert.Equal(t, "Pull Requests Fetched Successfully", response.Message)
+		mockPullRequestUseCase.AssertExpectations(t)
+	})
+
+	t.Run("lists every state when asked for all", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/pulls?state=all", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		mockPullRequestUseCase.On("GetPullRequests", "testuser", "testrepo", "").Return([]*entity.PullRequest{}, nil)
+
+		http.HandlerFunc(controller.GetPullRequests).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockPullRequestUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid payload - unknown state", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/pulls?state=merged", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(controller.GetPullRequests).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
+
+func TestGetPullRequestMetrics(t *testing.T) {
+	mockPullRequestUseCase := new(mocks.MockPullRequestUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, nil, nil, mockPullRequestUseCase, nil, nil)
+
+	req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/pulls/metrics", nil)
+	assert.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+	rr := httptest.NewRecorder()
+	metrics := &entity.RepositoryPullRequestMetrics{Repository: "testrepo"}
+	metrics.Merged = 3
+	mockPullRequestUseCase.On("GetPullRequestMetrics", "testuser", "testrepo").Return(metrics, nil)
+
+	http.HandlerFunc(controller.GetPullRequestMetrics).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response utils.APIResponse
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.Equal(t, "Pull Request Metrics Fetched Successfully", response.Message)
+	assert.Equal(t, float64(3), response.Data.(map[string]interface{})["merged"])
+	mockPullRequestUseCase.AssertExpectations(t)
+}
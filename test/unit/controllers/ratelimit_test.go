@@ -0,0 +1,47 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/interface/controllers"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRateLimitStatus(t *testing.T) {
+	governor := requester.NewGovernor(10)
+	header := http.Header{}
+	header.Set("x-ratelimit-limit", "5000")
+	header.Set("x-ratelimit-remaining", "4321")
+	header.Set("x-ratelimit-reset", "4102444800")
+	governor.Observe("secret-token", "token #1", header)
+	controller := controllers.NewController(nil, governor, nil, nil, nil, nil, nil, nil, nil)
+
+	req, err := http.NewRequest("GET", "/ratelimit", nil)
+	assert.NoError(t, err)
+	rr := httptest.NewRecorder()
+
+	http.HandlerFunc(controller.GetRateLimitStatus).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.NotContains(t, rr.Body.String(), "secret-token")
+	var response struct {
+		utils.APIResponse
+		Data []requester.RateLimitStatus `json:"data"`
+	}
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.Equal(t, true, response.Success)
+	assert.Equal(t, "Rate Limit Status Fetched Successfully", response.Message)
+	assert.Equal(t, []requester.RateLimitStatus{{
+		Credential: "token #1",
+		Limit:      5000,
+		Remaining:  4321,
+		Reset:      time.Unix(4102444800, 0).UTC(),
+		Reserved:   10,
+	}}, response.Data)
+}
@@ -0,0 +1,77 @@
+package controllers_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/controllers"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRepositoryReleases(t *testing.T) {
+	mockReleaseUseCase := new(mocks.MockReleaseUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, nil, mockReleaseUseCase, nil, nil, nil)
+
+	t.Run("successful fetch repository releases", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/releases", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		releases := []*entity.Release{{TagName: "v1.1.0"}, {TagName: "v1.0.0"}}
+		mockReleaseUseCase.On("GetRepositoryReleases", "testuser", "testrepo").Return(releases, nil)
+
+		http.HandlerFunc(controller.GetRepositoryReleases).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, true, response.Success)
+		assert.Equal(t, "Repository Releases Fetched Successfully", response.Message)
+		mockReleaseUseCase.AssertExpectations(t)
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/releases", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "missing"})
+
+		rr := httptest.NewRecorder()
+		mockReleaseUseCase.On("GetRepositoryReleases", "testuser", "missing").Return(nil, fmt.Errorf("repository testuser/missing: %w", utils.ErrRepoNotFound))
+
+		http.HandlerFunc(controller.GetRepositoryReleases).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockReleaseUseCase.AssertExpectations(t)
+	})
+}
+
+func TestGetReleaseTimeline(t *testing.T) {
+	mockReleaseUseCase := new(mocks.MockReleaseUseCase)
+	controller := controllers.NewController(nil, nil, nil, nil, nil, mockReleaseUseCase, nil, nil, nil)
+
+	req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/releases/timeline", nil)
+	assert.NoError(t, err)
+	req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+	rr := httptest.NewRecorder()
+	timeline := []*entity.ReleaseTimelineEntry{
+		{Release: &entity.Release{TagName: "v1.0.0"}, Commits: []*entity.Commit{{SHA: "c1"}}},
+	}
+	mockReleaseUseCase.On("GetReleaseTimeline", "testuser", "testrepo").Return(timeline, nil)
+
+	http.HandlerFunc(controller.GetReleaseTimeline).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	var response utils.APIResponse
+	json.Unmarshal(rr.Body.Bytes(), &response)
+	assert.Equal(t, "Release Timeline Fetched Successfully", response.Message)
+	mockReleaseUseCase.AssertExpectations(t)
+}
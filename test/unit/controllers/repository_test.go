@@ -17,7 +17,7 @@ import (
 
 func TestGetRepositoryInfo(t *testing.T) {
 	mockRepoUseCase := new(mocks.MockRepoUseCase)
-	controller := controllers.NewController(nil, nil, mockRepoUseCase, nil)
+	controller := controllers.NewController(nil, nil, nil, mockRepoUseCase, nil, nil, nil, nil, nil)
 
 	t.Run("successful fetch repository info", func(t *testing.T) {
 		// Create a new HTTP request
@@ -97,7 +97,7 @@ func TestGetRepositoryInfo(t *testing.T) {
 
 func TestGetRepositories(t *testing.T) {
 	mockRepoUseCase := new(mocks.MockRepoUseCase)
-	controller := controllers.NewController(nil, nil, mockRepoUseCase, nil)
+	controller := controllers.NewController(nil, nil, nil, mockRepoUseCase, nil, nil, nil, nil, nil)
 
 	t.Run("successful fetch repositories", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "/{owner}/repos/", nil)
@@ -123,6 +123,42 @@ func TestGetRepositories(t *testing.T) {
 		mockRepoUseCase.AssertExpectations(t)
 	})
 
+	t.Run("filters by repository metadata", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/?topic=go&fork=false&archived=true&visibility=private&license=MIT&default_branch=main&pushed_after=2024-01-01", nil)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuserf"})
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+		fork, archived := false, true
+		repoSearchParams := &utils.RepositorySearchParams{
+			Topic: "go", Fork: &fork, Archived: &archived, Visibility: "private", License: "MIT", DefaultBranch: "main",
+			PushedAfter: "2024-01-01T00:00:00Z",
+		}
+		mockRepoUseCase.On("GetUserRepositories", "testuserf", repoSearchParams).Return([]*entity.Repository{}, nil)
+
+		http.HandlerFunc(controller.GetRepositories).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockRepoUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid filters", func(t *testing.T) {
+		for _, query := range []string{"archived=maybe", "fork=1x", "visibility=secret", "pushed_after=yesterday"} {
+			req, err := http.NewRequest("GET", "/{owner}/repos/?"+query, nil)
+			req = mux.SetURLVars(req, map[string]string{"owner": "testuser"})
+			assert.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+
+			http.HandlerFunc(controller.GetRepositories).ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusBadRequest, rr.Code, query)
+			var response utils.APIResponse
+			json.Unmarshal(rr.Body.Bytes(), &response)
+			assert.Equal(t, "Invalid Payload", response.Message)
+		}
+	})
+
 	t.Run("invalid payload - missing owner", func(t *testing.T) {
 		req, err := http.NewRequest("GET", "/{owner}/repos/", nil)
 		req = mux.SetURLVars(req, map[string]string{"owner": ""})
@@ -158,3 +194,38 @@ func TestGetRepositories(t *testing.T) {
 		mockRepoUseCase.AssertExpectations(t)
 	})
 }
+
+func TestGetRepositoryBranches(t *testing.T) {
+	mockRepoUseCase := new(mocks.MockRepoUseCase)
+	controller := controllers.NewController(nil, nil, nil, mockRepoUseCase, nil, nil, nil, nil, nil)
+
+	t.Run("successful fetch repository branches", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/branches", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"owner": "testuser", "repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+		branches := []*entity.Branch{{Name: "main", SHA: "c3"}}
+		mockRepoUseCase.On("GetRepositoryBranches", "testuser", "testrepo").Return(branches, nil)
+
+		http.HandlerFunc(controller.GetRepositoryBranches).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "Repository Branches Fetched Successfully", response.Message)
+		mockRepoUseCase.AssertExpectations(t)
+	})
+
+	t.Run("invalid payload - missing owner", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/{owner}/repos/{repo}/branches", nil)
+		assert.NoError(t, err)
+		req = mux.SetURLVars(req, map[string]string{"repo": "testrepo"})
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(controller.GetRepositoryBranches).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
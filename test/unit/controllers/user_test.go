@@ -18,7 +18,7 @@ import (
 
 func TestCreateUser(t *testing.T) {
 	mockUserUseCase := new(mocks.MockUserUseCase)
-	controller := controllers.NewController(nil, mockUserUseCase, nil, nil)
+	controller := controllers.NewController(nil, nil, mockUserUseCase, nil, nil, nil, nil, nil, nil)
 
 	t.Run("successful create user", func(t *testing.T) {
 		payload := &dto.CreateUserPayloadDTO{
@@ -59,6 +59,20 @@ func TestCreateUser(t *testing.T) {
 		assert.Equal(t, "Invalid Payload", response.Message)
 	})
 
+	t.Run("unknown provider", func(t *testing.T) {
+		req, err := http.NewRequest("POST", "/register", bytes.NewBuffer([]byte(`{"username": "testuser", "fullName": "Test User", "provider": "bitbucket"}`)))
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(controller.CreateUser).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, "Unknown provider, expected one of github, gitlab, gitea, local", response.Message)
+	})
+
 	t.Run("create user error", func(t *testing.T) {
 		payload := &dto.CreateUserPayloadDTO{
 			Username: "testuserx",
@@ -81,3 +95,39 @@ func TestCreateUser(t *testing.T) {
 		mockUserUseCase.AssertExpectations(t)
 	})
 }
+
+func TestGetUsers(t *testing.T) {
+	mockUserUseCase := new(mocks.MockUserUseCase)
+	controller := controllers.NewController(nil, nil, mockUserUseCase, nil, nil, nil, nil, nil, nil)
+
+	t.Run("filter by owner type", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/users?type=organization", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		orgs := []*entity.User{{ID: 2, Username: "acme", OwnerType: entity.OwnerTypeOrganization}}
+		mockUserUseCase.On("GetUsers", entity.OwnerTypeOrganization).Return(orgs, nil)
+
+		http.HandlerFunc(controller.GetUsers).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		var response utils.APIResponse
+		json.Unmarshal(rr.Body.Bytes(), &response)
+		assert.Equal(t, true, response.Success)
+		assert.Len(t, response.Data, 1)
+		mockUserUseCase.AssertExpectations(t)
+	})
+
+	t.Run("unknown owner type", func(t *testing.T) {
+		req, err := http.NewRequest("GET", "/users?type=team", nil)
+		assert.NoError(t, err)
+
+		rr := httptest.NewRecorder()
+
+		http.HandlerFunc(controller.GetUsers).ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockUserUseCase.AssertNotCalled(t, "GetUsers", "team")
+	})
+}
@@ -0,0 +1,84 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestAuthorIdentity(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Commit{}, &database.CommitFile{},
+		&database.AuthorCommitCount{}, &database.AuthorChurn{}, &database.FileChurn{}))
+	user := &database.User{Username: "testuser", Provider: entity.ProviderGitea}
+	assert.NoError(t, db.Create(user).Error)
+	repo := &database.Repository{OwnerID: user.ID, Name: "testrepo"}
+	assert.NoError(t, db.Create(repo).Error)
+
+	commitRepository := database.NewSqliteCommitRepository(db)
+	// ada renamed their account and changed how they sign their commits; the two Sams are different people
+	newCommits, err := commitRepository.StoreRepositoryCommits(&[]dto.CommitResponseDTO{
+		{SHA: "a2", Author: "Ada Lovelace", AuthorLogin: "lovelace", AuthorID: 1, Date: "2024-01-02T00:00:00Z",
+			Committer: "Bot", CommitterEmail: "bot@example.com", CommitterDate: "2024-01-03T00:00:00Z"},
+		{SHA: "a1", Author: "ada", AuthorLogin: "ada", AuthorID: 1, Date: "2024-01-01T00:00:00Z"},
+		{SHA: "s1", Author: "Sam", AuthorEmail: "Sam@One.example"},
+		{SHA: "s2", Author: "Sam", AuthorEmail: "sam@two.example"},
+		{SHA: "s3", Author: "Sam", AuthorEmail: "sam@one.example"},
+	}, "testrepo", &entity.User{ID: user.ID, Username: "testuser", Provider: entity.ProviderGitea})
+	assert.NoError(t, err)
+	assert.Len(t, newCommits, 5)
+
+	keys := make(map[string]string)
+	for _, commit := range newCommits {
+		keys[commit.SHA] = commit.AuthorKey
+	}
+	assert.Equal(t, "gitea:id:1", keys["a1"])
+	assert.Equal(t, keys["a1"], keys["a2"])
+	assert.Equal(t, "email:sam@one.example", keys["s1"])
+	assert.Equal(t, keys["s1"], keys["s3"])
+	assert.NotEqual(t, keys["s1"], keys["s2"])
+
+	t.Run("stores the committer apart from the author", func(t *testing.T) {
+		commit, err := commitRepository.GetCommitBySHA(repo.ID, "a2")
+		assert.NoError(t, err)
+		assert.Equal(t, "Ada Lovelace", commit.Author)
+		assert.Equal(t, "2024-01-02T00:00:00Z", commit.Date)
+		assert.Equal(t, "Bot", commit.Committer)
+		assert.Equal(t, "bot@example.com", commit.CommitterEmail)
+		assert.Equal(t, "2024-01-03T00:00:00Z", commit.CommitterDate)
+	})
+
+	t.Run("counts commits per identity", func(t *testing.T) {
+		for _, commit := range newCommits {
+			assert.NoError(t, commitRepository.AddAuthorCommitCount(commit.AuthorIdentity(), 1))
+		}
+		authors, err := commitRepository.FindTopNAuthorsByCommitCounts(10)
+		assert.NoError(t, err)
+		assert.Len(t, authors, 3)
+		assert.Equal(t, 2, authors[0].CommitCount)
+		assert.Equal(t, 2, authors[1].CommitCount)
+		counts := map[string]int{}
+		for _, author := range authors {
+			counts[author.Author+"/"+author.AuthorLogin] += author.CommitCount
+		}
+		// an author is shown under the name and login they were last counted with
+		assert.Equal(t, map[string]int{"ada/ada": 2, "Sam/": 3}, counts)
+	})
+
+	t.Run("sums churn per identity", func(t *testing.T) {
+		for _, commit := range newCommits {
+			assert.NoError(t, commitRepository.StoreCommitStats(repo.ID, commitDetail(commit.SHA, commit.Author, 10, 0, "main.go")))
+		}
+		authors, err := commitRepository.FindTopNAuthorsByChurn(10)
+		assert.NoError(t, err)
+		assert.Len(t, authors, 3)
+		assert.Equal(t, 20, authors[0].Additions)
+		assert.Equal(t, 2, authors[0].Commits)
+	})
+}
@@ -0,0 +1,113 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func newCommitRepository(t *testing.T) *database.SqliteCommitRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.Commit{}, &database.CommitFile{}, &database.AuthorChurn{}, &database.FileChurn{}, &database.CommitBranch{}))
+	return database.NewSqliteCommitRepository(db)
+}
+
+func commitDetail(sha, author string, additions, deletions int, files ...string) *dto.CommitDetailResponseDTO {
+	detail := &dto.CommitDetailResponseDTO{Additions: additions, Deletions: deletions}
+	detail.SHA = sha
+	detail.Author = author
+	for _, file := range files {
+		detail.Files = append(detail.Files, dto.CommitFileDTO{Filename: file, Additions: additions / len(files), Deletions: deletions / len(files)})
+	}
+	return detail
+}
+
+func TestCommitChurn(t *testing.T) {
+	commitRepository := newCommitRepository(t)
+	details := []*dto.CommitDetailResponseDTO{
+		commitDetail("sha1", "ada", 100, 20, "main.go", "go.mod"),
+		commitDetail("sha2", "ada", 10, 10, "main.go"),
+		commitDetail("sha3", "bob", 300, 0, "README.md"),
+	}
+	for _, detail := range details {
+		assert.NoError(t, commitRepository.DB.Create(&database.Commit{RepositoryName: "testrepo", RepositoryID: 1, SHA: detail.SHA, Author: detail.Author}).Error)
+	}
+
+	pending, err := commitRepository.GetCommitsWithoutStats(1)
+	assert.NoError(t, err)
+	assert.Len(t, pending, 3)
+	for _, detail := range details {
+		assert.NoError(t, commitRepository.StoreCommitStats(1, detail))
+	}
+	// storing the same stats twice does not count them twice
+	assert.NoError(t, commitRepository.StoreCommitStats(1, details[0]))
+
+	pending, err = commitRepository.GetCommitsWithoutStats(1)
+	assert.NoError(t, err)
+	assert.Empty(t, pending)
+
+	t.Run("ranks authors by lines changed", func(t *testing.T) {
+		authors, err := commitRepository.FindTopNAuthorsByChurn(2)
+		assert.NoError(t, err)
+		assert.Len(t, authors, 2)
+		assert.Equal(t, "bob", authors[0].Author)
+		assert.Equal(t, "ada", authors[1].Author)
+		assert.Equal(t, 2, authors[1].Commits)
+		assert.Equal(t, 110, authors[1].Additions)
+		assert.Equal(t, 30, authors[1].Deletions)
+	})
+
+	t.Run("totals churn per repository", func(t *testing.T) {
+		churn, err := commitRepository.GetRepositoryChurn(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, churn.Commits)
+		assert.Equal(t, 410, churn.Additions)
+		assert.Equal(t, 30, churn.Deletions)
+		assert.Len(t, churn.Authors, 2)
+	})
+
+	t.Run("ranks files by how often they change", func(t *testing.T) {
+		files, err := commitRepository.FindMostChangedFiles(1, 1)
+		assert.NoError(t, err)
+		assert.Len(t, files, 1)
+		assert.Equal(t, "main.go", files[0].Filename)
+		assert.Equal(t, 2, files[0].Changes)
+	})
+
+	t.Run("a reset takes deleted commits out of the churn", func(t *testing.T) {
+		assert.NoError(t, commitRepository.DeleteUntilSHA(1, "sha2"))
+
+		churn, err := commitRepository.GetRepositoryChurn(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, churn.Commits)
+		assert.Equal(t, 110, churn.Additions)
+
+		files, err := commitRepository.FindMostChangedFiles(1, 10)
+		assert.NoError(t, err)
+		assert.Len(t, files, 2)
+	})
+
+	t.Run("keeps repositories of the same name apart", func(t *testing.T) {
+		// another owner's testrepo
+		assert.NoError(t, commitRepository.DB.Create(&database.Commit{RepositoryName: "testrepo", RepositoryID: 2, SHA: "sha4", Author: "cy"}).Error)
+		assert.NoError(t, commitRepository.StoreCommitStats(2, commitDetail("sha4", "cy", 50, 5, "main.go")))
+
+		churn, err := commitRepository.GetRepositoryChurn(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, churn.Commits)
+		files, err := commitRepository.FindMostChangedFiles(1, 1)
+		assert.NoError(t, err)
+		assert.Equal(t, "main.go", files[0].Filename)
+		assert.Equal(t, 2, files[0].Changes)
+
+		churn, err = commitRepository.GetRepositoryChurn(2)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, churn.Commits)
+		assert.Equal(t, 50, churn.Additions)
+	})
+}
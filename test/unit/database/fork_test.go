@@ -0,0 +1,80 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestForkKeepsSharedHistory(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Commit{}, &database.CommitFile{},
+		&database.AuthorChurn{}, &database.FileChurn{}, &database.Branch{}, &database.CommitBranch{}))
+	parentOwner := &database.User{Username: "upstream"}
+	forkOwner := &database.User{Username: "forker"}
+	assert.NoError(t, db.Create(parentOwner).Error)
+	assert.NoError(t, db.Create(forkOwner).Error)
+	parent := &database.Repository{OwnerID: parentOwner.ID, Name: "api"}
+	fork := &database.Repository{OwnerID: forkOwner.ID, Name: "api"}
+	assert.NoError(t, db.Create(parent).Error)
+	assert.NoError(t, db.Create(fork).Error)
+	commitRepository := database.NewSqliteCommitRepository(db)
+
+	listing := func(shas ...string) *[]dto.CommitResponseDTO {
+		commits := []dto.CommitResponseDTO{}
+		for _, sha := range shas {
+			commits = append(commits, dto.CommitResponseDTO{SHA: sha, Author: "ada"})
+		}
+		return &commits
+	}
+	newCommits, err := commitRepository.StoreRepositoryCommits(listing("c2", "c1"), "api", &entity.User{ID: parentOwner.ID, Username: "upstream"})
+	assert.NoError(t, err)
+	assert.Len(t, newCommits, 2)
+	newCommits, err = commitRepository.StoreRepositoryCommits(listing("c3", "c2", "c1"), "api", &entity.User{ID: forkOwner.ID, Username: "forker"})
+	assert.NoError(t, err)
+	assert.Len(t, newCommits, 3)
+	for _, sha := range []string{"c3", "c2", "c1"} {
+		assert.NoError(t, db.Create(&database.CommitBranch{RepositoryID: fork.ID, BranchName: "main", CommitSHA: sha}).Error)
+		assert.NoError(t, commitRepository.StoreCommitStats(fork.ID, commitDetail(sha, "ada", 10, 0, "main.go")))
+	}
+	assert.NoError(t, db.Create(&database.CommitBranch{RepositoryID: parent.ID, BranchName: "main", CommitSHA: "c2"}).Error)
+
+	t.Run("stores a commit once per repository", func(t *testing.T) {
+		newCommits, err := commitRepository.StoreRepositoryCommits(listing("c3", "c2", "c1"), "api", &entity.User{ID: forkOwner.ID, Username: "forker"})
+		assert.NoError(t, err)
+		assert.Empty(t, newCommits)
+
+		commits, err := commitRepository.GetRepositoryCommits(parent.ID)
+		assert.NoError(t, err)
+		assert.Len(t, commits, 2)
+	})
+
+	t.Run("counts the shared history in the fork's churn", func(t *testing.T) {
+		churn, err := commitRepository.GetRepositoryChurn(fork.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, churn.Commits)
+		assert.Equal(t, 30, churn.Additions)
+	})
+
+	t.Run("lists a branch without the other repository's copies", func(t *testing.T) {
+		commits, err := commitRepository.GetBranchCommits(fork.ID, "main")
+		assert.NoError(t, err)
+		assert.Len(t, commits, 3)
+		for _, commit := range commits {
+			assert.Equal(t, fork.ID, commit.RepositoryID)
+		}
+	})
+
+	t.Run("stores reset commits again", func(t *testing.T) {
+		assert.NoError(t, commitRepository.DeleteUntilSHA(fork.ID, "c2"))
+		newCommits, err := commitRepository.StoreRepositoryCommits(listing("c3", "c2", "c1"), "api", &entity.User{ID: forkOwner.ID, Username: "forker"})
+		assert.NoError(t, err)
+		assert.Len(t, newCommits, 1)
+	})
+}
@@ -0,0 +1,52 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRepositoryLanguages(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.RepositoryLanguage{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	owner := &entity.User{ID: user.ID, Username: "testuser"}
+
+	repoRepository := database.NewSqliteRepoRepository(db)
+	service, err := repoRepository.StoreRepositoryInfo(&dto.RepositoryInfoResponseDTO{ID: 1, Name: "service"}, owner)
+	assert.NoError(t, err)
+	tools, err := repoRepository.StoreRepositoryInfo(&dto.RepositoryInfoResponseDTO{ID: 2, Name: "tools"}, owner)
+	assert.NoError(t, err)
+
+	assert.NoError(t, repoRepository.StoreRepositoryLanguages(map[string]int{"Go": 100, "Shell": 10}, service.ToEntity(), "2024-01-01T00:00:00Z"))
+	// a refresh replaces the languages, dropping the ones the repository no longer has
+	assert.NoError(t, repoRepository.StoreRepositoryLanguages(map[string]int{"Go": 300, "Makefile": 20}, service.ToEntity(), "2024-02-01T00:00:00Z"))
+	assert.NoError(t, repoRepository.StoreRepositoryLanguages(map[string]int{"Go": 50, "Python": 30}, tools.ToEntity(), "2024-01-01T00:00:00Z"))
+
+	languages, err := repoRepository.GetRepositoryLanguages(service.ID)
+	assert.NoError(t, err)
+	assert.Len(t, languages, 2)
+	assert.Equal(t, "Go", languages[0].Language)
+	assert.Equal(t, 300, languages[0].Bytes)
+	assert.Equal(t, "Makefile", languages[1].Language)
+
+	stored, err := repoRepository.GetRepository(user.ID, "service")
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-02-01T00:00:00Z", stored.ToEntity().LanguagesUpdatedAt)
+
+	languages, err = repoRepository.GetOwnerLanguages(user.ID)
+	assert.NoError(t, err)
+	assert.Len(t, languages, 3)
+	assert.Equal(t, "Go", languages[0].Language)
+	assert.Equal(t, 350, languages[0].Bytes)
+	assert.Equal(t, 2, languages[0].Repositories)
+	assert.Equal(t, "Python", languages[1].Language)
+	assert.Equal(t, 1, languages[1].Repositories)
+}
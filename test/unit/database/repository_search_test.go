@@ -0,0 +1,99 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestSearchRepositoryMetadata(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	owner := &entity.User{ID: user.ID, Username: "testuser"}
+
+	repoRepository := database.NewSqliteRepoRepository(db)
+	for _, info := range []*dto.RepositoryInfoResponseDTO{
+		{ID: 1, Name: "service", Topics: []string{"go", "api"}, License: &dto.License{Key: "mit", SPDXID: "MIT"},
+			Visibility: "public", Size: 2048, DefaultBranch: "main", PushedAt: "2024-06-01T00:00:00Z"},
+		{ID: 2, Name: "service-fork", Fork: true, Topics: []string{"go"}, DefaultBranch: "main", PushedAt: "2023-01-01T00:00:00Z"},
+		{ID: 3, Name: "legacy", Archived: true, Private: true, Topics: []string{"golang"}, DefaultBranch: "master"},
+	} {
+		_, err := repoRepository.StoreRepositoryInfo(info, owner)
+		assert.NoError(t, err)
+	}
+
+	stored, err := repoRepository.GetRepository(user.ID, "service")
+	assert.NoError(t, err)
+	repo := stored.ToEntity()
+	assert.Equal(t, []string{"go", "api"}, repo.Topics)
+	assert.Equal(t, "MIT", repo.License)
+	assert.Equal(t, entity.VisibilityPublic, repo.Visibility)
+	assert.Equal(t, 2048, repo.Size)
+	assert.Equal(t, "2024-06-01T00:00:00Z", repo.RemotePushedAt)
+
+	yes, no := true, false
+	search := func(params *utils.RepositorySearchParams) []string {
+		repos, err := repoRepository.SearchRepository(user.ID, params)
+		assert.NoError(t, err)
+		names := []string{}
+		for _, repo := range repos {
+			names = append(names, repo.Name)
+		}
+		return names
+	}
+	// a topic is matched whole, so go does not find golang
+	assert.ElementsMatch(t, []string{"service", "service-fork"}, search(&utils.RepositorySearchParams{Topic: "go"}))
+	assert.Equal(t, []string{"service-fork"}, search(&utils.RepositorySearchParams{Fork: &yes}))
+	assert.ElementsMatch(t, []string{"service", "legacy"}, search(&utils.RepositorySearchParams{Fork: &no}))
+	assert.ElementsMatch(t, []string{"service", "service-fork"}, search(&utils.RepositorySearchParams{Archived: &no}))
+	// the visibility of a forge that only says whether a repository is private is worked out from that
+	assert.Equal(t, []string{"legacy"}, search(&utils.RepositorySearchParams{Visibility: entity.VisibilityPrivate}))
+	assert.Equal(t, []string{"service"}, search(&utils.RepositorySearchParams{License: "mit"}))
+	assert.Equal(t, []string{"legacy"}, search(&utils.RepositorySearchParams{DefaultBranch: "master"}))
+	assert.Equal(t, []string{"service"}, search(&utils.RepositorySearchParams{PushedAfter: "2024-01-01T00:00:00Z"}))
+	assert.Equal(t, []string{"service"}, search(&utils.RepositorySearchParams{Topic: "go", Fork: &no}))
+}
+
+func TestStoreRepositoryInfoUpdatesChangedRepository(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	owner := &entity.User{ID: user.ID, Username: "testuser"}
+
+	repoRepository := database.NewSqliteRepoRepository(db)
+	info := &dto.RepositoryInfoResponseDTO{ID: 1, Name: "service", Topics: []string{"go"},
+		Visibility: "public", DefaultBranch: "master", HtmlUrl: "https://github.com/testuser/service", UpdatedAt: "2024-01-01T00:00:00Z"}
+	first, err := repoRepository.StoreRepositoryInfo(info, owner)
+	assert.NoError(t, err)
+	assert.True(t, first.Matches(info))
+
+	// an unchanged repository is left alone
+	again, err := repoRepository.StoreRepositoryInfo(info, owner)
+	assert.NoError(t, err)
+	assert.Equal(t, first.UpdatedAt, again.UpdatedAt)
+
+	_, err = repoRepository.StoreRepositoryInfo(&dto.RepositoryInfoResponseDTO{ID: 1, Name: "service", Topics: []string{"go", "api"},
+		Private: true, DefaultBranch: "main", HtmlUrl: "https://github.com/testuser/service",
+		URL: "https://api.github.com/repos/testuser/service", UpdatedAt: "2024-02-01T00:00:00Z"}, owner)
+	assert.NoError(t, err)
+
+	stored, err := repoRepository.GetRepository(user.ID, "service")
+	assert.NoError(t, err)
+	repo := stored.ToEntity()
+	assert.Equal(t, []string{"go", "api"}, repo.Topics)
+	assert.Equal(t, entity.VisibilityPrivate, repo.Visibility)
+	assert.Equal(t, "main", repo.DefaultBranch)
+	assert.Equal(t, "2024-02-01T00:00:00Z", repo.RemoteUpdatedAt)
+	assert.Equal(t, "https://github.com/testuser/service", repo.URL)
+}
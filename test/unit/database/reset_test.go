@@ -0,0 +1,57 @@
+package database_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestDeleteUntilSHA(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.Commit{}, &database.CommitFile{}, &database.AuthorCommitCount{},
+		&database.AuthorChurn{}, &database.FileChurn{}, &database.Branch{}, &database.CommitBranch{}))
+	commitRepository := database.NewSqliteCommitRepository(db)
+	branchRepository := database.NewSqliteBranchRepository(db)
+
+	// alice/api and bob/api share a name, but only alice's is reset
+	for _, repoID := range []uint{1, 2} {
+		for _, sha := range []string{"sha1", "sha2", "sha3"} {
+			assert.NoError(t, db.Create(&database.Commit{RepositoryName: "api", RepositoryID: repoID, SHA: sha}).Error)
+		}
+		assert.NoError(t, branchRepository.AddCommitsToBranch(repoID, "main", []string{"sha1", "sha2", "sha3"}))
+		branch, err := branchRepository.StoreBranch(repoID, &dto.BranchResponseDTO{Name: "main", SHA: "sha3"})
+		assert.NoError(t, err)
+		assert.NoError(t, branchRepository.MarkBranchSynced(branch, "sha3"))
+	}
+	other, err := branchRepository.StoreBranch(1, &dto.BranchResponseDTO{Name: "release", SHA: "sha1"})
+	assert.NoError(t, err)
+	assert.NoError(t, branchRepository.AddCommitsToBranch(1, "release", []string{"sha1"}))
+	assert.NoError(t, branchRepository.MarkBranchSynced(other, "sha1"))
+
+	assert.NoError(t, commitRepository.DeleteUntilSHA(1, "sha1"))
+
+	commits, err := commitRepository.GetRepositoryCommits(1)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 1)
+	commits, err = commitRepository.GetRepositoryCommits(2)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 3)
+
+	syncedSHAs := func(repoID uint) map[string]string {
+		branches, err := branchRepository.GetRepositoryBranches(repoID)
+		assert.NoError(t, err)
+		synced := map[string]string{}
+		for _, branch := range branches {
+			synced[branch.Name] = branch.SyncedSHA
+		}
+		return synced
+	}
+	// main is synced from the reset commit again; release lost nothing, so it is left as it was
+	assert.Equal(t, map[string]string{"main": "sha1", "release": "sha1"}, syncedSHAs(1))
+	assert.Equal(t, map[string]string{"main": "sha3"}, syncedSHAs(2))
+}
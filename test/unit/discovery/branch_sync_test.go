@@ -0,0 +1,172 @@
+package discovery_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// fakeBranchServer serves a repository whose branches share history, counting
+// how often each branch's commits are listed.
+type fakeBranchServer struct {
+	mu       sync.Mutex
+	branches map[string][]string
+	listed   map[string]int
+}
+
+func (f *fakeBranchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch {
+	case r.URL.Path == "/repos/testuser/testrepo/branches":
+		refs := []string{}
+		for name, commits := range f.branches {
+			refs = append(refs, fmt.Sprintf(`{"name": %q, "commit": {"sha": %q}}`, name, commits[0]))
+		}
+		fmt.Fprintf(w, "[%s]", strings.Join(refs, ","))
+	case r.URL.Path == "/repos/testuser/testrepo/tags":
+		fmt.Fprint(w, `[{"name": "v1.0.0", "commit": {"sha": "c2"}}]`)
+	case r.URL.Path == "/repos/testuser/testrepo/commits":
+		head := r.URL.Query().Get("sha")
+		for name, commits := range f.branches {
+			if commits[0] != head {
+				continue
+			}
+			f.listed[name]++
+			listing := []string{}
+			for _, sha := range commits {
+				listing = append(listing, fmt.Sprintf(`{"sha": %q, "commit": {"message": "commit %s", "author": {"name": "author of %s"}}}`, sha, sha, sha))
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(listing, ","))
+			return
+		}
+		fmt.Fprint(w, "[]")
+	case strings.HasPrefix(r.URL.Path, "/repos/testuser/testrepo/commits/"):
+		sha := strings.TrimPrefix(r.URL.Path, "/repos/testuser/testrepo/commits/")
+		fmt.Fprintf(w, `{"sha": %q, "stats": {"additions": 1, "deletions": 1}, "files": [{"filename": "main.go", "additions": 1, "deletions": 1}]}`, sha)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestBranchAwareCommitSync(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Commit{}, &database.CommitFile{},
+		&database.AuthorCommitCount{}, &database.AuthorChurn{}, &database.FileChurn{},
+		&database.Branch{}, &database.Tag{}, &database.CommitBranch{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	assert.NoError(t, db.Create(&database.Repository{OwnerID: user.ID, Name: "testrepo", DefaultBranch: "main"}).Error)
+
+	fake := &fakeBranchServer{
+		branches: map[string][]string{
+			"main":    {"c3", "c2", "c1"},
+			"feature": {"c4", "c2", "c1"},
+		},
+		listed: map[string]int{},
+	}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	repoRepository := database.NewSqliteRepoRepository(db)
+	commitRepository := database.NewSqliteCommitRepository(db)
+	branchRepository := database.NewSqliteBranchRepository(db)
+	commitDiscovery := discovery.NewCommitDiscoveryService(repoRepository,
+		requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL}),
+		commitRepository, branchRepository, "", "", []string{"*"})
+
+	dbRepo, err := repoRepository.GetRepository(user.ID, "testrepo")
+	assert.NoError(t, err)
+	repo := dbRepo.ToEntity()
+	repo.Owner = &entity.User{ID: user.ID, Username: "testuser"}
+
+	assert.NoError(t, commitDiscovery.GetCommitsForNewRepo(context.Background(), repo))
+
+	t.Run("stores commits shared by branches once", func(t *testing.T) {
+		commits, err := commitRepository.GetRepositoryCommits(repo.ID)
+		assert.NoError(t, err)
+		assert.Len(t, commits, 4)
+
+		authors, err := commitRepository.FindTopNAuthorsByCommitCounts(10)
+		assert.NoError(t, err)
+		assert.Len(t, authors, 4)
+		for _, author := range authors {
+			assert.Equal(t, 1, author.CommitCount)
+		}
+	})
+
+	t.Run("records which branches each commit is on", func(t *testing.T) {
+		mainCommits, err := commitRepository.GetBranchCommits(repo.ID, "main")
+		assert.NoError(t, err)
+		assert.Len(t, mainCommits, 3)
+		featureCommits, err := commitRepository.GetBranchCommits(repo.ID, "feature")
+		assert.NoError(t, err)
+		assert.Len(t, featureCommits, 3)
+
+		tags, err := branchRepository.GetRepositoryTags(repo.ID)
+		assert.NoError(t, err)
+		assert.Len(t, tags, 1)
+		assert.Equal(t, "c2", tags[0].SHA)
+	})
+
+	t.Run("only re-syncs branches that moved", func(t *testing.T) {
+		fake.mu.Lock()
+		fake.branches["main"] = []string{"c5", "c3", "c2", "c1"}
+		fake.mu.Unlock()
+
+		assert.NoError(t, commitDiscovery.CheckForNewCommits(context.Background(), repo))
+
+		assert.Equal(t, 2, fake.listed["main"])
+		assert.Equal(t, 1, fake.listed["feature"])
+		mainCommits, err := commitRepository.GetBranchCommits(repo.ID, "main")
+		assert.NoError(t, err)
+		assert.Len(t, mainCommits, 4)
+	})
+
+	t.Run("keeps branches of same-named repositories apart", func(t *testing.T) {
+		other := &database.User{Username: "otheruser"}
+		assert.NoError(t, db.Create(other).Error)
+		otherRepo := &database.Repository{OwnerID: other.ID, Name: "testrepo", DefaultBranch: "main"}
+		assert.NoError(t, db.Create(otherRepo).Error)
+		_, err := branchRepository.StoreBranch(otherRepo.ID, &dto.BranchResponseDTO{Name: "main", SHA: "other"})
+		assert.NoError(t, err)
+
+		branches, err := branchRepository.GetRepositoryBranches(repo.ID)
+		assert.NoError(t, err)
+		assert.Len(t, branches, 2)
+		for _, branch := range branches {
+			assert.NotEqual(t, "other", branch.SHA)
+		}
+		otherBranches, err := branchRepository.GetRepositoryBranches(otherRepo.ID)
+		assert.NoError(t, err)
+		assert.Len(t, otherBranches, 1)
+	})
+
+	t.Run("tracks only the default branch by default", func(t *testing.T) {
+		defaultOnly := discovery.NewCommitDiscoveryService(repoRepository,
+			requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL}),
+			commitRepository, branchRepository, "", "", nil)
+		fake.mu.Lock()
+		fake.branches["feature"] = []string{"c6", "c4", "c2", "c1"}
+		fake.mu.Unlock()
+
+		assert.NoError(t, defaultOnly.CheckForNewCommits(context.Background(), repo))
+
+		// feature moved, but is not the default branch
+		assert.Equal(t, 1, fake.listed["feature"])
+	})
+}
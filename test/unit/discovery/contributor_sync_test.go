@@ -0,0 +1,65 @@
+package discovery_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestSyncContributorsOnlyRefetchesChangedRepositories(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Contributor{}))
+	dbRepo := &database.Repository{Name: "testrepo"}
+	assert.NoError(t, db.Create(dbRepo).Error)
+
+	statsReady := false
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if strings.HasSuffix(r.URL.Path, "/stats/contributors") {
+			if !statsReady {
+				w.WriteHeader(http.StatusAccepted)
+				return
+			}
+			fmt.Fprint(w, `[{"total": 3, "author": {"id": 1, "login": "ada"}, "weeks": []}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 1, "login": "ada", "contributions": 3}]`)
+	}))
+	defer server.Close()
+
+	contributorRepository := database.NewSqliteContributorRepository(db)
+	repoDiscovery := discovery.NewRepositoryDiscoveryService(requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL}),
+		nil, database.NewSqliteRepoRepository(db), nil, nil, nil, nil, contributorRepository, nil)
+	repo := &entity.Repository{ID: dbRepo.ID, Name: "testrepo", Owner: &entity.User{Username: "testuser"}}
+
+	// totals GitHub is still computing are asked for again on the next sync
+	assert.NoError(t, repoDiscovery.SyncContributors(context.Background(), repo, "2024-01-01T00:00:00Z"))
+	assert.Empty(t, repo.ContributorsUpdatedAt)
+
+	statsReady = true
+	assert.NoError(t, repoDiscovery.SyncContributors(context.Background(), repo, "2024-01-01T00:00:00Z"))
+	assert.Equal(t, "2024-01-01T00:00:00Z", repo.ContributorsUpdatedAt)
+	contributors, err := contributorRepository.GetRepositoryContributors(repo.ID)
+	assert.NoError(t, err)
+	assert.Len(t, contributors, 1)
+	assert.Equal(t, 3, contributors[0].StatsCommits)
+
+	requests = 0
+	assert.NoError(t, repoDiscovery.SyncContributors(context.Background(), repo, "2024-01-01T00:00:00Z"))
+	assert.Equal(t, 0, requests)
+	assert.NoError(t, repoDiscovery.SyncContributors(context.Background(), repo, "2024-02-01T00:00:00Z"))
+	assert.Equal(t, 2, requests)
+}
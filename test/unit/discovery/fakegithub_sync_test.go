@@ -0,0 +1,132 @@
+package discovery_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/interface/database"
+	tasks "github.com/midedickson/github-service/interface/task-manager"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/test/fakegithub"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// startTaskManager wires the discovery services to github and a fresh database the way main does,
+// and starts the worker for newly requested repositories until the test ends.
+func startTaskManager(t *testing.T, github *fakegithub.Server) (*gorm.DB, *tasks.TaskManager) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Commit{}, &database.CommitFile{},
+		&database.AuthorCommitCount{}, &database.AuthorChurn{}, &database.FileChurn{}, &database.Branch{}, &database.Tag{},
+		&database.CommitBranch{}, &database.Release{}, &database.PullRequest{}, &database.Issue{}, &database.Contributor{},
+		&database.RepositoryLanguage{}))
+
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL, Tokens: []string{"token"}})
+	repoRepository := database.NewSqliteRepoRepository(db)
+	commitRepository := database.NewSqliteCommitRepository(db)
+	commitManager := discovery.NewCommitDiscoveryService(repoRepository, repoRequester, commitRepository,
+		database.NewSqliteBranchRepository(db), "", "", []string{"*"})
+	repoDiscovery := discovery.NewRepositoryDiscoveryService(repoRequester, database.NewSqliteUserRepository(db), repoRepository,
+		commitRepository, database.NewSqliteReleaseRepository(db), database.NewSqlitePullRequestRepository(db),
+		database.NewSqliteIssueRepository(db), database.NewSqliteContributorRepository(db), commitManager)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	taskManager := tasks.NewTaskManager(ctx, repoDiscovery, commitManager, 10*time.Second, 0)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go taskManager.FetchNewlyRequestedRepo(&wg)
+	t.Cleanup(func() {
+		cancel()
+		wg.Wait()
+	})
+	return db, taskManager
+}
+
+func TestRequestedRepositorySyncsFromFakeGitHub(t *testing.T) {
+	github := fakegithub.NewServer()
+	defer github.Close()
+	repo := github.AddRepo("octocat", "hello")
+	repo.Description = "My first repository"
+	repo.Languages = map[string]int{"Go": 2048, "Shell": 512}
+	first := repo.Commit("main", fakegithub.Commit{Message: "initial commit", AuthorLogin: "octocat",
+		Files: []fakegithub.File{{Filename: "README.md", Status: "added", Additions: 10}}})
+	repo.Commit("main", fakegithub.Commit{Message: "add greeting", AuthorLogin: "hubot",
+		Files: []fakegithub.File{{Filename: "hello.go", Status: "added", Additions: 20}}})
+	repo.Branch("feature", first.SHA)
+	repo.Commit("feature", fakegithub.Commit{Message: "try something", AuthorLogin: "octocat"})
+	repo.Tag("v1.0.0", "main")
+	repo.AddRelease(fakegithub.Release{TagName: "v1.0.0", Name: "First release", Author: "octocat"})
+	repo.AddPullRequest(fakegithub.PullRequest{Title: "Try something", Author: "octocat", BaseRef: "main", HeadRef: "feature"})
+	repo.AddIssue(fakegithub.Issue{Title: "Say hello louder", Author: "hubot", Labels: []string{"enhancement"}})
+	// contributor statistics are still being computed on the first try, as is usual on GitHub
+	repo.StatsPending = 1
+
+	db, taskManager := startTaskManager(t, github)
+	user, err := database.NewSqliteUserRepository(db).CreateUser(&dto.CreateUserPayloadDTO{Username: "octocat"})
+	assert.NoError(t, err)
+	taskManager.AddRequestToFetchNewlyRequestedRepoQueue("octocat", "hello")
+
+	var contributors []*database.Contributor
+	assert.Eventually(t, func() bool {
+		dbRepo, err := database.NewSqliteRepoRepository(db).GetRepository(user.ID, "hello")
+		if err != nil || dbRepo == nil {
+			return false
+		}
+		contributors, err = database.NewSqliteContributorRepository(db).GetRepositoryContributors(dbRepo.ID)
+		return err == nil && len(contributors) > 0
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.NoError(t, taskManager.TakeRepoRequestError("octocat", "hello"))
+
+	dbRepo, err := database.NewSqliteRepoRepository(db).GetRepository(user.ID, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "My first repository", dbRepo.Description)
+	commits, err := database.NewSqliteCommitRepository(db).GetRepositoryCommits(dbRepo.ID)
+	assert.NoError(t, err)
+	assert.Len(t, commits, 3)
+	branches, err := database.NewSqliteBranchRepository(db).GetRepositoryBranches(dbRepo.ID)
+	assert.NoError(t, err)
+	assert.Len(t, branches, 2)
+	releases, err := database.NewSqliteReleaseRepository(db).GetRepositoryReleases(dbRepo.ID)
+	assert.NoError(t, err)
+	assert.Len(t, releases, 1)
+	pullRequests, err := database.NewSqlitePullRequestRepository(db).GetRepositoryPullRequests(dbRepo.ID, "")
+	assert.NoError(t, err)
+	assert.Len(t, pullRequests, 1)
+	var issues int64
+	assert.NoError(t, db.Model(&database.Issue{}).Where("repository_id = ?", dbRepo.ID).Count(&issues).Error)
+	// the pull request GitHub also lists as an issue is stored only as a pull request
+	assert.EqualValues(t, 1, issues)
+	assert.Len(t, contributors, 2)
+	languages, err := database.NewSqliteRepoRepository(db).GetRepositoryLanguages(dbRepo.ID)
+	assert.NoError(t, err)
+	assert.Len(t, languages, 2)
+	assert.Equal(t, "Go", languages[0].Language)
+	assert.Equal(t, 2048, languages[0].Bytes)
+	// the languages are remembered as fetched at the repository's current update
+	assert.Equal(t, dbRepo.RemoteUpdatedAt, dbRepo.LanguagesUpdatedAt)
+}
+
+func TestRequestedRepositoryMissingOnFakeGitHub(t *testing.T) {
+	github := fakegithub.NewServer()
+	defer github.Close()
+	github.AddUser("octocat")
+
+	db, taskManager := startTaskManager(t, github)
+	_, err := database.NewSqliteUserRepository(db).CreateUser(&dto.CreateUserPayloadDTO{Username: "octocat"})
+	assert.NoError(t, err)
+	taskManager.AddRequestToFetchNewlyRequestedRepoQueue("octocat", "missing")
+
+	assert.Eventually(t, func() bool {
+		err = taskManager.TakeRepoRequestError("octocat", "missing")
+		return err != nil
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.True(t, errors.Is(err, utils.ErrRepoNotFound))
+}
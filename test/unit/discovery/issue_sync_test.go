@@ -0,0 +1,64 @@
+package discovery_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestSyncIssuesResumesAfterFailedPage(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Issue{}))
+	dbRepo := &database.Repository{Name: "testrepo"}
+	assert.NoError(t, db.Create(dbRepo).Error)
+
+	// issues are listed newest created first, so the failed page holds the most recently updated one
+	failSecondPage := true
+	requestedSince := []string{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			requestedSince = append(requestedSince, r.URL.Query().Get("since"))
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?state=all&page=2>; rel="next"`, server.URL, r.URL.Path))
+			fmt.Fprint(w, `[{"id": 3, "number": 3, "state": "open", "updated_at": "2024-01-03T00:00:00Z"}, {"id": 2, "number": 2, "state": "open", "updated_at": "2024-01-02T00:00:00Z"}]`)
+			return
+		}
+		if failSecondPage {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 1, "number": 1, "state": "closed", "updated_at": "2024-03-01T00:00:00Z"}]`)
+	}))
+	defer server.Close()
+
+	issueRepository := database.NewSqliteIssueRepository(db)
+	repoRepository := database.NewSqliteRepoRepository(db)
+	repoDiscovery := discovery.NewRepositoryDiscoveryService(requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL}),
+		nil, repoRepository, nil, nil, nil, issueRepository, nil, nil)
+	repo := &entity.Repository{ID: dbRepo.ID, Name: "testrepo", Owner: &entity.User{Username: "testuser"}}
+
+	assert.Error(t, repoDiscovery.SyncIssues(context.Background(), repo))
+	assert.Empty(t, repo.IssuesSyncedAt)
+
+	failSecondPage = false
+	assert.NoError(t, repoDiscovery.SyncIssues(context.Background(), repo))
+	stored, err := issueRepository.SearchIssues(repo.ID, &utils.IssueSearchParams{})
+	assert.NoError(t, err)
+	assert.Len(t, stored, 3)
+
+	// only a complete sync moves the next one forward, to the newest update it stored
+	assert.NoError(t, repoDiscovery.SyncIssues(context.Background(), repo))
+	assert.Equal(t, []string{"", "", "2024-03-01T00:00:00Z"}, requestedSince)
+}
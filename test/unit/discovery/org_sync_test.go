@@ -0,0 +1,69 @@
+package discovery_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/test/fakegithub"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestOrganizationRepositoriesAreListedThroughTheOrgEndpoint(t *testing.T) {
+	github := fakegithub.NewServer()
+	defer github.Close()
+	github.AddOrg("acme")
+	internal := github.AddRepo("acme", "internal-tools")
+	internal.Private = true
+	internal.Commit("main", fakegithub.Commit{Message: "initial commit", AuthorLogin: "octocat"})
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Commit{}, &database.CommitFile{},
+		&database.AuthorCommitCount{}, &database.Branch{}, &database.Tag{}, &database.CommitBranch{}, &database.Contributor{}))
+	userRepository := database.NewSqliteUserRepository(db)
+	repoRepository := database.NewSqliteRepoRepository(db)
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL, Tokens: []string{"token"}})
+	commitManager := discovery.NewCommitDiscoveryService(repoRepository, repoRequester, database.NewSqliteCommitRepository(db),
+		database.NewSqliteBranchRepository(db), "", "", []string{"*"})
+	repoDiscovery := discovery.NewRepositoryDiscoveryService(repoRequester, userRepository, repoRepository, nil, nil, nil, nil,
+		database.NewSqliteContributorRepository(db), commitManager)
+
+	org, err := userRepository.CreateUser(&dto.CreateUserPayloadDTO{Username: "acme", FullName: "Acme Corp"})
+	assert.NoError(t, err)
+	// the sync paces itself between repositories; the deadline cuts that short once the one repository is stored
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	repoDiscovery.GetAllUserRepositories(ctx, org.ToEntity())
+
+	stored, err := userRepository.GetUser("acme")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.OwnerTypeOrganization, stored.OwnerType)
+	repo, err := repoRepository.GetRepository(org.ID, "internal-tools")
+	assert.NoError(t, err)
+	assert.NotNil(t, repo)
+
+	// the type is looked up once; later syncs go straight to the organization's listing
+	ctx, cancel = context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	repoDiscovery.GetAllUserRepositories(ctx, org.ToEntity())
+	var ownerLookups, orgListings int
+	for _, request := range github.Requests() {
+		if request == "GET /users/acme" {
+			ownerLookups++
+		}
+		if strings.HasPrefix(request, "GET /orgs/acme/repos") {
+			orgListings++
+		}
+	}
+	assert.Equal(t, 1, ownerLookups)
+	assert.Equal(t, 2, orgListings)
+}
@@ -0,0 +1,107 @@
+package discovery_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestSyncPullRequestsStopsAtLastUpdate(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.PullRequest{}))
+	dbRepo := &database.Repository{Name: "testrepo"}
+	assert.NoError(t, db.Create(dbRepo).Error)
+
+	pages := map[string]string{
+		"":  `[{"id": 3, "number": 3, "state": "open", "updated_at": "2024-03-01T00:00:00Z"}, {"id": 2, "number": 2, "state": "closed", "updated_at": "2024-02-01T00:00:00Z"}]`,
+		"2": `[{"id": 1, "number": 1, "state": "closed", "updated_at": "2024-01-01T00:00:00Z"}]`,
+	}
+	requestedPages := []string{}
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		requestedPages = append(requestedPages, page)
+		if page == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?state=all&page=2>; rel="next"`, server.URL, r.URL.Path))
+		}
+		fmt.Fprint(w, pages[page])
+	}))
+	defer server.Close()
+
+	pullRequestRepository := database.NewSqlitePullRequestRepository(db)
+	repoDiscovery := discovery.NewRepositoryDiscoveryService(requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL}),
+		nil, database.NewSqliteRepoRepository(db), nil, nil, pullRequestRepository, nil, nil, nil)
+	repo := &entity.Repository{ID: dbRepo.ID, Name: "testrepo", Owner: &entity.User{Username: "testuser"}}
+
+	assert.NoError(t, repoDiscovery.SyncPullRequests(context.Background(), repo))
+	stored, err := pullRequestRepository.GetRepositoryPullRequests(repo.ID, "")
+	assert.NoError(t, err)
+	assert.Len(t, stored, 3)
+	assert.Equal(t, []string{"", "2"}, requestedPages)
+
+	// only pull requests updated since the last sync are stored again, and paging stops there
+	pages[""] = `[{"id": 4, "number": 4, "state": "open", "updated_at": "2024-04-01T00:00:00Z"}, {"id": 3, "number": 3, "state": "closed", "updated_at": "2024-03-01T00:00:00Z"}, {"id": 2, "number": 2, "state": "closed", "updated_at": "2024-02-01T00:00:00Z"}]`
+	requestedPages = nil
+	assert.NoError(t, repoDiscovery.SyncPullRequests(context.Background(), repo))
+
+	assert.Equal(t, []string{""}, requestedPages)
+	open, err := pullRequestRepository.GetRepositoryPullRequests(repo.ID, "open")
+	assert.NoError(t, err)
+	assert.Len(t, open, 1)
+	assert.Equal(t, 4, open[0].Number)
+}
+
+func TestSyncPullRequestsResumesAfterFailedPage(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.PullRequest{}))
+	dbRepo := &database.Repository{Name: "testrepo"}
+	assert.NoError(t, db.Create(dbRepo).Error)
+
+	failSecondPage := true
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?state=all&page=2>; rel="next"`, server.URL, r.URL.Path))
+			fmt.Fprint(w, `[{"id": 3, "number": 3, "state": "open", "updated_at": "2024-03-01T00:00:00Z"}, {"id": 2, "number": 2, "state": "closed", "updated_at": "2024-02-01T00:00:00Z"}]`)
+			return
+		}
+		if failSecondPage {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+		fmt.Fprint(w, `[{"id": 1, "number": 1, "state": "closed", "updated_at": "2024-01-01T00:00:00Z"}]`)
+	}))
+	defer server.Close()
+
+	pullRequestRepository := database.NewSqlitePullRequestRepository(db)
+	repoRepository := database.NewSqliteRepoRepository(db)
+	repoDiscovery := discovery.NewRepositoryDiscoveryService(requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL}),
+		nil, repoRepository, nil, nil, pullRequestRepository, nil, nil, nil)
+	repo := &entity.Repository{ID: dbRepo.ID, Name: "testrepo", Owner: &entity.User{Username: "testuser"}}
+
+	assert.Error(t, repoDiscovery.SyncPullRequests(context.Background(), repo))
+	assert.Empty(t, repo.PullRequestsSyncedAt)
+
+	// the pull requests of the failed page are older than the stored ones, yet the next sync still reaches them
+	failSecondPage = false
+	assert.NoError(t, repoDiscovery.SyncPullRequests(context.Background(), repo))
+	stored, err := pullRequestRepository.GetRepositoryPullRequests(repo.ID, "")
+	assert.NoError(t, err)
+	assert.Len(t, stored, 3)
+
+	synced, err := repoRepository.GetAllRepositories()
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-03-01T00:00:00Z", synced[0].PullRequestsSyncedAt)
+}
@@ -0,0 +1,72 @@
+package discovery_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// syncCommits runs a commit sync of testuser/testrepo into a fresh database through transport,
+// returning the commit repository to inspect the result.
+func syncCommits(t *testing.T, baseURL string, transport *requester.CassetteTransport) *database.SqliteCommitRepository {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Commit{}, &database.CommitFile{},
+		&database.AuthorCommitCount{}, &database.AuthorChurn{}, &database.FileChurn{},
+		&database.Branch{}, &database.Tag{}, &database.CommitBranch{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	repoRepository := database.NewSqliteRepoRepository(db)
+	assert.NoError(t, db.Create(&database.Repository{OwnerID: user.ID, Name: "testrepo", DefaultBranch: "main"}).Error)
+	commitRepository := database.NewSqliteCommitRepository(db)
+	commitDiscovery := discovery.NewCommitDiscoveryService(repoRepository,
+		requester.NewRepositoryRequester(&requester.Options{BaseURL: baseURL, Transport: transport}),
+		commitRepository, database.NewSqliteBranchRepository(db), "", "", []string{"*"})
+
+	dbRepo, err := repoRepository.GetRepository(user.ID, "testrepo")
+	assert.NoError(t, err)
+	repo := dbRepo.ToEntity()
+	repo.Owner = &entity.User{ID: user.ID, Username: "testuser"}
+	assert.NoError(t, commitDiscovery.GetCommitsForNewRepo(context.Background(), repo))
+	return commitRepository
+}
+
+func TestCommitSyncReplaysFromCassettes(t *testing.T) {
+	dir := t.TempDir()
+	server := httptest.NewServer(&fakeBranchServer{
+		branches: map[string][]string{
+			"main":    {"c3", "c2", "c1"},
+			"feature": {"c4", "c2", "c1"},
+		},
+		listed: map[string]int{},
+	})
+	recorder, err := requester.NewCassetteTransport(requester.CassetteRecord, dir, nil)
+	assert.NoError(t, err)
+	recorded := syncCommits(t, server.URL, recorder)
+	server.Close()
+
+	// the same sync, with GitHub replaced by what it answered the first time
+	replayer, err := requester.NewCassetteTransport(requester.CassetteReplay, dir, nil)
+	assert.NoError(t, err)
+	replayed := syncCommits(t, server.URL, replayer)
+
+	// each sync stores testrepo in a fresh database, as its first repository
+	recordedCommits, err := recorded.GetRepositoryCommits(1)
+	assert.NoError(t, err)
+	replayedCommits, err := replayed.GetRepositoryCommits(1)
+	assert.NoError(t, err)
+	assert.Len(t, replayedCommits, 4)
+	assert.Len(t, replayedCommits, len(recordedCommits))
+	for i := range recordedCommits {
+		assert.Equal(t, recordedCommits[i].SHA, replayedCommits[i].SHA)
+		assert.Equal(t, recordedCommits[i].Additions, replayedCommits[i].Additions)
+	}
+}
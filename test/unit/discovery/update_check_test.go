@@ -0,0 +1,56 @@
+package discovery_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/midedickson/github-service/discovery"
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/test/fakegithub"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestUpdateCheckStoresMetadataMissingFromAnUnmodifiedRepository(t *testing.T) {
+	github := fakegithub.NewServer()
+	defer github.Close()
+	repo := github.AddRepo("octocat", "hello")
+	repo.Topics = []string{"go", "api"}
+	repo.License = "MIT"
+	repo.Commit("main", fakegithub.Commit{Message: "initial commit", AuthorLogin: "octocat"})
+
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Release{}, &database.PullRequest{},
+		&database.Issue{}, &database.Contributor{}, &database.RepositoryLanguage{}, &database.HTTPCacheEntry{}))
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL, Tokens: []string{"token"},
+		Cache: database.NewSqliteHTTPCacheRepository(db)})
+	repoRepository := database.NewSqliteRepoRepository(db)
+	repoDiscovery := discovery.NewRepositoryDiscoveryService(repoRequester, database.NewSqliteUserRepository(db), repoRepository,
+		nil, database.NewSqliteReleaseRepository(db), database.NewSqlitePullRequestRepository(db),
+		database.NewSqliteIssueRepository(db), database.NewSqliteContributorRepository(db), nil)
+
+	user, err := database.NewSqliteUserRepository(db).CreateUser(&dto.CreateUserPayloadDTO{Username: "octocat"})
+	assert.NoError(t, err)
+	info, err := repoRequester.GetRepositoryInfo(context.Background(), "octocat", "hello")
+	assert.NoError(t, err)
+	stored, err := repoRepository.StoreRepositoryInfo(info, user.ToEntity())
+	assert.NoError(t, err)
+	// a record stored before the metadata was, with the response it came from still cached
+	assert.NoError(t, db.Model(stored).Updates(map[string]interface{}{"default_branch": "", "topics": "", "license": ""}).Error)
+
+	assert.NoError(t, repoDiscovery.CheckForUpdateOnAllRepo(context.Background()))
+
+	stored, err = repoRepository.GetRepository(user.ID, "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "main", stored.DefaultBranch)
+	assert.Equal(t, []string{"go", "api"}, stored.ToEntity().Topics)
+	assert.Equal(t, "MIT", stored.License)
+	// GitHub still has nothing new to send, which is why the record had to be compared
+	again, err := repoRequester.GetRepositoryInfo(context.Background(), "octocat", "hello")
+	assert.NoError(t, err)
+	assert.True(t, again.NotModified)
+}
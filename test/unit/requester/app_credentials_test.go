@@ -0,0 +1,239 @@
+package requester_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeAppServer stands in for the GitHub App endpoints, verifying app JWTs
+// with the public half of the test key and issuing installation tokens.
+type fakeAppServer struct {
+	t              *testing.T
+	publicKey      *rsa.PublicKey
+	tokenLifetime  time.Duration
+	mu             sync.Mutex
+	tokensIssued   int
+	lastAuthHeader string
+	// installationID is the installation on testorg, 42 when unset
+	installationID int
+	lookups        int
+	// requested, when set, is signalled as a token is requested, which is then held until release is closed
+	requested chan struct{}
+	release   chan struct{}
+}
+
+func (f *fakeAppServer) verifyJWT(authHeader string) bool {
+	jwt := strings.TrimPrefix(authHeader, "Bearer ")
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return false
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if rsa.VerifyPKCS1v15(f.publicKey, crypto.SHA256, digest[:], signature) != nil {
+		return false
+	}
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims map[string]interface{}
+	json.Unmarshal(payload, &claims)
+	return claims["iss"] == "12345"
+}
+
+func (f *fakeAppServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch {
+	case r.URL.Path == "/orgs/testorg/installation":
+		assert.True(f.t, f.verifyJWT(r.Header.Get("Authorization")))
+		f.mu.Lock()
+		f.lookups++
+		f.mu.Unlock()
+		fmt.Fprintf(w, `{"id": %d}`, f.installation())
+	case strings.HasSuffix(r.URL.Path, "/installation"):
+		f.mu.Lock()
+		f.lookups++
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusNotFound)
+	case r.URL.Path == fmt.Sprintf("/app/installations/%d/access_tokens", f.installation()) && r.Method == http.MethodPost:
+		assert.True(f.t, f.verifyJWT(r.Header.Get("Authorization")))
+		if f.requested != nil {
+			f.requested <- struct{}{}
+			<-f.release
+		}
+		f.mu.Lock()
+		f.tokensIssued++
+		issued := f.tokensIssued
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprintf(w, `{"token": "ghs_%d", "expires_at": %q}`, issued, time.Now().Add(f.tokenLifetime).Format(time.RFC3339))
+	case strings.HasSuffix(r.URL.Path, "/testrepo"):
+		f.lastAuthHeader = r.Header.Get("Authorization")
+		fmt.Fprint(w, `{"id": 1, "name": "testrepo"}`)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeAppServer) installation() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.installationID == 0 {
+		return 42
+	}
+	return f.installationID
+}
+
+func generateTestKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, keyPEM
+}
+
+func TestAppCredentialProvider(t *testing.T) {
+	key, keyPEM := generateTestKey(t)
+
+	t.Run("exchanges the app jwt for a cached installation token", func(t *testing.T) {
+		fake := &fakeAppServer{t: t, publicKey: &key.PublicKey, tokenLifetime: time.Hour}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		credentials, err := requester.NewAppCredentialProvider("12345", keyPEM, server.URL)
+		assert.NoError(t, err)
+		repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, Credentials: credentials})
+
+		for i := 0; i < 2; i++ {
+			_, err = repoRequester.GetRepositoryInfo(context.Background(), "testorg", "testrepo")
+			assert.NoError(t, err)
+		}
+		assert.Equal(t, "Bearer ghs_1", fake.lastAuthHeader)
+		assert.Equal(t, 1, fake.tokensIssued)
+	})
+
+	t.Run("refreshes installation tokens before they expire", func(t *testing.T) {
+		fake := &fakeAppServer{t: t, publicKey: &key.PublicKey, tokenLifetime: time.Minute}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		credentials, err := requester.NewAppCredentialProvider("12345", keyPEM, server.URL)
+		assert.NoError(t, err)
+
+		first, err := credentials.Token(context.Background(), "testorg")
+		assert.NoError(t, err)
+		second, err := credentials.Token(context.Background(), "testorg")
+		assert.NoError(t, err)
+		assert.Equal(t, "ghs_1", first)
+		assert.Equal(t, "ghs_2", second)
+	})
+
+	t.Run("refreshes a token once for concurrent callers without blocking others", func(t *testing.T) {
+		fake := &fakeAppServer{t: t, publicKey: &key.PublicKey, tokenLifetime: time.Hour,
+			requested: make(chan struct{}, 1), release: make(chan struct{})}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		credentials, err := requester.NewAppCredentialProvider("12345", keyPEM, server.URL)
+		assert.NoError(t, err)
+
+		tokens := make([]string, 5)
+		var wg sync.WaitGroup
+		for i := range tokens {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				token, err := credentials.Token(context.Background(), "testorg")
+				assert.NoError(t, err)
+				tokens[i] = token
+			}(i)
+		}
+		<-fake.requested
+		// the refresh in flight does not hold up anything that only reads the cache
+		assert.Equal(t, "app installation", credentials.Label("ghs_1"))
+		time.Sleep(50 * time.Millisecond)
+		close(fake.release)
+		wg.Wait()
+
+		assert.Equal(t, []string{"ghs_1", "ghs_1", "ghs_1", "ghs_1", "ghs_1"}, tokens)
+		assert.Equal(t, 1, fake.tokensIssued)
+		assert.Equal(t, "app installation for testorg", credentials.Label("ghs_1"))
+	})
+
+	t.Run("looks the installation up again once it is gone", func(t *testing.T) {
+		fake := &fakeAppServer{t: t, publicKey: &key.PublicKey, tokenLifetime: time.Minute}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		credentials, err := requester.NewAppCredentialProvider("12345", keyPEM, server.URL)
+		assert.NoError(t, err)
+
+		_, err = credentials.Token(context.Background(), "testorg")
+		assert.NoError(t, err)
+		// reinstalled under another ID
+		fake.mu.Lock()
+		fake.installationID = 43
+		fake.mu.Unlock()
+		token, err := credentials.Token(context.Background(), "testorg")
+		assert.NoError(t, err)
+		assert.Equal(t, "ghs_2", token)
+		// found on the org after missing on the user, both times
+		assert.Equal(t, 4, fake.lookups)
+	})
+
+	t.Run("reports owners the app is not installed for", func(t *testing.T) {
+		fake := &fakeAppServer{t: t, publicKey: &key.PublicKey, tokenLifetime: time.Hour}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		credentials, err := requester.NewAppCredentialProvider("12345", keyPEM, server.URL)
+		assert.NoError(t, err)
+
+		for i := 0; i < 2; i++ {
+			_, err = credentials.Token(context.Background(), "someone")
+			assert.ErrorIs(t, err, requester.ErrAppNotInstalled)
+		}
+		// the second call does not look again
+		assert.Equal(t, 2, fake.lookups)
+		assert.Equal(t, 0, fake.tokensIssued)
+	})
+
+	t.Run("falls back to personal tokens for owners the app is not installed for", func(t *testing.T) {
+		fake := &fakeAppServer{t: t, publicKey: &key.PublicKey, tokenLifetime: time.Hour}
+		server := httptest.NewServer(fake)
+		defer server.Close()
+
+		credentials, err := requester.NewAppCredentialProvider("12345", keyPEM, server.URL)
+		assert.NoError(t, err)
+		repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, Credentials: credentials, Tokens: []string{"ghp_personal"}})
+
+		_, err = repoRequester.GetRepositoryInfo(context.Background(), "someone", "testrepo")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer ghp_personal", fake.lastAuthHeader)
+
+		_, err = repoRequester.GetRepositoryInfo(context.Background(), "testorg", "testrepo")
+		assert.NoError(t, err)
+		assert.Equal(t, "Bearer ghs_1", fake.lastAuthHeader)
+	})
+
+	t.Run("rejects a key that is not PEM encoded", func(t *testing.T) {
+		_, err := requester.NewAppCredentialProvider("12345", []byte("not a key"), "")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,58 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCassetteTransportRecordsAndReplays(t *testing.T) {
+	dir := t.TempDir()
+	served := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		served++
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		fmt.Fprintf(w, `{"id": 1, "name": "project", "stargazers_count": %d}`, served)
+	}))
+
+	recorder, err := requester.NewCassetteTransport(requester.CassetteRecord, dir, nil)
+	assert.NoError(t, err)
+	recording := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, Tokens: []string{"secret-token"}, Transport: recorder})
+	for stars := 1; stars <= 2; stars++ {
+		info, err := recording.GetRepositoryInfo(context.Background(), "testuser", "project")
+		assert.NoError(t, err)
+		assert.Equal(t, stars, info.StarsCount)
+	}
+	server.Close()
+
+	files, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, files, 1)
+	cassette, err := os.ReadFile(filepath.Join(dir, files[0].Name()))
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(files[0].Name(), "GET_127.0.0.1_"))
+	assert.NotContains(t, string(cassette), "secret-token")
+
+	// the server is gone, so everything below is served from the cassette
+	replayer, err := requester.NewCassetteTransport(requester.CassetteReplay, dir, nil)
+	assert.NoError(t, err)
+	replaying := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, Transport: replayer})
+	for _, stars := range []int{1, 2, 2} {
+		// responses come back in the order they were recorded, the last one repeating once they run out
+		info, err := replaying.GetRepositoryInfo(context.Background(), "testuser", "project")
+		assert.NoError(t, err)
+		assert.Equal(t, stars, info.StarsCount)
+	}
+	assert.Equal(t, 4999, replaying.RateLimits()[0].Remaining)
+
+	_, err = replaying.GetRepositoryInfo(context.Background(), "testuser", "unrecorded")
+	assert.ErrorIs(t, err, requester.ErrNoRecording)
+}
@@ -0,0 +1,51 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetCommit(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/testuser/testrepo/commits/abc123", r.URL.Path)
+		if r.URL.Query().Get("page") == "2" {
+			fmt.Fprint(w, `{"sha": "abc123", "files": [{"filename": "b.go", "status": "added", "additions": 3, "deletions": 0}]}`)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, server.URL, r.URL.Path))
+		fmt.Fprint(w, `{
+			"sha": "abc123",
+			"commit": {"message": "Fix parser", "author": {"name": "testuser", "email": "test@example.com", "date": "2024-07-01T00:00:00Z"},
+				"committer": {"name": "GitHub", "email": "noreply@github.com", "date": "2024-07-02T00:00:00Z"}},
+			"author": {"login": "testuser", "id": 42},
+			"committer": {"login": "web-flow", "id": 19864447},
+			"stats": {"additions": 13, "deletions": 4, "total": 17},
+			"files": [{"filename": "a.go", "status": "modified", "additions": 10, "deletions": 4}]
+		}`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	commit, err := repoRequester.GetCommit(context.Background(), "testuser", "testrepo", "abc123")
+	assert.NoError(t, err)
+	assert.Equal(t, "abc123", commit.SHA)
+	assert.Equal(t, "testuser", commit.Author)
+	assert.Equal(t, "test@example.com", commit.AuthorEmail)
+	assert.Equal(t, 42, commit.AuthorID)
+	assert.Equal(t, "2024-07-01T00:00:00Z", commit.Date)
+	assert.Equal(t, "GitHub", commit.Committer)
+	assert.Equal(t, "web-flow", commit.CommitterLogin)
+	assert.Equal(t, "2024-07-02T00:00:00Z", commit.CommitterDate)
+	assert.Equal(t, 13, commit.Additions)
+	assert.Equal(t, 4, commit.Deletions)
+	assert.Len(t, commit.Files, 2)
+	assert.Equal(t, "a.go", commit.Files[0].Filename)
+	assert.Equal(t, "b.go", commit.Files[1].Filename)
+}
@@ -0,0 +1,61 @@
+package requester_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpstreamErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/testuser/unauthorized":
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"message": "Bad credentials"}`)
+		case "/repos/testuser/forbidden":
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, `{"message": "Resource not accessible by integration"}`)
+		case "/repos/testuser/invalid":
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			fmt.Fprint(w, `{"message": "Validation Failed"}`)
+		case "/repos/testuser/garbled":
+			fmt.Fprint(w, `{"id": `)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	cases := []struct {
+		repo   string
+		kind   error
+		status int
+	}{
+		{"unauthorized", utils.ErrUnauthorized, http.StatusUnauthorized},
+		{"forbidden", utils.ErrForbidden, http.StatusForbidden},
+		{"invalid", utils.ErrInvalidRequest, http.StatusUnprocessableEntity},
+		{"garbled", utils.ErrInvalidResponse, http.StatusOK},
+	}
+	for _, c := range cases {
+		t.Run(c.repo, func(t *testing.T) {
+			_, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", c.repo)
+			assert.ErrorIs(t, err, c.kind)
+			var upstream *utils.UpstreamError
+			assert.True(t, errors.As(err, &upstream))
+			assert.Equal(t, c.status, upstream.StatusCode)
+		})
+	}
+
+	t.Run("keeps the message github sent", func(t *testing.T) {
+		_, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "unauthorized")
+		assert.Contains(t, err.Error(), "Bad credentials")
+	})
+}
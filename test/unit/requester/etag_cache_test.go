@@ -0,0 +1,84 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestConditionalRepositoryInfo(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.HTTPCacheEntry{}))
+
+	var conditionalHeaders []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conditionalHeaders = append(conditionalHeaders, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id": 1, "name": "testrepo", "updated_at": "2024-07-01T00:00:00Z"}`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{
+		BaseURL: server.URL,
+		Cache:   database.NewSqliteHTTPCacheRepository(db),
+	})
+
+	first, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+	assert.NoError(t, err)
+	assert.False(t, first.NotModified)
+
+	second, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+	assert.NoError(t, err)
+	assert.True(t, second.NotModified)
+	assert.Equal(t, "testrepo", second.Name)
+	assert.Equal(t, first.UpdatedAt, second.UpdatedAt)
+
+	assert.Equal(t, []string{"", `"v1"`}, conditionalHeaders)
+}
+
+func TestNotModifiedResponsesDoNotSpendBudget(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.HTTPCacheEntry{}))
+
+	reset := time.Now().Add(time.Hour)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ratelimit-limit", "5000")
+		w.Header().Set("x-ratelimit-remaining", "4999")
+		w.Header().Set("x-ratelimit-reset", strconv.FormatInt(reset.Unix(), 10))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		fmt.Fprint(w, `{"id": 1, "name": "testrepo"}`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{
+		BaseURL: server.URL,
+		Tokens:  []string{"token"},
+		Cache:   database.NewSqliteHTTPCacheRepository(db),
+	})
+
+	for i := 0; i < 3; i++ {
+		_, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+		assert.NoError(t, err)
+	}
+	limits := repoRequester.RateLimits()
+	assert.Len(t, limits, 1)
+	assert.Equal(t, 4999, limits[0].Remaining)
+}
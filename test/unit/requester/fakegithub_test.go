@@ -0,0 +1,126 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/test/fakegithub"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRequesterAgainstFakeGitHub(t *testing.T) {
+	github := fakegithub.NewServer()
+	defer github.Close()
+	repo := github.AddRepo("octocat", "hello")
+	for i := 1; i <= 250; i++ {
+		repo.Commit("main", fakegithub.Commit{Message: fmt.Sprintf("change %d", i), AuthorLogin: "octocat"})
+	}
+	repo.Branch("feature", "main")
+	feature := repo.Commit("feature", fakegithub.Commit{
+		Message:     "feature work",
+		AuthorLogin: "hubot",
+		Files:       []fakegithub.File{{Filename: "main.go", Additions: 7, Deletions: 2}},
+	})
+
+	t.Run("pages through the history of a branch", func(t *testing.T) {
+		repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL, Tokens: []string{"token"}})
+		var pages, commits int
+		err := repoRequester.GetRepositoryCommits(context.Background(), "octocat", "hello", nil, func(page *[]dto.CommitResponseDTO) error {
+			pages++
+			commits += len(*page)
+			return nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, pages)
+		assert.Equal(t, 250, commits)
+
+		detail, err := repoRequester.GetCommit(context.Background(), "octocat", "hello", feature.SHA)
+		assert.NoError(t, err)
+		assert.Equal(t, 7, detail.Additions)
+		assert.Equal(t, "hubot", detail.AuthorLogin)
+		assert.Equal(t, fakegithub.DefaultRateLimit-4, github.Remaining("token"))
+	})
+
+	t.Run("unchanged resources are answered from the cache for free", func(t *testing.T) {
+		db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+		assert.NoError(t, err)
+		assert.NoError(t, db.AutoMigrate(&database.HTTPCacheEntry{}))
+		repoRequester := requester.NewRepositoryRequester(&requester.Options{
+			BaseURL: github.URL,
+			Tokens:  []string{"cached"},
+			Cache:   database.NewSqliteHTTPCacheRepository(db),
+		})
+
+		first, err := repoRequester.GetRepositoryInfo(context.Background(), "octocat", "hello")
+		assert.NoError(t, err)
+		second, err := repoRequester.GetRepositoryInfo(context.Background(), "octocat", "hello")
+		assert.NoError(t, err)
+		assert.False(t, first.NotModified)
+		assert.True(t, second.NotModified)
+		assert.Equal(t, "octocat/hello", second.FullName)
+		assert.Equal(t, fakegithub.DefaultRateLimit-1, github.Remaining("cached"))
+	})
+
+	t.Run("missing repositories and forbidden requests", func(t *testing.T) {
+		repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL})
+		_, err := repoRequester.GetRepositoryInfo(context.Background(), "octocat", "missing")
+		assert.ErrorIs(t, err, utils.ErrRepoNotFound)
+
+		github.FailNext("/repos/octocat/hello", http.StatusForbidden, 1)
+		_, err = repoRequester.GetRepositoryInfo(context.Background(), "octocat", "hello")
+		assert.ErrorIs(t, err, utils.ErrForbidden)
+		_, err = repoRequester.GetRepositoryInfo(context.Background(), "octocat", "hello")
+		assert.NoError(t, err)
+	})
+
+	t.Run("organizations show their private repositories to the token", func(t *testing.T) {
+		github.AddOrg("acme")
+		github.AddRepo("acme", "website")
+		github.AddRepo("acme", "internal-tools").Private = true
+		listed := func(repoRequester *requester.RepositoryRequester) []string {
+			var names []string
+			err := repoRequester.GetAllOrganizationRepositories(context.Background(), "acme", func(page *[]dto.RepositoryInfoResponseDTO) error {
+				for _, repository := range *page {
+					names = append(names, repository.Name)
+				}
+				return nil
+			})
+			assert.NoError(t, err)
+			return names
+		}
+
+		authenticated := requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL, Tokens: []string{"member"}})
+		owner, err := authenticated.GetOwner(context.Background(), "acme")
+		assert.NoError(t, err)
+		assert.Equal(t, dto.OwnerTypeOrganization, owner.Type)
+		assert.Equal(t, []string{"internal-tools", "website"}, listed(authenticated))
+		assert.Equal(t, []string{"website"}, listed(requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL})))
+	})
+
+	t.Run("an exhausted token waits for its window to reset", func(t *testing.T) {
+		github.SetRateLimit(1, time.Now().Add(time.Hour))
+		defer github.SetRateLimit(fakegithub.DefaultRateLimit, time.Now().Add(time.Hour))
+		repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL, Tokens: []string{"scarce"}})
+		before := len(github.Requests())
+
+		_, err := repoRequester.GetRepositoryInfo(context.Background(), "octocat", "hello")
+		assert.NoError(t, err)
+		assert.Equal(t, 0, repoRequester.RateLimits()[0].Remaining)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+		_, err = repoRequester.GetRepositoryInfo(ctx, "octocat", "hello")
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		// the governor held the second request back instead of spending it on a certain 403
+		assert.Len(t, github.Requests(), before+1)
+	})
+}
@@ -0,0 +1,131 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGiteaRequesterPagesWithLimit(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/repos/testuser/mirror/branches", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+		if r.URL.Query().Get("page") == "" {
+			w.Header().Set("Link", fmt.Sprintf(`<%s/api/v1/repos/testuser/mirror/branches?limit=2&page=2>; rel="next"`, server.URL))
+			fmt.Fprint(w, `[{"name": "main", "commit": {"id": "aaa"}, "protected": true}, {"name": "dev", "commit": {"id": "bbb"}}]`)
+			return
+		}
+		fmt.Fprint(w, `[{"name": "old", "commit": {"id": "ccc"}}]`)
+	}))
+	defer server.Close()
+	giteaRequester := requester.NewGiteaRequester(&requester.Options{BaseURL: server.URL + "/api/v1", PerPage: 2})
+
+	branches := []dto.BranchResponseDTO{}
+	err := giteaRequester.GetRepositoryBranches(context.Background(), "testuser", "mirror", func(page *[]dto.BranchResponseDTO) error {
+		branches = append(branches, *page...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.BranchResponseDTO{
+		{Name: "main", SHA: "aaa", Protected: true},
+		{Name: "dev", SHA: "bbb"},
+		{Name: "old", SHA: "ccc"},
+	}, branches)
+}
+
+func TestGiteaRequesterMapsRepositoryAndCommits(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/testuser/mirror":
+			fmt.Fprint(w, `{"id": 5, "name": "mirror", "full_name": "testuser/mirror", "stars_count": 4, "watchers_count": 2,
+				"open_issues_count": 1, "open_pr_counter": 2, "default_branch": "main", "updated_at": "2024-01-01T09:00:00+08:00"}`)
+		case "/repos/testuser/mirror/commits":
+			assert.Equal(t, "main", r.URL.Query().Get("sha"))
+			assert.Equal(t, "false", r.URL.Query().Get("stat"))
+			fmt.Fprint(w, `[{"sha": "abc", "html_url": "https://gitea.example.com/testuser/mirror/commit/abc",
+				"commit": {"message": "fix", "author": {"name": "Test User", "date": "2024-01-01T09:00:00+08:00"}}, "author": null}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL)
+		}
+	}))
+	defer server.Close()
+	giteaRequester := requester.NewGiteaRequester(&requester.Options{BaseURL: server.URL})
+
+	info, err := giteaRequester.GetRepositoryInfo(context.Background(), "testuser", "mirror")
+	assert.NoError(t, err)
+	assert.Equal(t, 4, info.StarsCount)
+	// like GitHub's, the open issue count includes open pull requests
+	assert.Equal(t, 3, info.OpenIssues)
+	assert.Equal(t, "2024-01-01T01:00:00Z", info.UpdatedAt)
+
+	commits := []dto.CommitResponseDTO{}
+	err = giteaRequester.GetRepositoryCommits(context.Background(), "testuser", "mirror", &dto.CommitQueryParams{SHA: "main"}, func(page *[]dto.CommitResponseDTO) error {
+		commits = append(commits, *page...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.CommitResponseDTO{
+		{SHA: "abc", Message: "fix", Author: "Test User", Date: "2024-01-01T01:00:00Z", URL: "https://gitea.example.com/testuser/mirror/commit/abc"},
+	}, commits)
+}
+
+func TestGiteaRequesterCountsCommitFileChangesFromDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/testuser/mirror/git/commits/abc":
+			fmt.Fprint(w, `{"sha": "abc", "commit": {"message": "tidy", "author": {"name": "Test User", "date": "2024-01-01T00:00:00Z"}},
+				"stats": {"total": 4, "additions": 1, "deletions": 3},
+				"files": [{"filename": "notes.md", "status": "modified"}, {"filename": "old.go", "status": "deleted"}]}`)
+		case "/repos/testuser/mirror/git/commits/abc.diff":
+			// a removed line that starts with "--" is still a deletion, not a file header
+			fmt.Fprint(w, "diff --git a/notes.md b/notes.md\n--- a/notes.md\n+++ b/notes.md\n@@ -1,2 +1,2 @@\n----\n+===\n"+
+				"diff --git a/old.go b/old.go\ndeleted file mode 100644\n--- a/old.go\n+++ /dev/null\n@@ -1,2 +0,0 @@\n-package old\n-\n")
+		default:
+			t.Errorf("unexpected request to %s", r.URL)
+		}
+	}))
+	defer server.Close()
+	giteaRequester := requester.NewGiteaRequester(&requester.Options{BaseURL: server.URL})
+
+	commit, err := giteaRequester.GetCommit(context.Background(), "testuser", "mirror", "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, commit.Additions)
+	assert.Equal(t, 3, commit.Deletions)
+	assert.Equal(t, []dto.CommitFileDTO{
+		{Filename: "notes.md", Status: "modified", Additions: 1, Deletions: 1, Changes: 2},
+		{Filename: "old.go", Status: "removed", Deletions: 2, Changes: 2},
+	}, commit.Files)
+}
+
+func TestGiteaRequesterTellsOrganizationsFromUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/platform":
+			fmt.Fprint(w, `{"id": 3, "username": "platform"}`)
+		case "/users/testuser":
+			fmt.Fprint(w, `{"id": 4, "username": "testuser"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			fmt.Fprint(w, `{"message": "GetOrgByName"}`)
+		}
+	}))
+	defer server.Close()
+	giteaRequester := requester.NewGiteaRequester(&requester.Options{BaseURL: server.URL})
+
+	org, err := giteaRequester.GetOwner(context.Background(), "platform")
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.OwnerResponseDTO{ID: 3, Login: "platform", Type: dto.OwnerTypeOrganization}, org)
+	user, err := giteaRequester.GetOwner(context.Background(), "testuser")
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.OwnerResponseDTO{ID: 4, Login: "testuser", Type: dto.OwnerTypeUser}, user)
+	_, err = giteaRequester.GetOwner(context.Background(), "nobody")
+	assert.ErrorIs(t, err, utils.ErrRepoNotFound)
+}
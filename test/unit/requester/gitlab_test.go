@@ -0,0 +1,129 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitLabRequesterMapsProjectInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the project is addressed by its URL-encoded path rather than its ID
+		assert.Equal(t, "/projects/testuser%2Fproject", r.URL.EscapedPath())
+		assert.Equal(t, "Bearer glpat-test", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"id": 42, "name": "Project", "path": "project", "path_with_namespace": "testuser/project",
+			"web_url": "https://gitlab.com/testuser/project", "star_count": 3, "forks_count": 1, "open_issues_count": 2,
+			"default_branch": "main", "forked_from_project": {"id": 7},
+			"created_at": "2024-01-01T10:00:00.000+02:00", "last_activity_at": "2024-02-01T00:00:00.000Z"}`)
+	}))
+	defer server.Close()
+	gitlabRequester := requester.NewGitLabRequester(&requester.Options{BaseURL: server.URL, Tokens: []string{"glpat-test"}})
+
+	info, err := gitlabRequester.GetRepositoryInfo(context.Background(), "testuser", "project")
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.RepositoryInfoResponseDTO{
+		ID: 42, Name: "project", FullName: "testuser/project", HtmlUrl: "https://gitlab.com/testuser/project",
+		URL: server.URL + "/projects/42", Fork: true, ForksCount: 1, StarsCount: 3, OpenIssues: 2, DefaultBranch: "main",
+		CreatedAt: "2024-01-01T08:00:00Z", UpdatedAt: "2024-02-01T00:00:00Z",
+	}, info)
+}
+
+func TestGitLabRequesterListsGroupProjectsAsOrganizationRepositories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/namespaces/acme%2Fplatform":
+			fmt.Fprint(w, `{"id": 9, "full_path": "acme/platform", "kind": "group"}`)
+		case "/groups/acme%2Fplatform/projects":
+			// projects of subgroups belong to the group as well
+			assert.Equal(t, "true", r.URL.Query().Get("include_subgroups"))
+			fmt.Fprint(w, `[{"id": 42, "path": "api", "path_with_namespace": "acme/platform/api"}]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL)
+		}
+	}))
+	defer server.Close()
+	gitlabRequester := requester.NewGitLabRequester(&requester.Options{BaseURL: server.URL})
+
+	owner, err := gitlabRequester.GetOwner(context.Background(), "acme/platform")
+	assert.NoError(t, err)
+	assert.Equal(t, &dto.OwnerResponseDTO{ID: 9, Login: "acme/platform", Type: dto.OwnerTypeOrganization}, owner)
+	var names []string
+	err = gitlabRequester.GetAllOrganizationRepositories(context.Background(), "acme/platform", func(page *[]dto.RepositoryInfoResponseDTO) error {
+		for _, repository := range *page {
+			names = append(names, repository.FullName)
+		}
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"acme/platform/api"}, names)
+}
+
+func TestGitLabRequesterCountsCommitFileChangesFromDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/testuser%2Fproject/repository/commits/abc":
+			assert.Equal(t, "true", r.URL.Query().Get("stats"))
+			fmt.Fprint(w, `{"id": "abc", "message": "fix", "author_name": "Test User", "authored_date": "2024-01-01T00:00:00Z",
+				"stats": {"additions": 2, "deletions": 1}}`)
+		case "/projects/testuser%2Fproject/repository/commits/abc/diff":
+			fmt.Fprint(w, `[
+				{"new_path": "main.go", "diff": "--- a/main.go\n+++ b/main.go\n@@ -1,2 +1,2 @@\n-old\n+new\n context\n"},
+				{"new_path": "NEW.md", "new_file": true, "diff": "@@ -0,0 +1 @@\n+hello\n"}
+			]`)
+		default:
+			t.Errorf("unexpected request to %s", r.URL)
+		}
+	}))
+	defer server.Close()
+	gitlabRequester := requester.NewGitLabRequester(&requester.Options{BaseURL: server.URL})
+
+	commit, err := gitlabRequester.GetCommit(context.Background(), "testuser", "project", "abc")
+	assert.NoError(t, err)
+	assert.Equal(t, "Test User", commit.Author)
+	assert.Equal(t, 2, commit.Additions)
+	assert.Equal(t, 1, commit.Deletions)
+	assert.Equal(t, []dto.CommitFileDTO{
+		{Filename: "main.go", Status: "modified", Additions: 1, Deletions: 1, Changes: 2},
+		{Filename: "NEW.md", Status: "added", Additions: 1, Changes: 1},
+	}, commit.Files)
+}
+
+func TestMultiRequesterDispatchesOnProvider(t *testing.T) {
+	github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/testuser/project", r.URL.Path)
+		fmt.Fprint(w, `{"id": 1, "name": "project"}`)
+	}))
+	defer github.Close()
+	gitlab := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/testuser%2Fproject", r.URL.EscapedPath())
+		fmt.Fprint(w, `{"id": 2, "path": "project"}`)
+	}))
+	defer gitlab.Close()
+	multiRequester := requester.NewMultiRequester(map[string]requester.Requester{
+		entity.ProviderGitHub: requester.NewRepositoryRequester(&requester.Options{BaseURL: github.URL}),
+		entity.ProviderGitLab: requester.NewGitLabRequester(&requester.Options{BaseURL: gitlab.URL}),
+	})
+
+	// unmarked requests go to GitHub
+	info, err := multiRequester.GetRepositoryInfo(context.Background(), "testuser", "project")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, info.ID)
+
+	gitlabCtx := requester.WithProvider(context.Background(), entity.ProviderGitLab)
+	info, err = multiRequester.GetRepositoryInfo(gitlabCtx, "testuser", "project")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, info.ID)
+
+	err = multiRequester.GetRepositoryReleases(gitlabCtx, "testuser", "project", func(*[]dto.ReleaseResponseDTO) error { return nil })
+	assert.ErrorIs(t, err, utils.ErrNotSupported)
+	_, err = multiRequester.GetRepositoriesInfo(gitlabCtx, "testuser", []string{"project"})
+	assert.ErrorIs(t, err, utils.ErrNotSupported)
+}
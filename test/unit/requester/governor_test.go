@@ -0,0 +1,72 @@
+package requester_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+)
+
+func rateLimitHeader(limit, remaining int, reset time.Time) http.Header {
+	header := http.Header{}
+	header.Set("x-ratelimit-limit", strconv.Itoa(limit))
+	header.Set("x-ratelimit-remaining", strconv.Itoa(remaining))
+	header.Set("x-ratelimit-reset", strconv.FormatInt(reset.Unix(), 10))
+	return header
+}
+
+func TestGovernorReservesBudgetForInteractiveWork(t *testing.T) {
+	governor := requester.NewGovernor(10)
+	governor.Observe("token", "token #1", rateLimitHeader(5000, 11, time.Now().Add(time.Hour)))
+
+	// background work may use the budget down to the reserve
+	assert.NoError(t, governor.Acquire(context.Background(), "token"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, governor.Acquire(ctx, "token"), context.DeadlineExceeded)
+
+	// interactive work may spend the reserve
+	interactive := requester.WithPriority(context.Background(), requester.PriorityInteractive)
+	assert.NoError(t, governor.Acquire(interactive, "token"))
+
+	status := governor.Snapshot()
+	assert.Len(t, status, 1)
+	assert.Equal(t, "token #1", status[0].Credential)
+	assert.Equal(t, 9, status[0].Remaining)
+	assert.Equal(t, 10, status[0].Reserved)
+}
+
+func TestGovernorConcurrentAcquire(t *testing.T) {
+	governor := requester.NewGovernor(1)
+	reset := time.Now().Add(time.Hour)
+	governor.Observe("token", "token #1", rateLimitHeader(5000, 51, reset))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, governor.Acquire(context.Background(), "token"))
+			// a stale report from before the other goroutines spent their share
+			governor.Observe("token", "token #1", rateLimitHeader(5000, 51, reset))
+		}()
+	}
+	wg.Wait()
+
+	// out of order reports never hand back budget that was already spent
+	assert.Equal(t, 1, governor.Snapshot()[0].Remaining)
+}
+
+func TestGovernorStartsAFreshWindowOnReset(t *testing.T) {
+	governor := requester.NewGovernor(1)
+	governor.Observe("token", "token #1", rateLimitHeader(60, 0, time.Now().Add(time.Hour)))
+	governor.Observe("token", "token #1", rateLimitHeader(60, 59, time.Now().Add(2*time.Hour)))
+
+	assert.Equal(t, 59, governor.Snapshot()[0].Remaining)
+}
@@ -0,0 +1,111 @@
+package requester_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+type graphQLTestRequest struct {
+	Query     string                 `json:"query"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+func graphQLRateLimitJSON(cost, remaining int) string {
+	return fmt.Sprintf(`"rateLimit": {"limit": 5000, "cost": %d, "remaining": %d, "resetAt": %q}`, cost, remaining, time.Now().Add(time.Hour).UTC().Format(time.RFC3339))
+}
+
+func TestGraphQLRequesterBatchesRepositoryInfo(t *testing.T) {
+	queries := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/graphql", r.URL.Path)
+		assert.Equal(t, http.MethodPost, r.Method)
+		var req graphQLTestRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		queries++
+		if strings.Contains(req.Query, `name: "first"`) {
+			assert.Contains(t, req.Query, `r0: repository(owner: "testuser", name: "first")`)
+			assert.Contains(t, req.Query, `r1: repository(owner: "testuser", name: "gone")`)
+			fmt.Fprintf(w, `{"data": {
+				"r0": {"databaseId": 1, "name": "first", "nameWithOwner": "testuser/first", "isFork": true, "primaryLanguage": {"name": "Go"},
+					"stargazerCount": 7, "issues": {"totalCount": 2}, "pullRequests": {"totalCount": 1}, "defaultBranchRef": {"name": "main"},
+					"updatedAt": "2024-01-01T00:00:00Z"},
+				"r1": null,
+				%s
+			}, "errors": [{"type": "NOT_FOUND", "path": ["r1"], "message": "Could not resolve to a Repository with the name 'testuser/gone'."}]}`, graphQLRateLimitJSON(2, 4990))
+			return
+		}
+		fmt.Fprintf(w, `{"data": {"r0": null, %s}, "errors": [{"type": "NOT_FOUND", "path": ["r0"], "message": "Could not resolve to a Repository with the name 'testuser/gone'."}]}`, graphQLRateLimitJSON(1, 4989))
+	}))
+	defer server.Close()
+	governor := requester.NewGovernor(10)
+	graphQLRequester := requester.NewGraphQLRequester(&requester.Options{BaseURL: server.URL, GraphQLURL: server.URL + "/graphql", Governor: governor})
+
+	infos, err := graphQLRequester.GetRepositoriesInfo(context.Background(), "testuser", []string{"first", "gone"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, queries)
+	assert.Len(t, infos, 1)
+	assert.Equal(t, &dto.RepositoryInfoResponseDTO{
+		ID: 1, Name: "first", FullName: "testuser/first", URL: server.URL + "/repos/testuser/first", Fork: true, Language: "Go",
+		StarsCount: 7, Watchers: 7, OpenIssues: 3, DefaultBranch: "main", UpdatedAt: "2024-01-01T00:00:00Z",
+	}, infos["first"])
+
+	// the points reported in the response are budgeted apart from the REST requests
+	status := governor.Snapshot()
+	assert.Len(t, status, 1)
+	assert.Equal(t, "anonymous (graphql)", status[0].Credential)
+	assert.Equal(t, 4990, status[0].Remaining)
+
+	_, err = graphQLRequester.GetRepositoryInfo(context.Background(), "testuser", "gone")
+	assert.ErrorIs(t, err, utils.ErrRepoNotFound)
+	assert.Equal(t, 2, queries)
+	// the second query acquired the two points the first one was reported to cost up front
+	assert.Equal(t, 4988, governor.Snapshot()[0].Remaining)
+}
+
+func TestGraphQLRequesterPagesThroughCommitHistory(t *testing.T) {
+	cursors := []interface{}{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req graphQLTestRequest
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.True(t, strings.Contains(req.Query, "history("))
+		assert.Equal(t, "HEAD", req.Variables["ref"])
+		assert.Equal(t, "2024-01-01T00:00:00Z", req.Variables["since"])
+		cursors = append(cursors, req.Variables["cursor"])
+		if req.Variables["cursor"] == nil {
+			fmt.Fprintf(w, `{"data": {"repository": {"object": {"history": {
+				"pageInfo": {"hasNextPage": true, "endCursor": "abc 1"},
+				"nodes": [{"oid": "sha2", "message": "second", "author": {"name": "Ada", "date": "2024-01-02T02:00:00+02:00", "user": {"login": "ada"}}, "parents": {"nodes": [{"oid": "sha1"}]}}]
+			}}}, %s}}`, graphQLRateLimitJSON(1, 4999))
+			return
+		}
+		fmt.Fprintf(w, `{"data": {"repository": {"object": {"history": {
+			"pageInfo": {"hasNextPage": false, "endCursor": "abc 2"},
+			"nodes": [{"oid": "sha1", "message": "first", "author": {"name": "Unlinked", "date": "2024-01-01T12:00:00Z", "user": null}}]
+		}}}, %s}}`, graphQLRateLimitJSON(1, 4998))
+	}))
+	defer server.Close()
+	graphQLRequester := requester.NewGraphQLRequester(&requester.Options{GraphQLURL: server.URL})
+
+	var commits []dto.CommitResponseDTO
+	err := graphQLRequester.GetRepositoryCommits(context.Background(), "testuser", "testrepo", &dto.CommitQueryParams{Since: "2024-01-01T00:00:00Z"}, func(page *[]dto.CommitResponseDTO) error {
+		commits = append(commits, *page...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, "abc 1"}, cursors)
+	assert.Equal(t, []dto.CommitResponseDTO{
+		{SHA: "sha2", Message: "second", Author: "Ada", AuthorLogin: "ada", Date: "2024-01-02T00:00:00Z", Parents: []string{"sha1"}},
+		{SHA: "sha1", Message: "first", Author: "Unlinked", Date: "2024-01-01T12:00:00Z"},
+	}, commits)
+}
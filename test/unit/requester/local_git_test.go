@@ -0,0 +1,166 @@
+package requester_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+// runGit runs git in dir as a fixed author, with the author and commit dates set to date when given.
+func runGit(t *testing.T, dir, date string, args ...string) string {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Test User", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=Test User", "GIT_COMMITTER_EMAIL=test@example.com", "GIT_CONFIG_GLOBAL=/dev/null")
+	if date != "" {
+		cmd.Env = append(cmd.Env, "GIT_AUTHOR_DATE="+date, "GIT_COMMITTER_DATE="+date)
+	}
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %v: %v: %s", args, err, out)
+	}
+	return string(out)
+}
+
+// newLocalClone creates a repository with three commits and a tag, and returns the directory
+// holding a bare clone of it as testuser/project.git.
+func newLocalClone(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	work := t.TempDir()
+	runGit(t, work, "", "init", "-q", "-b", "main")
+	os.WriteFile(filepath.Join(work, "a.txt"), []byte("one\ntwo\n"), 0o644)
+	os.WriteFile(filepath.Join(work, "b.txt"), []byte("gone\n"), 0o644)
+	runGit(t, work, "", "add", ".")
+	runGit(t, work, "2024-01-01T10:00:00+02:00", "commit", "-q", "-m", "first")
+	runGit(t, work, "", "tag", "-a", "v1", "-m", "release one")
+	os.WriteFile(filepath.Join(work, "a.txt"), []byte("one\n2\n3\n"), 0o644)
+	runGit(t, work, "2024-01-02T00:00:00Z", "commit", "-q", "-am", "second")
+	runGit(t, work, "", "mv", "a.txt", "c.txt")
+	runGit(t, work, "", "rm", "-q", "b.txt")
+	runGit(t, work, "2024-01-03T00:00:00Z", "commit", "-q", "-m", "third\n\nwith a body")
+
+	root := t.TempDir()
+	runGit(t, root, "", "clone", "-q", "--bare", work, filepath.Join(root, "testuser", "project.git"))
+	os.MkdirAll(filepath.Join(root, "testuser", "not-a-clone"), 0o755)
+	return root
+}
+
+func TestLocalGitRequesterReadsRepositoriesAndRefs(t *testing.T) {
+	root := newLocalClone(t)
+	localRequester := requester.NewLocalGitRequester(root, 0)
+	ctx := context.Background()
+
+	repositories := []dto.RepositoryInfoResponseDTO{}
+	err := localRequester.GetAllUserRepositories(ctx, "testuser", func(page *[]dto.RepositoryInfoResponseDTO) error {
+		repositories = append(repositories, *page...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, repositories, 1)
+	info := repositories[0]
+	assert.Equal(t, "project", info.Name)
+	assert.Equal(t, "testuser/project", info.FullName)
+	assert.Equal(t, "main", info.DefaultBranch)
+	assert.Equal(t, "2024-01-03T00:00:00Z", info.UpdatedAt)
+	// where the clone lives on disk is not reported
+	assert.Empty(t, info.HtmlUrl)
+	assert.Empty(t, info.URL)
+	// the ID is derived from the name, so it stays the same from one read to the next
+	again, err := localRequester.GetRepositoryInfo(ctx, "testuser", "project")
+	assert.NoError(t, err)
+	assert.Equal(t, info.ID, again.ID)
+
+	_, err = localRequester.GetRepositoryInfo(ctx, "testuser", "missing")
+	assert.ErrorIs(t, err, utils.ErrRepoNotFound)
+	_, err = localRequester.GetRepositoryInfo(ctx, "..", "testuser")
+	assert.ErrorIs(t, err, utils.ErrRepoNotFound)
+
+	head := runGit(t, filepath.Join(root, "testuser", "project.git"), "", "rev-parse", "main")
+	first := runGit(t, filepath.Join(root, "testuser", "project.git"), "", "rev-parse", "main~2")
+	err = localRequester.GetRepositoryBranches(ctx, "testuser", "project", func(branches *[]dto.BranchResponseDTO) error {
+		assert.Equal(t, []dto.BranchResponseDTO{{Name: "main", SHA: head[:len(head)-1]}}, *branches)
+		return nil
+	})
+	assert.NoError(t, err)
+	err = localRequester.GetRepositoryTags(ctx, "testuser", "project", func(tags *[]dto.TagResponseDTO) error {
+		// annotated tags point at the commit they tag, not at the tag object
+		assert.Equal(t, []dto.TagResponseDTO{{Name: "v1", SHA: first[:len(first)-1]}}, *tags)
+		return nil
+	})
+	assert.NoError(t, err)
+
+	err = localRequester.GetRepositoryReleases(ctx, "testuser", "project", func(*[]dto.ReleaseResponseDTO) error { return nil })
+	assert.ErrorIs(t, err, utils.ErrNotSupported)
+}
+
+func TestLocalGitRequesterPagesThroughHistory(t *testing.T) {
+	root := newLocalClone(t)
+	localRequester := requester.NewLocalGitRequester(root, 2)
+	ctx := context.Background()
+
+	pages := [][]dto.CommitResponseDTO{}
+	err := localRequester.GetRepositoryCommits(ctx, "testuser", "project", &dto.CommitQueryParams{SHA: "main"}, func(commits *[]dto.CommitResponseDTO) error {
+		pages = append(pages, *commits)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pages, 2)
+	assert.Len(t, pages[0], 2)
+	assert.Equal(t, "third\n\nwith a body", pages[0][0].Message)
+	assert.Equal(t, "Test User", pages[0][0].Author)
+	assert.Equal(t, "first", pages[1][0].Message)
+	assert.Equal(t, []string{pages[1][0].SHA}, pages[0][1].Parents)
+	assert.Empty(t, pages[1][0].Parents)
+	// dates are reported in UTC, like the GitHub REST API does
+	assert.Equal(t, "2024-01-01T08:00:00Z", pages[1][0].Date)
+
+	since := []dto.CommitResponseDTO{}
+	err = localRequester.GetRepositoryCommits(ctx, "testuser", "project", &dto.CommitQueryParams{Since: "2024-01-02T00:00:00Z"}, func(commits *[]dto.CommitResponseDTO) error {
+		since = append(since, *commits...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, since, 2)
+
+	// a handler that stops early stops git too
+	errStop := errors.New("stop")
+	err = localRequester.GetRepositoryCommits(ctx, "testuser", "project", nil, func(*[]dto.CommitResponseDTO) error { return errStop })
+	assert.ErrorIs(t, err, errStop)
+}
+
+func TestLocalGitRequesterCountsCommitFileChanges(t *testing.T) {
+	root := newLocalClone(t)
+	localRequester := requester.NewLocalGitRequester(root, 0)
+	ctx := context.Background()
+	dir := filepath.Join(root, "testuser", "project.git")
+
+	second := runGit(t, dir, "", "rev-parse", "main~1")
+	commit, err := localRequester.GetCommit(ctx, "testuser", "project", second[:len(second)-1])
+	assert.NoError(t, err)
+	assert.Equal(t, "second", commit.Message)
+	assert.Equal(t, 2, commit.Additions)
+	assert.Equal(t, 1, commit.Deletions)
+	assert.Equal(t, []dto.CommitFileDTO{{Filename: "a.txt", Status: "modified", Additions: 2, Deletions: 1, Changes: 3}}, commit.Files)
+
+	third := runGit(t, dir, "", "rev-parse", "main")
+	commit, err = localRequester.GetCommit(ctx, "testuser", "project", third[:len(third)-1])
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []dto.CommitFileDTO{
+		{Filename: "b.txt", Status: "removed", Deletions: 1, Changes: 1},
+		{Filename: "c.txt", Status: "renamed"},
+	}, commit.Files)
+
+	_, err = localRequester.GetCommit(ctx, "testuser", "project", "0000000000000000000000000000000000000000")
+	assert.ErrorIs(t, err, utils.ErrRepoNotFound)
+}
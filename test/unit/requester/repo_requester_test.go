@@ -0,0 +1,209 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/requester"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRepositoryInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/testuser/testrepo":
+			fmt.Fprint(w, `{"id": 1, "name": "testrepo", "full_name": "testuser/testrepo"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL + "/api/v3/"})
+
+	t.Run("uses the configured base url", func(t *testing.T) {
+		repo, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, repo.ID)
+		assert.Equal(t, "testuser/testrepo", repo.FullName)
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		repo, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "missing")
+		assert.ErrorIs(t, err, utils.ErrRepoNotFound)
+		assert.Nil(t, repo)
+	})
+}
+
+func TestGetRepositoryCommitsPagination(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "100", r.URL.Query().Get("per_page"))
+		switch r.URL.Query().Get("page") {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/testuser/testrepo/commits?per_page=100&page=2>; rel="next", <%s/repos/testuser/testrepo/commits?per_page=100&page=2>; rel="last"`, server.URL, server.URL))
+			fmt.Fprint(w, `[{"sha": "a1", "commit": {"message": "first"}}, {"sha": "a2", "commit": {"message": "second"}}]`)
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/testuser/testrepo/commits?per_page=100&page=1>; rel="first"`, server.URL))
+			fmt.Fprint(w, `[{"sha": "a3", "commit": {"message": "third"}}]`)
+		}
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, PerPage: 500})
+
+	var pages [][]string
+	err := repoRequester.GetRepositoryCommits(context.Background(), "testuser", "testrepo", nil, func(commits *[]dto.CommitResponseDTO) error {
+		var shas []string
+		for _, commit := range *commits {
+			shas = append(shas, commit.SHA)
+		}
+		pages = append(pages, shas)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, [][]string{{"a1", "a2"}, {"a3"}}, pages)
+}
+
+func TestGetAllUserRepositoriesStopsOnHandlerError(t *testing.T) {
+	requests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Link", fmt.Sprintf(`<%s/users/testuser/repos?page=%d>; rel="next"`, server.URL, requests+1))
+		fmt.Fprint(w, `[{"id": 1, "name": "testrepo"}]`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	handlerErr := fmt.Errorf("stop")
+	err := repoRequester.GetAllUserRepositories(context.Background(), "testuser", func(repositories *[]dto.RepositoryInfoResponseDTO) error {
+		return handlerErr
+	})
+	assert.ErrorIs(t, err, handlerErr)
+	assert.Equal(t, 1, requests)
+}
+
+func TestRequestsAbortWithContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// simulate a hung upstream call
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err := repoRequester.GetRepositoryInfo(ctx, "testuser", "testrepo")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestGetRepositoryReleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/testuser/testrepo/releases", r.URL.Path)
+		fmt.Fprint(w, `[{"id": 7, "tag_name": "v1.0.0", "name": "First", "author": {"login": "testuser"}, "published_at": "2024-02-01T00:00:00Z"}]`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	var releases []dto.ReleaseResponseDTO
+	err := repoRequester.GetRepositoryReleases(context.Background(), "testuser", "testrepo", func(page *[]dto.ReleaseResponseDTO) error {
+		releases = append(releases, *page...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, releases, 1)
+	assert.Equal(t, 7, releases[0].ID)
+	assert.Equal(t, "v1.0.0", releases[0].TagName)
+	assert.Equal(t, "testuser", releases[0].Author)
+	assert.Equal(t, "2024-02-01T00:00:00Z", releases[0].PublishedAt)
+}
+
+func TestGetRepositoryPullRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/testuser/testrepo/pulls", r.URL.Path)
+		assert.Equal(t, "all", r.URL.Query().Get("state"))
+		assert.Equal(t, "updated", r.URL.Query().Get("sort"))
+		fmt.Fprint(w, `[{
+			"id": 9, "number": 3, "title": "Add feature", "state": "closed",
+			"user": {"login": "testuser"},
+			"created_at": "2024-01-01T00:00:00Z", "merged_at": "2024-01-02T00:00:00Z", "closed_at": "2024-01-02T00:00:00Z",
+			"base": {"ref": "main"}, "head": {"ref": "feature"}, "merge_commit_sha": "abc123"
+		}]`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	var pullRequests []dto.PullRequestResponseDTO
+	err := repoRequester.GetRepositoryPullRequests(context.Background(), "testuser", "testrepo", func(page *[]dto.PullRequestResponseDTO) error {
+		pullRequests = append(pullRequests, *page...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, pullRequests, 1)
+	assert.Equal(t, 3, pullRequests[0].Number)
+	assert.Equal(t, "testuser", pullRequests[0].Author)
+	assert.Equal(t, "main", pullRequests[0].BaseRef)
+	assert.Equal(t, "feature", pullRequests[0].HeadRef)
+	assert.Equal(t, "abc123", pullRequests[0].MergeCommitSHA)
+	assert.Equal(t, "2024-01-02T00:00:00Z", pullRequests[0].MergedAt)
+}
+
+func TestGetRepositoryIssues(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/testuser/testrepo/issues", r.URL.Path)
+		assert.Equal(t, "all", r.URL.Query().Get("state"))
+		assert.Equal(t, "2024-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		fmt.Fprint(w, `[{
+			"id": 11, "number": 5, "title": "Crash on start", "state": "open",
+			"labels": [{"name": "bug"}, {"name": "p1"}], "user": {"login": "testuser"}, "assignees": [{"login": "ada"}],
+			"created_at": "2024-01-02T00:00:00Z", "updated_at": "2024-01-03T00:00:00Z", "closed_at": null
+		}, {
+			"id": 12, "number": 6, "title": "Add feature", "state": "open", "labels": [], "user": {"login": "testuser"},
+			"pull_request": {"url": "https://api.github.com/repos/testuser/testrepo/pulls/6"}
+		}]`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	var issues []dto.IssueResponseDTO
+	err := repoRequester.GetRepositoryIssues(context.Background(), "testuser", "testrepo", "2024-01-01T00:00:00Z", func(page *[]dto.IssueResponseDTO) error {
+		issues = append(issues, *page...)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Len(t, issues, 2)
+	assert.Equal(t, 5, issues[0].Number)
+	assert.Equal(t, []string{"bug", "p1"}, issues[0].Labels)
+	assert.Equal(t, []string{"ada"}, issues[0].Assignees)
+	assert.Equal(t, "", issues[0].ClosedAt)
+	assert.False(t, issues[0].IsPullRequest)
+	assert.True(t, issues[1].IsPullRequest)
+}
+
+func TestGetContributorStats(t *testing.T) {
+	computing := true
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repos/testuser/testrepo/stats/contributors", r.URL.Path)
+		if computing {
+			w.WriteHeader(http.StatusAccepted)
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprint(w, `[{"total": 3, "author": {"id": 1, "login": "ada"}, "weeks": [{"w": 1, "a": 10, "d": 2, "c": 2}, {"w": 2, "a": 5, "d": 1, "c": 1}]}]`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	_, err := repoRequester.GetContributorStats(context.Background(), "testuser", "testrepo")
+	assert.ErrorIs(t, err, utils.ErrStatsPending)
+
+	computing = false
+	stats, err := repoRequester.GetContributorStats(context.Background(), "testuser", "testrepo")
+	assert.NoError(t, err)
+	assert.Equal(t, []dto.ContributorStatsResponseDTO{{ID: 1, Login: "ada", Total: 3, Additions: 15, Deletions: 3}}, stats)
+}
@@ -0,0 +1,82 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+)
+
+var fastRetries = requester.RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond}
+
+func TestRetryOnServerErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "name": "testrepo"}`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, Retry: fastRetries})
+
+	repo, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+	assert.NoError(t, err)
+	assert.Equal(t, "testrepo", repo.Name)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	var requestTimes []time.Time
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestTimes = append(requestTimes, time.Now())
+		if len(requestTimes) == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, `{"id": 1, "name": "testrepo"}`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, Retry: fastRetries})
+
+	_, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+	assert.NoError(t, err)
+	assert.Len(t, requestTimes, 2)
+	assert.GreaterOrEqual(t, requestTimes[1].Sub(requestTimes[0]), time.Second)
+}
+
+func TestRetryStopsAfterMaxAttempts(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, Retry: fastRetries})
+
+	_, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+}
+
+func TestNoRetryOnClientErrors(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL, Retry: fastRetries})
+
+	_, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+	assert.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
@@ -0,0 +1,80 @@
+package requester_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/requester"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenRotation(t *testing.T) {
+	remaining := map[string]int{"Bearer token-a": 200, "Bearer token-b": 500}
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		seen = append(seen, auth)
+		remaining[auth]--
+		w.Header().Set("x-ratelimit-limit", "5000")
+		w.Header().Set("x-ratelimit-remaining", strconv.Itoa(remaining[auth]))
+		w.Header().Set("x-ratelimit-reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		fmt.Fprint(w, `{"id": 1, "name": "testrepo"}`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{
+		BaseURL: server.URL,
+		Tokens:  []string{"token-a", "token-b"},
+	})
+
+	for i := 0; i < 4; i++ {
+		_, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+		assert.NoError(t, err)
+	}
+
+	// both tokens start unobserved, so the first two calls warm them up in order;
+	// afterwards token-b has the larger budget and keeps being picked.
+	assert.Equal(t, []string{"Bearer token-a", "Bearer token-b", "Bearer token-b", "Bearer token-b"}, seen)
+}
+
+func TestTokenRotationOnExhaustedToken(t *testing.T) {
+	var seen []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		seen = append(seen, auth)
+		w.Header().Set("x-ratelimit-reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		if auth == "Bearer token-a" {
+			w.Header().Set("x-ratelimit-remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("x-ratelimit-remaining", "4999")
+		fmt.Fprint(w, `{"id": 1, "name": "testrepo"}`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{
+		BaseURL: server.URL,
+		Tokens:  []string{"token-a", "token-b"},
+	})
+
+	repo, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+	assert.NoError(t, err)
+	assert.Equal(t, "testrepo", repo.Name)
+	assert.Equal(t, []string{"Bearer token-a", "Bearer token-b"}, seen)
+}
+
+func TestAnonymousRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"id": 1}`)
+	}))
+	defer server.Close()
+	repoRequester := requester.NewRepositoryRequester(&requester.Options{BaseURL: server.URL})
+
+	_, err := repoRequester.GetRepositoryInfo(context.Background(), "testuser", "testrepo")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,45 @@
+package tasks_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/midedickson/github-service/entity"
+	tasks "github.com/midedickson/github-service/interface/task-manager"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetAllRepoForUserWaitsBeforeResyncing(t *testing.T) {
+	var mu sync.Mutex
+	var syncs []time.Time
+	repoDiscovery := new(mocks.MockRepositoryDiscovery)
+	// an owner on a forge without a rate limit, whose sync returns straight away
+	repoDiscovery.On("GetAllUserRepositories", mock.Anything, mock.Anything).Run(func(mock.Arguments) {
+		mu.Lock()
+		defer mu.Unlock()
+		syncs = append(syncs, time.Now())
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	taskManager := tasks.NewTaskManager(ctx, repoDiscovery, nil, 0, 200*time.Millisecond)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go taskManager.GetAllRepoForUser(&wg)
+	taskManager.AddUserToGetAllRepoQueue(&entity.User{Username: "testuser", Provider: entity.ProviderLocal})
+
+	time.Sleep(500 * time.Millisecond)
+	cancel()
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.GreaterOrEqual(t, len(syncs), 2)
+	assert.LessOrEqual(t, len(syncs), 3)
+	for i := 1; i < len(syncs); i++ {
+		assert.GreaterOrEqual(t, syncs[i].Sub(syncs[i-1]), 200*time.Millisecond)
+	}
+}
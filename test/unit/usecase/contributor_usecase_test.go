@@ -0,0 +1,110 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/usecase"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestReconcileAuthorCommitCounts(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Commit{}, &database.AuthorCommitCount{}, &database.Contributor{}, &database.CommitBranch{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	repo := &database.Repository{OwnerID: user.ID, Name: "testrepo"}
+	assert.NoError(t, db.Create(repo).Error)
+
+	commitRepository := database.NewSqliteCommitRepository(db)
+	_, err = commitRepository.StoreRepositoryCommits(&[]dto.CommitResponseDTO{
+		{SHA: "a1", Author: "Ada", AuthorLogin: "ada"},
+		{SHA: "a2", Author: "Ada", AuthorLogin: "ada"},
+		{SHA: "a3", Author: "ada lovelace", AuthorLogin: "ada"},
+		{SHA: "b1", Author: "Bob", AuthorLogin: "bob"},
+		{SHA: "c1", Author: "Unlinked"},
+	}, "testrepo", &entity.User{ID: user.ID, Username: "testuser"})
+	assert.NoError(t, err)
+	// the commits of a login are counted as one author whatever name they were made under
+	ada := entity.AuthorIdentity{Key: entity.AuthorKey(entity.ProviderGitHub, 0, "ada", "", "Ada"), Name: "Ada", Login: "ada"}
+	bob := entity.AuthorIdentity{Key: entity.AuthorKey(entity.ProviderGitHub, 0, "bob", "", "Bob"), Name: "Bob", Login: "bob"}
+	assert.NoError(t, commitRepository.AddAuthorCommitCount(ada, 3))
+	assert.NoError(t, commitRepository.AddAuthorCommitCount(bob, 1))
+
+	contributorRepository := database.NewSqliteContributorRepository(db)
+	repoEntity := &entity.Repository{ID: repo.ID, Name: "testrepo"}
+	assert.NoError(t, contributorRepository.StoreContributors(&[]dto.ContributorResponseDTO{
+		{Login: "ada", Contributions: 4}, {Login: "bob", Contributions: 1}, {Login: "eve", Contributions: 2},
+	}, repoEntity))
+	assert.NoError(t, contributorRepository.StoreContributorStats(&[]dto.ContributorStatsResponseDTO{{Login: "ada", Total: 5}}, repoEntity))
+
+	mockUserUseCase := new(mocks.MockUserUseCase)
+	mockUserUseCase.On("GetUser", "testuser").Return(&entity.User{ID: user.ID, Username: "testuser"}, nil)
+	contributorUseCase := usecase.NewContributorUseCaseService(contributorRepository, commitRepository, database.NewSqliteRepoRepository(db), mockUserUseCase)
+
+	entries := func(reconciliation *entity.ContributorReconciliation) map[string]*entity.ContributorReconciliationEntry {
+		byLogin := make(map[string]*entity.ContributorReconciliationEntry)
+		for _, entry := range reconciliation.Contributors {
+			byLogin[entry.Login] = entry
+		}
+		return byLogin
+	}
+
+	t.Run("reports the drift without touching the counts", func(t *testing.T) {
+		reconciliation, err := contributorUseCase.ReconcileAuthorCommitCounts("testuser", "testrepo", false)
+		assert.NoError(t, err)
+		byLogin := entries(reconciliation)
+		assert.Len(t, byLogin, 3)
+		// the statistics total wins over the contributors listing, and the most used name is credited
+		assert.Equal(t, &entity.ContributorReconciliationEntry{Login: "ada", Author: "Ada", Upstream: 5, Stored: 3, Difference: 2}, byLogin["ada"])
+		assert.Equal(t, 0, byLogin["bob"].Difference)
+		assert.Equal(t, "", byLogin["eve"].Author)
+		assert.Equal(t, 2, byLogin["eve"].Difference)
+
+		top, err := commitRepository.FindTopNAuthorsByCommitCounts(1)
+		assert.NoError(t, err)
+		assert.Equal(t, 3, top[0].CommitCount)
+	})
+
+	t.Run("corrects each author once", func(t *testing.T) {
+		for i := 0; i < 2; i++ {
+			reconciliation, err := contributorUseCase.ReconcileAuthorCommitCounts("testuser", "testrepo", true)
+			assert.NoError(t, err)
+			byLogin := entries(reconciliation)
+			assert.Equal(t, 0, byLogin["ada"].Difference)
+			assert.Equal(t, 2, byLogin["ada"].Adjustment)
+			// nothing can be credited to a contributor none of whose commits we have
+			assert.Equal(t, 2, byLogin["eve"].Difference)
+		}
+
+		top, err := commitRepository.FindTopNAuthorsByCommitCounts(1)
+		assert.NoError(t, err)
+		assert.Equal(t, "Ada", top[0].Author)
+		assert.Equal(t, "ada", top[0].AuthorLogin)
+		assert.Equal(t, 5, top[0].CommitCount)
+	})
+
+	t.Run("counts only commits on the default branch", func(t *testing.T) {
+		assert.NoError(t, db.Model(repo).Update("default_branch", "main").Error)
+		branchRepository := database.NewSqliteBranchRepository(db)
+		assert.NoError(t, branchRepository.AddCommitsToBranch(repo.ID, "main", []string{"a1", "a2", "a3", "b1", "c1"}))
+		_, err := commitRepository.StoreRepositoryCommits(&[]dto.CommitResponseDTO{
+			{SHA: "f1", Author: "Bob", AuthorLogin: "bob"},
+		}, "testrepo", &entity.User{ID: user.ID, Username: "testuser"})
+		assert.NoError(t, err)
+		assert.NoError(t, branchRepository.AddCommitsToBranch(repo.ID, "feature", []string{"b1", "f1"}))
+
+		reconciliation, err := contributorUseCase.ReconcileAuthorCommitCounts("testuser", "testrepo", false)
+		assert.NoError(t, err)
+		byLogin := entries(reconciliation)
+		assert.Equal(t, 3, byLogin["ada"].Stored)
+		assert.Equal(t, 1, byLogin["bob"].Stored)
+		assert.Equal(t, 0, byLogin["bob"].Difference)
+	})
+}
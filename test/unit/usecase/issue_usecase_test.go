@@ -0,0 +1,63 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/usecase"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestIssues(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Issue{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	repo := &database.Repository{OwnerID: user.ID, Name: "testrepo"}
+	assert.NoError(t, db.Create(repo).Error)
+
+	issueRepository := database.NewSqliteIssueRepository(db)
+	assert.NoError(t, issueRepository.StoreIssues(&[]dto.IssueResponseDTO{
+		{ID: 1, Number: 1, Author: "ada", State: "closed", Labels: []string{"bug"}, CreatedAt: "2024-01-01T00:00:00Z", ClosedAt: "2024-01-01T00:30:00Z"},
+		{ID: 2, Number: 2, Author: "bob", State: "closed", Labels: []string{"bug", "ui"}, CreatedAt: "2024-01-01T00:00:00Z", ClosedAt: "2024-01-03T00:00:00Z"},
+		{ID: 3, Number: 3, Author: "bob", State: "open", Labels: []string{"bugfix"}, Assignees: []string{"ada"}, CreatedAt: "2024-01-05T00:00:00Z"},
+		{ID: 4, Number: 4, Author: "ada", State: "open", IsPullRequest: true, CreatedAt: "2024-01-05T00:00:00Z"},
+	}, &entity.Repository{ID: repo.ID, Name: "testrepo"}))
+
+	mockUserUseCase := new(mocks.MockUserUseCase)
+	mockUserUseCase.On("GetUser", "testuser").Return(&entity.User{ID: user.ID, Username: "testuser"}, nil)
+	issueUseCase := usecase.NewIssueUseCaseService(issueRepository, database.NewSqliteRepoRepository(db), mockUserUseCase)
+
+	t.Run("filters issues by label, author and assignee", func(t *testing.T) {
+		bugs, err := issueUseCase.GetIssues("testuser", "testrepo", &utils.IssueSearchParams{Label: "bug"})
+		assert.NoError(t, err)
+		assert.Len(t, bugs, 2)
+		assert.Equal(t, 2, bugs[0].Number)
+		assert.Equal(t, []string{"bug", "ui"}, bugs[0].Labels)
+
+		assigned, err := issueUseCase.GetIssues("testuser", "testrepo", &utils.IssueSearchParams{State: "open", Author: "bob", Assignee: "ada"})
+		assert.NoError(t, err)
+		assert.Len(t, assigned, 1)
+		assert.Equal(t, 3, assigned[0].Number)
+	})
+
+	t.Run("summarises time to close and open issue age", func(t *testing.T) {
+		statistics, err := issueUseCase.GetIssueStatistics("testuser", "testrepo")
+		assert.NoError(t, err)
+		// the pull request listed among the issues is not counted
+		assert.Equal(t, 1, statistics.Open)
+		assert.Equal(t, 2, statistics.Closed)
+		assert.Equal(t, 2, statistics.TimeToClose.Count)
+		assert.Equal(t, 0.5, statistics.TimeToClose.Median)
+		assert.Equal(t, 48.0, statistics.TimeToClose.Max)
+		assert.Equal(t, 1, statistics.OpenIssueAge.Count)
+		assert.Equal(t, 1, statistics.OpenIssueAge.Buckets[4].Count)
+	})
+}
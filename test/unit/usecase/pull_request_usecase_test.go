@@ -0,0 +1,74 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/usecase"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestPullRequestMetrics(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.PullRequest{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	repo := &database.Repository{OwnerID: user.ID, Name: "testrepo"}
+	assert.NoError(t, db.Create(repo).Error)
+
+	pullRequestRepository := database.NewSqlitePullRequestRepository(db)
+	assert.NoError(t, pullRequestRepository.StorePullRequests(&[]dto.PullRequestResponseDTO{
+		{ID: 1, Number: 1, Author: "ada", State: "closed", CreatedAt: "2024-01-01T00:00:00Z", MergedAt: "2024-01-01T00:30:00Z"},
+		{ID: 2, Number: 2, Author: "ada", State: "closed", CreatedAt: "2024-01-01T00:00:00Z", MergedAt: "2024-01-03T00:00:00Z"},
+		{ID: 3, Number: 3, Author: "bob", State: "closed", CreatedAt: "2024-01-01T00:00:00Z", MergedAt: "2024-01-11T00:00:00Z"},
+		{ID: 4, Number: 4, Author: "bob", State: "closed", CreatedAt: "2024-01-01T00:00:00Z", ClosedAt: "2024-01-02T00:00:00Z"},
+		{ID: 5, Number: 5, Author: "bob", State: "open", CreatedAt: "2024-01-05T00:00:00Z"},
+	}, &entity.Repository{ID: repo.ID, Name: "testrepo"}))
+
+	mockUserUseCase := new(mocks.MockUserUseCase)
+	mockUserUseCase.On("GetUser", "testuser").Return(&entity.User{ID: user.ID, Username: "testuser"}, nil)
+	pullRequestUseCase := usecase.NewPullRequestUseCaseService(pullRequestRepository, database.NewSqliteRepoRepository(db), mockUserUseCase)
+
+	t.Run("lists pull requests by state", func(t *testing.T) {
+		open, err := pullRequestUseCase.GetPullRequests("testuser", "testrepo", "open")
+		assert.NoError(t, err)
+		assert.Len(t, open, 1)
+		assert.Equal(t, 5, open[0].Number)
+	})
+
+	t.Run("summarises the repository", func(t *testing.T) {
+		metrics, err := pullRequestUseCase.GetPullRequestMetrics("testuser", "testrepo")
+		assert.NoError(t, err)
+		assert.Equal(t, 5, metrics.Total)
+		assert.Equal(t, 1, metrics.Open)
+		assert.Equal(t, 3, metrics.Merged)
+		assert.Equal(t, 1, metrics.ClosedUnmerged)
+		assert.Equal(t, 0.75, metrics.MergeRate)
+
+		timeToMerge := metrics.TimeToMerge
+		assert.Equal(t, 3, timeToMerge.Count)
+		assert.Equal(t, 48.0, timeToMerge.Median)
+		assert.Equal(t, 240.0, timeToMerge.Max)
+		assert.Equal(t, []int{1, 0, 1, 1, 0}, []int{
+			timeToMerge.Buckets[0].Count, timeToMerge.Buckets[1].Count, timeToMerge.Buckets[2].Count,
+			timeToMerge.Buckets[3].Count, timeToMerge.Buckets[4].Count,
+		})
+	})
+
+	t.Run("breaks the metrics down by author", func(t *testing.T) {
+		metrics, err := pullRequestUseCase.GetPullRequestMetrics("testuser", "testrepo")
+		assert.NoError(t, err)
+		assert.Len(t, metrics.Authors, 2)
+		assert.Equal(t, "bob", metrics.Authors[0].Author)
+		assert.Equal(t, 0.5, metrics.Authors[0].MergeRate)
+		assert.Equal(t, "ada", metrics.Authors[1].Author)
+		assert.Equal(t, 1.0, metrics.Authors[1].MergeRate)
+		assert.Equal(t, 0.5, metrics.Authors[1].TimeToMerge.Median)
+	})
+}
@@ -0,0 +1,107 @@
+package usecase_test
+
+import (
+	"testing"
+
+	"github.com/midedickson/github-service/dto"
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/test/mocks"
+	"github.com/midedickson/github-service/usecase"
+	"github.com/midedickson/github-service/utils"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestReleaseTimeline(t *testing.T) {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	assert.NoError(t, err)
+	assert.NoError(t, db.AutoMigrate(&database.User{}, &database.Repository{}, &database.Commit{}, &database.Release{},
+		&database.Tag{}, &database.CommitBranch{}))
+	user := &database.User{Username: "testuser"}
+	assert.NoError(t, db.Create(user).Error)
+	repo := &database.Repository{OwnerID: user.ID, Name: "testrepo"}
+	assert.NoError(t, db.Create(repo).Error)
+	for sha, date := range map[string]string{
+		"c1": "2024-01-05T00:00:00Z",
+		"c2": "2024-02-10T00:00:00Z",
+		"c3": "2024-02-20T00:00:00Z",
+		"c4": "2024-03-15T00:00:00Z",
+	} {
+		assert.NoError(t, db.Create(&database.Commit{RepositoryName: "testrepo", RepositoryID: repo.ID, SHA: sha, Date: date}).Error)
+	}
+
+	releaseRepository := database.NewSqliteReleaseRepository(db)
+	assert.NoError(t, releaseRepository.StoreReleases(&[]dto.ReleaseResponseDTO{
+		{ID: 3, TagName: "v3.0.0", Draft: true},
+		{ID: 2, TagName: "v2.0.0", PublishedAt: "2024-03-01T00:00:00Z"},
+		{ID: 1, TagName: "v1.0.0", PublishedAt: "2024-02-01T00:00:00Z"},
+	}, &entity.Repository{ID: repo.ID, Name: "testrepo"}))
+
+	mockUserUseCase := new(mocks.MockUserUseCase)
+	mockUserUseCase.On("GetUser", "testuser").Return(&entity.User{ID: user.ID, Username: "testuser"}, nil)
+	releaseUseCase := usecase.NewReleaseUseCaseService(releaseRepository, database.NewSqliteCommitRepository(db), database.NewSqliteBranchRepository(db),
+		database.NewSqliteRepoRepository(db), mockUserUseCase)
+
+	t.Run("lists releases newest first", func(t *testing.T) {
+		releases, err := releaseUseCase.GetRepositoryReleases("testuser", "testrepo")
+		assert.NoError(t, err)
+		assert.Len(t, releases, 3)
+		assert.Equal(t, "v2.0.0", releases[0].TagName)
+	})
+
+	t.Run("pairs published releases with the commits dated since the previous one without tags", func(t *testing.T) {
+		timeline, err := releaseUseCase.GetReleaseTimeline("testuser", "testrepo")
+		assert.NoError(t, err)
+		assert.Len(t, timeline, 2)
+		assert.Equal(t, entity.CommitRangeDates, timeline[0].CommitRange)
+
+		assert.Equal(t, "v2.0.0", timeline[0].Release.TagName)
+		assert.Len(t, timeline[0].Commits, 2)
+		assert.Equal(t, "c3", timeline[0].Commits[0].SHA)
+		assert.Equal(t, "c2", timeline[0].Commits[1].SHA)
+
+		assert.Equal(t, "v1.0.0", timeline[1].Release.TagName)
+		assert.Len(t, timeline[1].Commits, 1)
+		assert.Equal(t, "c1", timeline[1].Commits[0].SHA)
+	})
+
+	t.Run("pairs published releases with the commits between their tags", func(t *testing.T) {
+		tagged := &database.Repository{OwnerID: user.ID, Name: "tagged"}
+		assert.NoError(t, db.Create(tagged).Error)
+		// t3 was authored before v1.0.0 was published but only made it into v2.0.0
+		_, err := database.NewSqliteCommitRepository(db).StoreRepositoryCommits(&[]dto.CommitResponseDTO{
+			{SHA: "t4", Date: "2024-03-15T00:00:00Z", Parents: []string{"t3"}},
+			{SHA: "t3", Date: "2024-01-20T00:00:00Z", Parents: []string{"t2"}},
+			{SHA: "t2", Date: "2024-02-10T00:00:00Z", Parents: []string{"t1"}},
+			{SHA: "t1", Date: "2024-01-05T00:00:00Z"},
+		}, "tagged", &entity.User{ID: user.ID, Username: "testuser"})
+		assert.NoError(t, err)
+		assert.NoError(t, database.NewSqliteBranchRepository(db).StoreTags(tagged.ID, &[]dto.TagResponseDTO{
+			{Name: "v1.0.0", SHA: "t1"}, {Name: "v2.0.0", SHA: "t3"},
+		}))
+		assert.NoError(t, releaseRepository.StoreReleases(&[]dto.ReleaseResponseDTO{
+			{ID: 12, TagName: "v2.0.0", PublishedAt: "2024-03-01T00:00:00Z"},
+			{ID: 11, TagName: "v1.0.0", PublishedAt: "2024-02-01T00:00:00Z"},
+		}, &entity.Repository{ID: tagged.ID, Name: "tagged"}))
+
+		timeline, err := releaseUseCase.GetReleaseTimeline("testuser", "tagged")
+		assert.NoError(t, err)
+		assert.Len(t, timeline, 2)
+
+		assert.Equal(t, entity.CommitRangeTags, timeline[0].CommitRange)
+		assert.Len(t, timeline[0].Commits, 2)
+		assert.Equal(t, "t2", timeline[0].Commits[0].SHA)
+		assert.Equal(t, "t3", timeline[0].Commits[1].SHA)
+
+		assert.Equal(t, entity.CommitRangeTags, timeline[1].CommitRange)
+		assert.Len(t, timeline[1].Commits, 1)
+		assert.Equal(t, "t1", timeline[1].Commits[0].SHA)
+	})
+
+	t.Run("repository not found", func(t *testing.T) {
+		_, err := releaseUseCase.GetReleaseTimeline("testuser", "missing")
+		assert.ErrorIs(t, err, utils.ErrRepoNotFound)
+	})
+}
@@ -9,23 +9,33 @@ import (
 )
 
 type CommitUseCase interface {
-	GetRepositoryCommits(repoName string) ([]*entity.Commit, error)
+	GetRepositoryCommits(owner, repoName string) ([]*entity.Commit, error)
+	GetBranchCommits(owner, repoName, branchName string) ([]*entity.Commit, error)
 	MakeRepoResetRequest(owner, repoName, resetSHA string) error
 	GetTopNAuthorsByCommits(topN int) ([]*entity.AuthorCommitCount, error)
+	GetTopNAuthorsByChurn(topN int) ([]*entity.AuthorChurn, error)
+	GetRepositoryChurn(owner, repoName string) (*entity.RepositoryChurn, error)
+	GetMostChangedFiles(owner, repoName string, topN int) ([]*entity.FileChurn, error)
 }
 
 type CommitUseCaseService struct {
 	commitRepository repository.CommitRepository
+	repoRepository   repository.RepoRepository
+	userUseCase      UserUseCase
 	repoUseCase      RepoUseCase
 	task             tasks.Task
 }
 
-func NewCommitUseCaseService(commitRepository repository.CommitRepository, repoUseCase RepoUseCase, task tasks.Task) *CommitUseCaseService {
-	return &CommitUseCaseService{commitRepository: commitRepository, repoUseCase: repoUseCase, task: task}
+func NewCommitUseCaseService(commitRepository repository.CommitRepository, repoRepository repository.RepoRepository, userUseCase UserUseCase, repoUseCase RepoUseCase, task tasks.Task) *CommitUseCaseService {
+	return &CommitUseCaseService{commitRepository: commitRepository, repoRepository: repoRepository, userUseCase: userUseCase, repoUseCase: repoUseCase, task: task}
 }
 
-func (c *CommitUseCaseService) GetRepositoryCommits(repoName string) ([]*entity.Commit, error) {
-	repoCommits, err := c.commitRepository.GetRepositoryCommits(repoName)
+func (c *CommitUseCaseService) GetRepositoryCommits(owner, repoName string) ([]*entity.Commit, error) {
+	repo, err := findRepository(c.userUseCase, c.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	repoCommits, err := c.commitRepository.GetRepositoryCommits(repo.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -36,6 +46,22 @@ func (c *CommitUseCaseService) GetRepositoryCommits(repoName string) ([]*entity.
 	return commitEntities, nil
 }
 
+func (c *CommitUseCaseService) GetBranchCommits(owner, repoName, branchName string) ([]*entity.Commit, error) {
+	repo, err := findRepository(c.userUseCase, c.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	branchCommits, err := c.commitRepository.GetBranchCommits(repo.ID, branchName)
+	if err != nil {
+		return nil, err
+	}
+	commitEntities := make([]*entity.Commit, len(branchCommits))
+	for i, commit := range branchCommits {
+		commitEntities[i] = commit.ToEntity()
+	}
+	return commitEntities, nil
+}
+
 func (c *CommitUseCaseService) MakeRepoResetRequest(owner, repoName, resetSHA string) error {
 	repo, err := c.repoUseCase.GetRepositoryInfo(owner, repoName)
 	if err != nil {
@@ -46,7 +72,7 @@ func (c *CommitUseCaseService) MakeRepoResetRequest(owner, repoName, resetSHA st
 		return errors.New("this repository does not exist in our databse right now, but we're going to try and get it please check back in a bit")
 	}
 
-	go c.task.AddRequestToResetRepositoryQueue(repoName, resetSHA)
+	go c.task.AddRequestToResetRepositoryQueue(repo.ID, repoName, resetSHA)
 	return nil
 }
 
@@ -61,3 +87,44 @@ func (c *CommitUseCaseService) GetTopNAuthorsByCommits(topN int) ([]*entity.Auth
 	}
 	return topAuthorsEntities, nil
 }
+
+func (c *CommitUseCaseService) GetTopNAuthorsByChurn(topN int) ([]*entity.AuthorChurn, error) {
+	topAuthors, err := c.commitRepository.FindTopNAuthorsByChurn(topN)
+	if err != nil {
+		return nil, err
+	}
+	topAuthorsEntities := make([]*entity.AuthorChurn, len(topAuthors))
+	for i, author := range topAuthors {
+		topAuthorsEntities[i] = author.ToEntity()
+	}
+	return topAuthorsEntities, nil
+}
+
+func (c *CommitUseCaseService) GetRepositoryChurn(owner, repoName string) (*entity.RepositoryChurn, error) {
+	repo, err := findRepository(c.userUseCase, c.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	repoChurn, err := c.commitRepository.GetRepositoryChurn(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	repoChurn.RepositoryName = repo.Name
+	return repoChurn.ToEntity(), nil
+}
+
+func (c *CommitUseCaseService) GetMostChangedFiles(owner, repoName string, topN int) ([]*entity.FileChurn, error) {
+	repo, err := findRepository(c.userUseCase, c.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	files, err := c.commitRepository.FindMostChangedFiles(repo.ID, topN)
+	if err != nil {
+		return nil, err
+	}
+	fileEntities := make([]*entity.FileChurn, len(files))
+	for i, file := range files {
+		fileEntities[i] = file.ToEntity()
+	}
+	return fileEntities, nil
+}
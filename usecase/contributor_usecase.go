@@ -0,0 +1,104 @@
+package usecase
+
+import (
+	"log"
+
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/repository"
+)
+
+type ContributorUseCase interface {
+	GetContributors(owner, repoName string) ([]*entity.Contributor, error)
+	ReconcileAuthorCommitCounts(owner, repoName string, correct bool) (*entity.ContributorReconciliation, error)
+}
+
+type ContributorUseCaseService struct {
+	contributorRepository repository.ContributorRepository
+	commitRepository      repository.CommitRepository
+	repoRepository        repository.RepoRepository
+	userUseCase           UserUseCase
+}
+
+func NewContributorUseCaseService(contributorRepository repository.ContributorRepository, commitRepository repository.CommitRepository, repoRepository repository.RepoRepository, userUseCase UserUseCase) *ContributorUseCaseService {
+	return &ContributorUseCaseService{contributorRepository: contributorRepository, commitRepository: commitRepository, repoRepository: repoRepository, userUseCase: userUseCase}
+}
+
+func (c *ContributorUseCaseService) GetContributors(owner, repoName string) ([]*entity.Contributor, error) {
+	repo, err := findRepository(c.userUseCase, c.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	contributors, err := c.contributorRepository.GetRepositoryContributors(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	contributorEntities := make([]*entity.Contributor, len(contributors))
+	for i, contributor := range contributors {
+		contributorEntities[i] = contributor.ToEntity()
+	}
+	return contributorEntities, nil
+}
+
+// ReconcileAuthorCommitCounts compares GitHub's commit total for each contributor of a repository
+// with the commits we stored for their login. When correct is set, the difference is added to the
+// author's pre-aggregated commit count and remembered as an adjustment, so a contributor is only
+// ever corrected by what is still missing.
+func (c *ContributorUseCaseService) ReconcileAuthorCommitCounts(owner, repoName string, correct bool) (*entity.ContributorReconciliation, error) {
+	repo, err := findRepository(c.userUseCase, c.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	contributors, err := c.contributorRepository.GetRepositoryContributors(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	// GitHub's totals only cover the default branch, so commits only on other branches are not counted;
+	// a repository whose default branch is not known yet has all of its commits counted
+	loginCounts, err := c.commitRepository.CountRepositoryCommitsByLogin(repo.ID, repo.DefaultBranch)
+	if err != nil {
+		return nil, err
+	}
+
+	// a login may have committed under several names, and before its account ID was known under
+	// several keys; the leaderboard is credited under the most used of them
+	stored := make(map[string]int)
+	authors := make(map[string]entity.AuthorIdentity)
+	authorCommits := make(map[string]int)
+	for _, count := range loginCounts {
+		stored[count.AuthorLogin] += count.Commits
+		if count.Commits > authorCommits[count.AuthorLogin] {
+			authors[count.AuthorLogin] = entity.AuthorIdentity{Key: count.AuthorKey, Name: count.Author, Login: count.AuthorLogin}
+			authorCommits[count.AuthorLogin] = count.Commits
+		}
+	}
+
+	reconciliation := &entity.ContributorReconciliation{Repository: repoName, Corrected: correct}
+	for _, contributor := range contributors {
+		// the statistics count every commit on the default branch; the contributors listing is a fallback until they are computed
+		upstream := contributor.StatsCommits
+		if upstream == 0 {
+			upstream = contributor.Contributions
+		}
+		entry := &entity.ContributorReconciliationEntry{
+			Login:      contributor.Login,
+			Author:     authors[contributor.Login].Name,
+			Upstream:   upstream,
+			Stored:     stored[contributor.Login],
+			Adjustment: contributor.Adjustment,
+		}
+		entry.Difference = entry.Upstream - entry.Stored - entry.Adjustment
+		if correct && entry.Difference != 0 && entry.Author != "" {
+			if err := c.commitRepository.AddAuthorCommitCount(authors[contributor.Login], entry.Difference); err != nil {
+				return nil, err
+			}
+			if err := c.contributorRepository.AddContributorAdjustment(contributor, entry.Difference); err != nil {
+				return nil, err
+			}
+			log.Printf("Corrected commit count of %s by %d", entry.Author, entry.Difference)
+			entry.Adjustment += entry.Difference
+			entry.Difference = 0
+		}
+		reconciliation.Contributors = append(reconciliation.Contributors, entry)
+	}
+	return reconciliation, nil
+}
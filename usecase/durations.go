@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/midedickson/github-service/entity"
+)
+
+// durationBuckets are the upper bounds of duration histograms; the last bucket is unbounded.
+var durationBuckets = []struct {
+	label string
+	limit time.Duration
+}{
+	{"under 1 hour", time.Hour},
+	{"under 1 day", 24 * time.Hour},
+	{"under 1 week", 7 * 24 * time.Hour},
+	{"under 30 days", 30 * 24 * time.Hour},
+	{"30 days or more", math.MaxInt64},
+}
+
+func timeBetween(start, end string) (time.Duration, bool) {
+	startTime, err := time.Parse(time.RFC3339, start)
+	if err != nil {
+		return 0, false
+	}
+	endTime, err := time.Parse(time.RFC3339, end)
+	if err != nil {
+		return 0, false
+	}
+	return endTime.Sub(startTime), true
+}
+
+func durationDistribution(durations []time.Duration) entity.DurationDistribution {
+	distribution := entity.DurationDistribution{
+		Count:   len(durations),
+		Buckets: make([]entity.DurationBucket, len(durationBuckets)),
+	}
+	for i, bucket := range durationBuckets {
+		distribution.Buckets[i].Label = bucket.label
+	}
+	if len(durations) == 0 {
+		return distribution
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+
+	var total time.Duration
+	for _, duration := range durations {
+		total += duration
+		for i, bucket := range durationBuckets {
+			if duration < bucket.limit {
+				distribution.Buckets[i].Count++
+				break
+			}
+		}
+	}
+	distribution.Mean = total.Hours() / float64(len(durations))
+	distribution.Median = percentile(durations, 50).Hours()
+	distribution.P75 = percentile(durations, 75).Hours()
+	distribution.P90 = percentile(durations, 90).Hours()
+	distribution.Max = durations[len(durations)-1].Hours()
+	return distribution
+}
+
+// percentile picks the nearest-rank percentile p of sorted durations.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := int(math.Ceil(float64(p) / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,26 @@
+package usecase
+
+import (
+	"fmt"
+
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/interface/repository"
+	"github.com/midedickson/github-service/utils"
+)
+
+// findRepository looks up a stored repository by its owner's username and its name,
+// without queueing a fetch the way RepoUseCase.GetRepositoryInfo does.
+func findRepository(userUseCase UserUseCase, repoRepository repository.RepoRepository, owner, repoName string) (*database.Repository, error) {
+	user, err := userUseCase.GetUser(owner)
+	if err != nil {
+		return nil, err
+	}
+	repo, err := repoRepository.GetRepository(user.ID, repoName)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, fmt.Errorf("repository %s/%s: %w", owner, repoName, utils.ErrRepoNotFound)
+	}
+	return repo, nil
+}
@@ -0,0 +1,73 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/repository"
+	"github.com/midedickson/github-service/utils"
+)
+
+type IssueUseCase interface {
+	GetIssues(owner, repoName string, issueSearchParams *utils.IssueSearchParams) ([]*entity.Issue, error)
+	GetIssueStatistics(owner, repoName string) (*entity.IssueStatistics, error)
+}
+
+type IssueUseCaseService struct {
+	issueRepository repository.IssueRepository
+	repoRepository  repository.RepoRepository
+	userUseCase     UserUseCase
+}
+
+func NewIssueUseCaseService(issueRepository repository.IssueRepository, repoRepository repository.RepoRepository, userUseCase UserUseCase) *IssueUseCaseService {
+	return &IssueUseCaseService{issueRepository: issueRepository, repoRepository: repoRepository, userUseCase: userUseCase}
+}
+
+func (i *IssueUseCaseService) GetIssues(owner, repoName string, issueSearchParams *utils.IssueSearchParams) ([]*entity.Issue, error) {
+	repo, err := findRepository(i.userUseCase, i.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := i.issueRepository.SearchIssues(repo.ID, issueSearchParams)
+	if err != nil {
+		return nil, err
+	}
+	issueEntities := make([]*entity.Issue, len(issues))
+	for j, issue := range issues {
+		issueEntities[j] = issue.ToEntity()
+	}
+	return issueEntities, nil
+}
+
+func (i *IssueUseCaseService) GetIssueStatistics(owner, repoName string) (*entity.IssueStatistics, error) {
+	// computed on read for the same reason as the pull request metrics
+	repo, err := findRepository(i.userUseCase, i.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := i.issueRepository.SearchIssues(repo.ID, &utils.IssueSearchParams{})
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	statistics := &entity.IssueStatistics{Repository: repoName}
+	timesToClose := []time.Duration{}
+	openIssueAges := []time.Duration{}
+	for _, issue := range issues {
+		if issue.State == "open" {
+			statistics.Open++
+			if age, ok := timeBetween(issue.RemoteCreatedAt, now); ok {
+				openIssueAges = append(openIssueAges, age)
+			}
+			continue
+		}
+		statistics.Closed++
+		if timeToClose, ok := timeBetween(issue.RemoteCreatedAt, issue.ClosedAt); ok {
+			timesToClose = append(timesToClose, timeToClose)
+		}
+	}
+	statistics.TimeToClose = durationDistribution(timesToClose)
+	statistics.OpenIssueAge = durationDistribution(openIssueAges)
+	return statistics, nil
+}
@@ -0,0 +1,99 @@
+package usecase
+
+import (
+	"sort"
+	"time"
+
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/interface/repository"
+)
+
+type PullRequestUseCase interface {
+	GetPullRequests(owner, repoName, state string) ([]*entity.PullRequest, error)
+	GetPullRequestMetrics(owner, repoName string) (*entity.RepositoryPullRequestMetrics, error)
+}
+
+type PullRequestUseCaseService struct {
+	pullRequestRepository repository.PullRequestRepository
+	repoRepository        repository.RepoRepository
+	userUseCase           UserUseCase
+}
+
+func NewPullRequestUseCaseService(pullRequestRepository repository.PullRequestRepository, repoRepository repository.RepoRepository, userUseCase UserUseCase) *PullRequestUseCaseService {
+	return &PullRequestUseCaseService{pullRequestRepository: pullRequestRepository, repoRepository: repoRepository, userUseCase: userUseCase}
+}
+
+func (p *PullRequestUseCaseService) GetPullRequests(owner, repoName, state string) ([]*entity.PullRequest, error) {
+	repo, err := findRepository(p.userUseCase, p.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	pullRequests, err := p.pullRequestRepository.GetRepositoryPullRequests(repo.ID, state)
+	if err != nil {
+		return nil, err
+	}
+	pullRequestEntities := make([]*entity.PullRequest, len(pullRequests))
+	for i, pullRequest := range pullRequests {
+		pullRequestEntities[i] = pullRequest.ToEntity()
+	}
+	return pullRequestEntities, nil
+}
+
+func (p *PullRequestUseCaseService) GetPullRequestMetrics(owner, repoName string) (*entity.RepositoryPullRequestMetrics, error) {
+	// Unlike the author leaderboards these are not pre-aggregated: percentiles cannot be summed
+	// incrementally, and they only ever cover the pull requests of a single repository
+	repo, err := findRepository(p.userUseCase, p.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	pullRequests, err := p.pullRequestRepository.GetRepositoryPullRequests(repo.ID, "")
+	if err != nil {
+		return nil, err
+	}
+
+	byAuthor := make(map[string][]*database.PullRequest)
+	for _, pullRequest := range pullRequests {
+		byAuthor[pullRequest.Author] = append(byAuthor[pullRequest.Author], pullRequest)
+	}
+	authors := make([]*entity.PullRequestMetrics, 0, len(byAuthor))
+	for author, authorPullRequests := range byAuthor {
+		metrics := pullRequestMetrics(authorPullRequests)
+		metrics.Author = author
+		authors = append(authors, &metrics)
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Total != authors[j].Total {
+			return authors[i].Total > authors[j].Total
+		}
+		return authors[i].Author < authors[j].Author
+	})
+	return &entity.RepositoryPullRequestMetrics{
+		Repository:         repoName,
+		PullRequestMetrics: pullRequestMetrics(pullRequests),
+		Authors:            authors,
+	}, nil
+}
+
+func pullRequestMetrics(pullRequests []*database.PullRequest) entity.PullRequestMetrics {
+	metrics := entity.PullRequestMetrics{Total: len(pullRequests)}
+	timesToMerge := []time.Duration{}
+	for _, pullRequest := range pullRequests {
+		switch {
+		case pullRequest.MergedAt != "":
+			metrics.Merged++
+			if timeToMerge, ok := timeBetween(pullRequest.RemoteCreatedAt, pullRequest.MergedAt); ok {
+				timesToMerge = append(timesToMerge, timeToMerge)
+			}
+		case pullRequest.State == "open":
+			metrics.Open++
+		default:
+			metrics.ClosedUnmerged++
+		}
+	}
+	if closed := metrics.Merged + metrics.ClosedUnmerged; closed > 0 {
+		metrics.MergeRate = float64(metrics.Merged) / float64(closed)
+	}
+	metrics.TimeToMerge = durationDistribution(timesToMerge)
+	return metrics
+}
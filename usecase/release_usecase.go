@@ -0,0 +1,155 @@
+package usecase
+
+import (
+	"sort"
+
+	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
+	"github.com/midedickson/github-service/interface/repository"
+)
+
+type ReleaseUseCase interface {
+	GetRepositoryReleases(owner, repoName string) ([]*entity.Release, error)
+	GetReleaseTimeline(owner, repoName string) ([]*entity.ReleaseTimelineEntry, error)
+}
+
+type ReleaseUseCaseService struct {
+	releaseRepository repository.ReleaseRepository
+	commitRepository  repository.CommitRepository
+	branchRepository  repository.BranchRepository
+	repoRepository    repository.RepoRepository
+	userUseCase       UserUseCase
+}
+
+func NewReleaseUseCaseService(releaseRepository repository.ReleaseRepository, commitRepository repository.CommitRepository, branchRepository repository.BranchRepository, repoRepository repository.RepoRepository, userUseCase UserUseCase) *ReleaseUseCaseService {
+	return &ReleaseUseCaseService{releaseRepository: releaseRepository, commitRepository: commitRepository, branchRepository: branchRepository, repoRepository: repoRepository, userUseCase: userUseCase}
+}
+
+func (r *ReleaseUseCaseService) GetRepositoryReleases(owner, repoName string) ([]*entity.Release, error) {
+	repo, err := findRepository(r.userUseCase, r.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := r.releaseRepository.GetRepositoryReleases(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	releaseEntities := make([]*entity.Release, len(releases))
+	for i, release := range releases {
+		releaseEntities[i] = release.ToEntity()
+	}
+	return releaseEntities, nil
+}
+
+func (r *ReleaseUseCaseService) GetReleaseTimeline(owner, repoName string) ([]*entity.ReleaseTimelineEntry, error) {
+	// Logic to pair each published release with the commits made since the release before it
+	repo, err := findRepository(r.userUseCase, r.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	releases, err := r.releaseRepository.GetRepositoryReleases(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	published := []*database.Release{}
+	for _, release := range releases {
+		if !release.Draft && release.PublishedAt != "" {
+			published = append(published, release)
+		}
+	}
+	tags, err := r.branchRepository.GetRepositoryTags(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	tagSHAs := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		tagSHAs[tag.Name] = tag.SHA
+	}
+	history, err := r.commitRepository.GetRepositoryCommits(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	commitsBySHA := make(map[string]*database.Commit, len(history))
+	for _, commit := range history {
+		commitsBySHA[commit.SHA] = commit
+	}
+
+	timeline := make([]*entity.ReleaseTimelineEntry, len(published))
+	for i, release := range published {
+		// releases are newest first, so the release before this one is the next in the list
+		var previous *database.Release
+		if i+1 < len(published) {
+			previous = published[i+1]
+		}
+		entry := &entity.ReleaseTimelineEntry{Release: release.ToEntity(), CommitRange: entity.CommitRangeTags}
+		commits, ok := commitsBetweenTags(commitsBySHA, tagSHAs, previous, release)
+		if !ok {
+			previousPublishedAt := ""
+			if previous != nil {
+				previousPublishedAt = previous.PublishedAt
+			}
+			entry.CommitRange = entity.CommitRangeDates
+			commits, err = r.commitRepository.GetRepositoryCommitsBetween(repo.ID, previousPublishedAt, release.PublishedAt)
+			if err != nil {
+				return nil, err
+			}
+		}
+		entry.Commits = make([]*entity.Commit, len(commits))
+		for j, commit := range commits {
+			entry.Commits[j] = commit.ToEntity()
+		}
+		timeline[i] = entry
+	}
+	return timeline, nil
+}
+
+// commitsBetweenTags walks the history back from the tag of release, stopping at the history of the
+// previous release's tag, and returns the commits newest first. It reports false when either tag or
+// the parents of a commit on the way were not synced, as the range cannot be worked out then.
+func commitsBetweenTags(commitsBySHA map[string]*database.Commit, tagSHAs map[string]string, previous, release *database.Release) ([]*database.Commit, bool) {
+	head, ok := tagSHAs[release.TagName]
+	if !ok || commitsBySHA[head] == nil {
+		return nil, false
+	}
+	released := map[string]bool{}
+	if previous != nil {
+		base, ok := tagSHAs[previous.TagName]
+		if !ok || commitsBySHA[base] == nil {
+			return nil, false
+		}
+		if _, ok := walkHistory(commitsBySHA, base, nil, released); !ok {
+			return nil, false
+		}
+	}
+	commits, ok := walkHistory(commitsBySHA, head, released, map[string]bool{})
+	if !ok {
+		return nil, false
+	}
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].Date > commits[j].Date
+	})
+	return commits, true
+}
+
+// walkHistory collects head and its ancestors, leaving out those in exclude and marking the rest in
+// seen. Ancestors older than what was synced are not stored, and end the walk along their line.
+func walkHistory(commitsBySHA map[string]*database.Commit, head string, exclude, seen map[string]bool) ([]*database.Commit, bool) {
+	commits := []*database.Commit{}
+	pending := []string{head}
+	for len(pending) > 0 {
+		sha := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		commit := commitsBySHA[sha]
+		if seen[sha] || exclude[sha] || commit == nil {
+			continue
+		}
+		parents, ok := commit.ParentSHAs()
+		if !ok {
+			return nil, false
+		}
+		seen[sha] = true
+		commits = append(commits, commit)
+		pending = append(pending, parents...)
+	}
+	return commits, true
+}
@@ -2,8 +2,10 @@ package usecase
 
 import (
 	"log"
+	"math"
 
 	"github.com/midedickson/github-service/entity"
+	"github.com/midedickson/github-service/interface/database"
 	"github.com/midedickson/github-service/interface/repository"
 	tasks "github.com/midedickson/github-service/interface/task-manager"
 	"github.com/midedickson/github-service/utils"
@@ -12,16 +14,21 @@ import (
 type RepoUseCase interface {
 	GetRepositoryInfo(owner, repoName string) (*entity.Repository, error)
 	GetUserRepositories(username string, repoSearchParams *utils.RepositorySearchParams) ([]*entity.Repository, error)
+	GetRepositoryBranches(owner, repoName string) ([]*entity.Branch, error)
+	GetRepositoryTags(owner, repoName string) ([]*entity.Tag, error)
+	GetRepositoryLanguages(owner, repoName string) (*entity.LanguageBreakdown, error)
+	GetOwnerLanguages(owner string) (*entity.LanguageBreakdown, error)
 }
 
 type RepoUseCaseService struct {
-	repoRepository repository.RepoRepository
-	userUseCase    UserUseCase
-	task           tasks.Task
+	repoRepository   repository.RepoRepository
+	branchRepository repository.BranchRepository
+	userUseCase      UserUseCase
+	task             tasks.Task
 }
 
-func NewRepoUseCaseService(repoRepository repository.RepoRepository, userUseCase UserUseCase, task tasks.Task) *RepoUseCaseService {
-	return &RepoUseCaseService{repoRepository: repoRepository, userUseCase: userUseCase, task: task}
+func NewRepoUseCaseService(repoRepository repository.RepoRepository, branchRepository repository.BranchRepository, userUseCase UserUseCase, task tasks.Task) *RepoUseCaseService {
+	return &RepoUseCaseService{repoRepository: repoRepository, branchRepository: branchRepository, userUseCase: userUseCase, task: task}
 }
 
 func (r *RepoUseCaseService) GetRepositoryInfo(username, repoName string) (*entity.Repository, error) {
@@ -34,7 +41,12 @@ func (r *RepoUseCaseService) GetRepositoryInfo(username, repoName string) (*enti
 		return nil, err
 	}
 	if repo == nil {
-		go r.task.AddRequestToFetchNewlyRequestedRepoQueue(user.Username, repoName)
+		// surface why an earlier attempt to fetch this repository failed, if it did;
+		// the error is forgotten, so asking again retries the fetch
+		if err := r.task.TakeRepoRequestError(user.Handle(), repoName); err != nil {
+			return nil, err
+		}
+		go r.task.AddRequestToFetchNewlyRequestedRepoQueue(user.Handle(), repoName)
 		return nil, nil
 	}
 	return repo.ToEntity(), nil
@@ -57,3 +69,81 @@ func (r *RepoUseCaseService) GetUserRepositories(username string, repoSearchPara
 	}
 	return repositoryEntities, nil
 }
+
+func (r *RepoUseCaseService) GetRepositoryBranches(owner, repoName string) ([]*entity.Branch, error) {
+	repo, err := findRepository(r.userUseCase, r.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	branches, err := r.branchRepository.GetRepositoryBranches(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	branchEntities := make([]*entity.Branch, len(branches))
+	for i, branch := range branches {
+		branchEntities[i] = branch.ToEntity()
+	}
+	return branchEntities, nil
+}
+
+func (r *RepoUseCaseService) GetRepositoryTags(owner, repoName string) ([]*entity.Tag, error) {
+	repo, err := findRepository(r.userUseCase, r.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	tags, err := r.branchRepository.GetRepositoryTags(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	tagEntities := make([]*entity.Tag, len(tags))
+	for i, tag := range tags {
+		tagEntities[i] = tag.ToEntity()
+	}
+	return tagEntities, nil
+}
+
+func (r *RepoUseCaseService) GetRepositoryLanguages(owner, repoName string) (*entity.LanguageBreakdown, error) {
+	repo, err := findRepository(r.userUseCase, r.repoRepository, owner, repoName)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := r.repoRepository.GetRepositoryLanguages(repo.ID)
+	if err != nil {
+		return nil, err
+	}
+	breakdown := languageBreakdown(languages)
+	breakdown.Owner = owner
+	breakdown.Repository = repoName
+	return breakdown, nil
+}
+
+func (r *RepoUseCaseService) GetOwnerLanguages(owner string) (*entity.LanguageBreakdown, error) {
+	// the languages of every repository of the owner, summed
+	user, err := r.userUseCase.GetUser(owner)
+	if err != nil {
+		return nil, err
+	}
+	languages, err := r.repoRepository.GetOwnerLanguages(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	breakdown := languageBreakdown(languages)
+	breakdown.Owner = owner
+	return breakdown, nil
+}
+
+// languageBreakdown works out the share of each language, keeping the order they were stored in.
+func languageBreakdown(languages []*database.RepositoryLanguage) *entity.LanguageBreakdown {
+	breakdown := &entity.LanguageBreakdown{Languages: make([]*entity.LanguageShare, len(languages))}
+	for _, language := range languages {
+		breakdown.Bytes += language.Bytes
+	}
+	for i, language := range languages {
+		share := language.ToEntity()
+		if breakdown.Bytes > 0 {
+			share.Percentage = math.Round(float64(share.Bytes)*10000/float64(breakdown.Bytes)) / 100
+		}
+		breakdown.Languages[i] = share
+	}
+	return breakdown
+}
@@ -1,17 +1,19 @@
 package usecase
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/midedickson/github-service/dto"
 	"github.com/midedickson/github-service/entity"
 	"github.com/midedickson/github-service/interface/repository"
 	tasks "github.com/midedickson/github-service/interface/task-manager"
+	"github.com/midedickson/github-service/utils"
 )
 
 type UserUseCase interface {
 	CreateUser(createUserPayload *dto.CreateUserPayloadDTO) (*entity.User, error)
 	GetUser(username string) (*entity.User, error)
+	GetUsers(ownerType string) ([]*entity.User, error)
 }
 
 type UserUseCaseService struct {
@@ -39,7 +41,19 @@ func (u *UserUseCaseService) GetUser(username string) (*entity.User, error) {
 		return nil, err
 	}
 	if dbUser == nil {
-		return nil, errors.New("user with the username " + username + "not found")
+		return nil, fmt.Errorf("user with the username %s: %w", username, utils.ErrUserNotFound)
 	}
 	return dbUser.ToEntity(), nil
 }
+
+func (u *UserUseCaseService) GetUsers(ownerType string) ([]*entity.User, error) {
+	dbUsers, err := u.userRepository.GetUsers(ownerType)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*entity.User, len(dbUsers))
+	for i, dbUser := range dbUsers {
+		users[i] = dbUser.ToEntity()
+	}
+	return users, nil
+}
@@ -1,5 +1,85 @@
 package utils
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+)
 
 var ErrRepoNotFound = errors.New("repo not found on github")
+
+var ErrUserNotFound = errors.New("user not found")
+
+// ErrNotSupported is returned by requesters for data their forge does not offer, or that they do not fetch yet.
+var ErrNotSupported = errors.New("not supported by this provider")
+
+// ErrStatsPending is returned while GitHub is still computing a repository's statistics; ask again later.
+var ErrStatsPending = errors.New("github is still computing the statistics")
+
+// kinds of failure reported by upstream calls, wrapped in an UpstreamError
+var (
+	ErrUnauthorized        = errors.New("github rejected our credentials")
+	ErrForbidden           = errors.New("github refused access to the resource")
+	ErrRateLimited         = errors.New("github rate limit exceeded")
+	ErrInvalidRequest      = errors.New("github rejected the request as invalid")
+	ErrUpstreamUnavailable = errors.New("github is unavailable")
+	ErrInvalidResponse     = errors.New("github returned an invalid response")
+)
+
+// UpstreamError describes a failed call to GitHub. It matches its Kind, and
+// the underlying cause if there is one, with errors.Is.
+type UpstreamError struct {
+	Kind       error
+	StatusCode int
+	URL        string
+	// Message is the explanation GitHub gave in the response body, if any.
+	Message string
+	Err     error
+}
+
+func (e *UpstreamError) Error() string {
+	msg := e.Kind.Error()
+	if e.StatusCode != 0 {
+		msg = fmt.Sprintf("%s (status %d)", msg, e.StatusCode)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if e.Err != nil {
+		msg += ": " + e.Err.Error()
+	}
+	return msg
+}
+
+func (e *UpstreamError) Unwrap() []error {
+	if e.Err != nil {
+		return []error{e.Kind, e.Err}
+	}
+	return []error{e.Kind}
+}
+
+// errorStatuses maps error kinds to the HTTP status and error code returned to our clients.
+var errorStatuses = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{ErrRepoNotFound, http.StatusNotFound, "not_found"},
+	{ErrUserNotFound, http.StatusNotFound, "user_not_found"},
+	{ErrRateLimited, http.StatusTooManyRequests, "rate_limited"},
+	{ErrForbidden, http.StatusForbidden, "forbidden"},
+	{ErrUnauthorized, http.StatusBadGateway, "upstream_unauthorized"},
+	{ErrInvalidRequest, http.StatusUnprocessableEntity, "invalid_request"},
+	{ErrUpstreamUnavailable, http.StatusServiceUnavailable, "upstream_unavailable"},
+	{ErrInvalidResponse, http.StatusBadGateway, "invalid_upstream_response"},
+}
+
+// ErrorStatus returns the HTTP status and error code for err, defaulting to 500.
+func ErrorStatus(err error) (int, string) {
+	for _, e := range errorStatuses {
+		if errors.Is(err, e.err) {
+			return e.status, e.code
+		}
+	}
+	return http.StatusInternalServerError, "internal_error"
+}
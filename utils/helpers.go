@@ -1,11 +1,16 @@
 package utils
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gorilla/mux"
+	"github.com/midedickson/github-service/entity"
 )
 
 // get a path param from request
@@ -18,7 +23,7 @@ func GetPathParam(r *http.Request, name string) (string, error) {
 	return value, nil
 }
 
-func ParseRepoSearchQueryParams(r *http.Request, repoSearchParams *RepositorySearchParams) {
+func ParseRepoSearchQueryParams(r *http.Request, repoSearchParams *RepositorySearchParams) error {
 	query := r.URL.Query()
 	if query.Get("name") != "" {
 		repoSearchParams.Name = query.Get("name")
@@ -29,4 +34,79 @@ func ParseRepoSearchQueryParams(r *http.Request, repoSearchParams *RepositorySea
 	if query.Get("top_stars") != "" {
 		repoSearchParams.TopStarsCount, _ = strconv.Atoi(query.Get("top_stars"))
 	}
+	if query.Get("topic") != "" {
+		repoSearchParams.Topic = query.Get("topic")
+	}
+	var err error
+	if repoSearchParams.Fork, err = parseBoolQueryParam(r, "fork"); err != nil {
+		return err
+	}
+	if repoSearchParams.Archived, err = parseBoolQueryParam(r, "archived"); err != nil {
+		return err
+	}
+	if query.Get("visibility") != "" {
+		repoSearchParams.Visibility = query.Get("visibility")
+		if !entity.IsVisibility(repoSearchParams.Visibility) {
+			return errors.New("visibility must be one of " + strings.Join(entity.Visibilities, ", "))
+		}
+	}
+	if query.Get("license") != "" {
+		repoSearchParams.License = query.Get("license")
+	}
+	if query.Get("default_branch") != "" {
+		repoSearchParams.DefaultBranch = query.Get("default_branch")
+	}
+	if query.Get("pushed_after") != "" {
+		// a date alone is taken as midnight UTC
+		pushedAfter, err := time.Parse(time.RFC3339, query.Get("pushed_after"))
+		if err != nil {
+			pushedAfter, err = time.Parse(time.DateOnly, query.Get("pushed_after"))
+		}
+		if err != nil {
+			return errors.New("pushed_after must be a date or an RFC 3339 time")
+		}
+		repoSearchParams.PushedAfter = pushedAfter.UTC().Format(time.RFC3339)
+	}
+	return nil
+}
+
+// parseBoolQueryParam reads a true or false query parameter, which is nil when it is absent.
+func parseBoolQueryParam(r *http.Request, name string) (*bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.ParseBool(raw)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be true or false", name)
+	}
+	return &value, nil
+}
+
+func ParseIssueSearchQueryParams(r *http.Request, issueSearchParams *IssueSearchParams) {
+	query := r.URL.Query()
+	if query.Get("state") != "" {
+		issueSearchParams.State = query.Get("state")
+	}
+	if query.Get("label") != "" {
+		issueSearchParams.Label = query.Get("label")
+	}
+	if query.Get("author") != "" {
+		issueSearchParams.Author = query.Get("author")
+	}
+	if query.Get("assignee") != "" {
+		issueSearchParams.Assignee = query.Get("assignee")
+	}
+}
+
+// SleepWithContext pauses for d, returning the context's error early if it is cancelled first.
+func SleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
 }
@@ -14,6 +14,24 @@ func Dispatch500Error(w http.ResponseWriter, err error) {
 	w.Write(WriteError(fmt.Sprintf("%v", err), nil))
 }
 
+// error mapped to its HTTP status and error code, 500 when it is not a known kind
+func DispatchError(w http.ResponseWriter, err error) {
+	status, code := ErrorStatus(err)
+	AddDefaultHeaders(w)
+	w.WriteHeader(status)
+	response := APIResponse{
+		Success: false,
+		Message: fmt.Sprintf("%v", err),
+		Code:    code,
+	}
+	data, marshalErr := json.Marshal(response)
+	if marshalErr != nil {
+		log.Printf("err: %s", marshalErr)
+		return
+	}
+	w.Write(data)
+}
+
 // 400 - bad request
 func Dispatch400Error(w http.ResponseWriter, msg string, err any) {
 	AddDefaultHeaders(w)
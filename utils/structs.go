@@ -3,11 +3,28 @@ package utils
 type APIResponse struct {
 	Success bool        `json:"success"`
 	Message string      `json:"message"`
+	Code    string      `json:"code,omitempty"`
 	Data    interface{} `json:"data"`
 }
 
+type IssueSearchParams struct {
+	State    string `json:"state"`
+	Label    string `json:"label"`
+	Author   string `json:"author"`
+	Assignee string `json:"assignee"`
+}
+
 type RepositorySearchParams struct {
 	Name          string `json:"name"`
 	Language      string `json:"language"`
 	TopStarsCount int    `json:"stars_count"`
+	Topic         string `json:"topic"`
+	// Fork and Archived only filter when set
+	Fork          *bool  `json:"fork"`
+	Archived      *bool  `json:"archived"`
+	Visibility    string `json:"visibility"`
+	License       string `json:"license"`
+	DefaultBranch string `json:"default_branch"`
+	// PushedAfter is an RFC 3339 time in UTC
+	PushedAfter string `json:"pushed_after"`
 }